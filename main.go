@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 	"runtime/debug"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -10,6 +11,9 @@ import (
 	"github.com/narasux/jutland/pkg/common/constants"
 	"github.com/narasux/jutland/pkg/config"
 	"github.com/narasux/jutland/pkg/game"
+	"github.com/narasux/jutland/pkg/mission/netplay"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/sim"
 )
 
 func main() {
@@ -19,11 +23,37 @@ func main() {
 	// 加载游戏设置
 	config.LoadGameSettings()
 
+	// 无界面模拟：jutland sim --mission xxx --ticks 36000
+	if len(os.Args) > 1 && os.Args[1] == sim.Command {
+		if err := sim.Run(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ebiten.SetTPS(constants.MaxTPS)
 	ebiten.SetFullscreen(true)
 	ebiten.SetWindowTitle("Jutland - Powered by Ebitengine")
 
-	if err := ebiten.RunGame(game.New()); err != nil {
+	g := game.New()
+	// 回放录像：jutland replay replays/xxx.jrp
+	if len(os.Args) > 2 && os.Args[1] == replay.Command {
+		rp, err := replay.Load(os.Args[2])
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.StartReplay(rp)
+	}
+	// 局域网联机：jutland host --mission xxx --addr :7777 / jutland join --addr 192.168.1.2:7777
+	if len(os.Args) > 1 && (os.Args[1] == netplay.HostCommand || os.Args[1] == netplay.JoinCommand) {
+		session, err := netplay.Connect(os.Args[1], os.Args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.StartNetplay(session)
+	}
+
+	if err := ebiten.RunGame(g); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -13,6 +13,7 @@ const (
 )
 
 // WeaponFire 统一管理武器发射相关短音效，并对高频音效做冷却去重。
+// nil 播放器表示静音（如无界面模拟），所有方法均可安全调用。
 type WeaponFire struct {
 	rocketSpawnAudioCD   int
 	rocketExplodeAudioCD int
@@ -25,6 +26,9 @@ func NewWeaponFire() *WeaponFire {
 
 // Update 推进音效冷却计数，应每帧调用一次。
 func (p *WeaponFire) Update() {
+	if p == nil {
+		return
+	}
 	if p.rocketSpawnAudioCD > 0 {
 		p.rocketSpawnAudioCD--
 	}
@@ -35,6 +39,9 @@ func (p *WeaponFire) Update() {
 
 // PlayShipFire 按战舰本帧发射事件播放音效，炮声优先按最大口径结算。
 func (p *WeaponFire) PlayShipFire(maxBulletDiameter int, torpedoLaunched, rocketLaunched bool) {
+	if p == nil {
+		return
+	}
 	if maxBulletDiameter > 0 {
 		baseAudio.PlayAudioToEnd(audioRes.NewGunFire(maxBulletDiameter))
 	}
@@ -47,6 +54,9 @@ func (p *WeaponFire) PlayShipFire(maxBulletDiameter int, torpedoLaunched, rocket
 
 // PlayPlaneFire 按战机本帧投弹、火箭或鱼雷发射事件播放音效。
 func (p *WeaponFire) PlayPlaneFire(bombReleased, rocketLaunched, torpedoLaunched bool) {
+	if p == nil {
+		return
+	}
 	if bombReleased {
 		baseAudio.PlayAudioToEnd(audioRes.NewBombSpawn())
 	} else if rocketLaunched {
@@ -58,6 +68,9 @@ func (p *WeaponFire) PlayPlaneFire(bombReleased, rocketLaunched, torpedoLaunched
 
 // PlayRocketSpawn 播放受冷却控制的火箭发射音，避免密集连发时声音堆叠。
 func (p *WeaponFire) PlayRocketSpawn() {
+	if p == nil {
+		return
+	}
 	if p.rocketSpawnAudioCD > 0 {
 		return
 	}
@@ -67,6 +80,9 @@ func (p *WeaponFire) PlayRocketSpawn() {
 
 // PlayRocketExplode 播放受冷却控制的火箭爆炸音，避免空爆密集时声音堆叠。
 func (p *WeaponFire) PlayRocketExplode() {
+	if p == nil {
+		return
+	}
 	if p.rocketExplodeAudioCD > 0 {
 		return
 	}
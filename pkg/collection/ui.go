@@ -1543,6 +1543,12 @@ func shipArchiveInfoItems(ship *objUnit.BattleShip, ref *objRef.Reference) []obj
 			Label: i18n.Text(i18n.MsgCollectionVerticalDR),
 			Value: formatShipArchiveNumber(ship.VerticalDamageReduction*100) + "%",
 		},
+		{
+			Label: i18n.Text(i18n.MsgCollectionArmor),
+			Value: i18n.Format(i18n.MsgValueArmor, map[string]any{
+				"Belt": formatShipArchiveNumber(ship.BeltArmor), "Deck": formatShipArchiveNumber(ship.DeckArmor),
+			}),
+		},
 	}
 }
 
@@ -57,6 +57,8 @@ type GameSettings struct {
 	SpeedMultiplier float64 `json:"speedMultiplier"`
 	// Language 游戏界面语言，当前正式启用 zh-Hans / en / ru / ja。
 	Language string `json:"language"`
+	// KeyBindings 任务操作的按键绑定，按键名称与 ebiten.Key 的名称一致（如 Q / Digit1 / BracketLeft / ArrowUp）
+	KeyBindings map[KeyAction]string `json:"keyBindings"`
 }
 
 // G 游戏设置全局变量
@@ -67,6 +69,7 @@ func NewDefaultGameSettings() *GameSettings {
 	return &GameSettings{
 		SpeedMultiplier: 1.0,
 		Language:        "zh-Hans",
+		KeyBindings:     DefaultKeyBindings(),
 	}
 }
 
@@ -83,6 +86,7 @@ func (s *GameSettings) validate() {
 	default:
 		s.Language = "zh-Hans"
 	}
+	s.KeyBindings = validateKeyBindings(s.KeyBindings)
 }
 
 // LoadGameSettings 加载游戏设置配置文件
@@ -155,6 +159,11 @@ func SaveGameSettings() error {
 	)
 	_, _ = file.WriteString("// 范围: 0.25 ~ 4.0，默认值: 1.0\n\n")
 	_, _ = file.WriteString("// Language: 游戏界面语言，当前正式启用 zh-Hans / en / ru / ja\n\n")
+	_, _ = file.WriteString(
+		"// KeyBindings: 任务操作的按键绑定（可在设置界面修改），按键名称与 ebiten.Key 一致，" +
+			"如 Q / Digit1 / BracketLeft / ArrowUp；Shift / Ctrl / Alt 等修饰键只能绑定到编组 / 终端修饰键，" +
+			"不同界面（战斗 / 暂停 / 地图 / 增援点）的操作可以绑定同一按键\n\n",
+	)
 
 	// 编码并写入配置
 	data, err := json5.MarshalIndent(G, "", "  ")
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// KeyAction 可以绑定按键的任务操作
+type KeyAction string
+
+const (
+	// 武器开关（按住 Shift 时为切换目标策略）
+	KeyActionToggleAllWeapons      KeyAction = "toggleAllWeapons"
+	KeyActionToggleMainGun         KeyAction = "toggleMainGun"
+	KeyActionToggleSecondaryGun    KeyAction = "toggleSecondaryGun"
+	KeyActionToggleAntiAircraftGun KeyAction = "toggleAntiAircraftGun"
+	KeyActionToggleTorpedo         KeyAction = "toggleTorpedo"
+	KeyActionToggleRocket          KeyAction = "toggleRocket"
+	// 交战
+	KeyActionFireStance    KeyAction = "fireStance"
+	KeyActionStandOff      KeyAction = "standOff"
+	KeyActionEngineFaster  KeyAction = "engineFaster"
+	KeyActionEngineSlower  KeyAction = "engineSlower"
+	KeyActionDamageControl KeyAction = "damageControl"
+	// 移动 & 命令
+	KeyActionAttackMove  KeyAction = "attackMove"
+	KeyActionScatter     KeyAction = "scatter"
+	KeyActionFormation   KeyAction = "formation"
+	KeyActionPatrol      KeyAction = "patrol"
+	KeyActionGuard       KeyAction = "guard"
+	KeyActionCancelOrder KeyAction = "cancelOrder"
+	KeyActionMoveUp      KeyAction = "moveUp"
+	KeyActionMoveDown    KeyAction = "moveDown"
+	KeyActionMoveLeft    KeyAction = "moveLeft"
+	KeyActionMoveRight   KeyAction = "moveRight"
+	// 舰载机
+	KeyActionAirStrike    KeyAction = "airStrike"
+	KeyActionAirPatrol    KeyAction = "airPatrol"
+	KeyActionHoldAircraft KeyAction = "holdAircraft"
+	// 选择
+	KeyActionSelectAll         KeyAction = "selectAll"
+	KeyActionCycleIdleShips    KeyAction = "cycleIdleShips"
+	KeyActionCycleDamagedShips KeyAction = "cycleDamagedShips"
+	// 编队（按住左 Ctrl 时为编组，按住 Shift 时追加选中）
+	KeyActionGroup1 KeyAction = "group1"
+	KeyActionGroup2 KeyAction = "group2"
+	KeyActionGroup3 KeyAction = "group3"
+	KeyActionGroup4 KeyAction = "group4"
+	KeyActionGroup5 KeyAction = "group5"
+	KeyActionGroup6 KeyAction = "group6"
+	KeyActionGroup7 KeyAction = "group7"
+	KeyActionGroup8 KeyAction = "group8"
+	KeyActionGroup9 KeyAction = "group9"
+	KeyActionGroup0 KeyAction = "group0"
+	// 任务
+	KeyActionMap       KeyAction = "map"
+	KeyActionReinforce KeyAction = "reinforce"
+	KeyActionPause     KeyAction = "pause"
+	// 战术暂停（模拟停止，仍可选择战舰 & 下达命令）
+	KeyActionTacticalPause KeyAction = "tacticalPause"
+	// 终端（需要同时按住编组键 + 终端修饰键）
+	KeyActionTerminal KeyAction = "terminal"
+	// 暂停界面
+	KeyActionQuitMission  KeyAction = "quitMission"
+	KeyActionSaveProgress KeyAction = "saveProgress"
+	// 返回（退出全屏地图 / 增援点 / 终端）
+	KeyActionBack KeyAction = "back"
+	// 增援点界面
+	KeyActionReinforceNextPoint KeyAction = "reinforceNextPoint"
+	KeyActionReinforcePrevPoint KeyAction = "reinforcePrevPoint"
+	KeyActionReinforcePrevShip  KeyAction = "reinforcePrevShip"
+	KeyActionReinforceNextShip  KeyAction = "reinforceNextShip"
+	KeyActionReinforceConfirm   KeyAction = "reinforceConfirm"
+	KeyActionReinforceCancel    KeyAction = "reinforceCancel"
+	// 按住的修饰键（可以绑定 Shift / Ctrl / Alt）
+	KeyActionGroupModifier    KeyAction = "groupModifier"
+	KeyActionTerminalModifier KeyAction = "terminalModifier"
+)
+
+// keyContext 操作生效的任务界面，不同界面的操作可以绑定同一按键
+type keyContext uint8
+
+const (
+	// 战斗（含战术暂停）
+	keyContextBattle keyContext = 1 << iota
+	// 暂停界面
+	keyContextPaused
+	// 全屏地图
+	keyContextMap
+	// 增援点界面
+	keyContextBuilding
+	// 终端
+	keyContextTerminal
+)
+
+// 战斗 / 全屏地图 / 增援点界面都可以使用的操作（切换地图 / 增援点，打开终端）
+const keyContextOverlay = keyContextBattle | keyContextMap | keyContextBuilding
+
+// keyActionDefaults 各操作的默认按键 & 生效的界面（按设置界面展示顺序），按键名称与 ebiten.Key 的名称一致
+var keyActionDefaults = []struct {
+	Action  KeyAction
+	Key     string
+	Context keyContext
+}{
+	{KeyActionToggleAllWeapons, "Q", keyContextBattle},
+	{KeyActionToggleMainGun, "W", keyContextBattle},
+	{KeyActionToggleSecondaryGun, "E", keyContextBattle},
+	{KeyActionToggleAntiAircraftGun, "R", keyContextBattle},
+	{KeyActionToggleTorpedo, "T", keyContextBattle},
+	{KeyActionToggleRocket, "Y", keyContextBattle},
+	{KeyActionFireStance, "H", keyContextBattle},
+	{KeyActionStandOff, "K", keyContextBattle},
+	{KeyActionEngineFaster, "BracketRight", keyContextBattle},
+	{KeyActionEngineSlower, "BracketLeft", keyContextBattle},
+	{KeyActionDamageControl, "D", keyContextBattle},
+	{KeyActionAttackMove, "A", keyContextBattle},
+	{KeyActionScatter, "X", keyContextBattle},
+	{KeyActionFormation, "F", keyContextBattle},
+	{KeyActionPatrol, "P", keyContextBattle},
+	{KeyActionGuard, "G", keyContextBattle},
+	{KeyActionCancelOrder, "Backspace", keyContextBattle},
+	{KeyActionMoveUp, "ArrowUp", keyContextBattle},
+	{KeyActionMoveDown, "ArrowDown", keyContextBattle},
+	{KeyActionMoveLeft, "ArrowLeft", keyContextBattle},
+	{KeyActionMoveRight, "ArrowRight", keyContextBattle},
+	{KeyActionAirStrike, "V", keyContextBattle},
+	{KeyActionAirPatrol, "C", keyContextBattle},
+	{KeyActionHoldAircraft, "Z", keyContextBattle},
+	{KeyActionSelectAll, "F2", keyContextBattle},
+	{KeyActionCycleIdleShips, "Period", keyContextBattle},
+	{KeyActionCycleDamagedShips, "Comma", keyContextBattle},
+	{KeyActionGroup1, "Digit1", keyContextBattle},
+	{KeyActionGroup2, "Digit2", keyContextBattle},
+	{KeyActionGroup3, "Digit3", keyContextBattle},
+	{KeyActionGroup4, "Digit4", keyContextBattle},
+	{KeyActionGroup5, "Digit5", keyContextBattle},
+	{KeyActionGroup6, "Digit6", keyContextBattle},
+	{KeyActionGroup7, "Digit7", keyContextBattle},
+	{KeyActionGroup8, "Digit8", keyContextBattle},
+	{KeyActionGroup9, "Digit9", keyContextBattle},
+	{KeyActionGroup0, "Digit0", keyContextBattle},
+	{KeyActionMap, "M", keyContextOverlay},
+	{KeyActionReinforce, "B", keyContextOverlay},
+	{KeyActionPause, "Escape", keyContextBattle | keyContextPaused},
+	{KeyActionTacticalPause, "Space", keyContextBattle},
+	{KeyActionTerminal, "Backquote", keyContextOverlay},
+	{KeyActionQuitMission, "Q", keyContextPaused},
+	{KeyActionSaveProgress, "S", keyContextPaused},
+	{KeyActionBack, "Escape", keyContextMap | keyContextBuilding | keyContextTerminal},
+	{KeyActionReinforceNextPoint, "ArrowUp", keyContextBuilding},
+	{KeyActionReinforcePrevPoint, "ArrowDown", keyContextBuilding},
+	{KeyActionReinforcePrevShip, "ArrowLeft", keyContextBuilding},
+	{KeyActionReinforceNextShip, "ArrowRight", keyContextBuilding},
+	{KeyActionReinforceConfirm, "Enter", keyContextBuilding},
+	{KeyActionReinforceCancel, "Backspace", keyContextBuilding},
+	{KeyActionGroupModifier, "ControlLeft", keyContextBattle},
+	{KeyActionTerminalModifier, "ShiftLeft", keyContextOverlay},
+}
+
+// modifierKeys 作为组合键修饰的按键（Shift 排队 / 切换目标策略，Ctrl 取消选中，Alt 巡逻航点），只能绑定到修饰键操作
+var modifierKeys = []string{
+	"shift", "shiftleft", "shiftright",
+	"control", "controlleft", "controlright",
+	"alt", "altleft", "altright",
+	"meta", "metaleft", "metaright",
+}
+
+// KeyActions 所有可以绑定按键的操作（按设置界面展示顺序）
+func KeyActions() []KeyAction {
+	actions := make([]KeyAction, 0, len(keyActionDefaults))
+	for _, d := range keyActionDefaults {
+		actions = append(actions, d.Action)
+	}
+	return actions
+}
+
+// modifierActions 需要按住的修饰键操作，可以绑定 Shift / Ctrl / Alt 等修饰键
+var modifierActions = []KeyAction{KeyActionGroupModifier, KeyActionTerminalModifier}
+
+// DefaultKeyBindings 默认按键绑定
+func DefaultKeyBindings() map[KeyAction]string {
+	bindings := make(map[KeyAction]string, len(keyActionDefaults))
+	for _, d := range keyActionDefaults {
+		bindings[d.Action] = d.Key
+	}
+	return bindings
+}
+
+// IsModifierKey 按键是否为组合键修饰键（不区分大小写）
+func IsModifierKey(key string) bool {
+	return slices.Contains(modifierKeys, strings.ToLower(key))
+}
+
+// IsModifierAction 操作是否为修饰键操作（可以绑定修饰键）
+func IsModifierAction(action KeyAction) bool {
+	return slices.Contains(modifierActions, action)
+}
+
+// KeyBinding 操作当前绑定的按键名称（未加载设置时为默认按键）
+func KeyBinding(action KeyAction) string {
+	if G != nil {
+		if key, ok := G.KeyBindings[action]; ok {
+			return key
+		}
+	}
+	return DefaultKeyBindings()[action]
+}
+
+// KeyBindingConflicts 与同一界面的其他操作绑定了同一按键的操作（按设置界面展示顺序，按键名称不区分大小写）
+func KeyBindingConflicts(bindings map[KeyAction]string) []KeyAction {
+	conflicts := []KeyAction{}
+	for _, d := range keyActionDefaults {
+		key := strings.ToLower(bindings[d.Action])
+		for _, other := range keyActionDefaults {
+			if other.Action != d.Action && other.Context&d.Context != 0 &&
+				strings.ToLower(bindings[other.Action]) == key {
+				conflicts = append(conflicts, d.Action)
+				break
+			}
+		}
+	}
+	return conflicts
+}
+
+// validateKeyBindings 丢弃未知操作，缺失 / 空白的绑定以及普通操作绑定的修饰键恢复为默认按键
+func validateKeyBindings(bindings map[KeyAction]string) map[KeyAction]string {
+	defaults := DefaultKeyBindings()
+	validated := make(map[KeyAction]string, len(defaults))
+	for action, defaultKey := range defaults {
+		key := strings.TrimSpace(bindings[action])
+		if key == "" || (IsModifierKey(key) && !IsModifierAction(action)) {
+			key = defaultKey
+		}
+		validated[action] = key
+	}
+	return validated
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKeyBindings(t *testing.T) {
+	settings := &GameSettings{
+		SpeedMultiplier: 1,
+		Language:        "en",
+		KeyBindings: map[KeyAction]string{
+			KeyActionToggleAllWeapons: "A",
+			KeyActionMap:              " Semicolon ",
+			KeyActionPause:            "ShiftLeft",
+			KeyActionReinforce:        "",
+			KeyActionGroupModifier:    "AltLeft",
+			"unknownAction":           "U",
+		},
+	}
+	settings.validate()
+
+	require.Len(t, settings.KeyBindings, len(KeyActions()))
+	require.Equal(t, "A", settings.KeyBindings[KeyActionToggleAllWeapons])
+	require.Equal(t, "Semicolon", settings.KeyBindings[KeyActionMap])
+	// 修饰键 / 空白绑定恢复为默认按键，未知操作被丢弃
+	require.Equal(t, "Escape", settings.KeyBindings[KeyActionPause])
+	require.Equal(t, "B", settings.KeyBindings[KeyActionReinforce])
+	require.Equal(t, "Digit1", settings.KeyBindings[KeyActionGroup1])
+	// 修饰键操作可以绑定修饰键
+	require.Equal(t, "AltLeft", settings.KeyBindings[KeyActionGroupModifier])
+	require.NotContains(t, settings.KeyBindings, KeyAction("unknownAction"))
+}
+
+func TestKeyBindingConflicts(t *testing.T) {
+	bindings := DefaultKeyBindings()
+	require.Empty(t, KeyBindingConflicts(bindings))
+
+	// 按键名称不区分大小写
+	bindings[KeyActionToggleAllWeapons] = "a"
+	require.Equal(
+		t, []KeyAction{KeyActionToggleAllWeapons, KeyActionAttackMove}, KeyBindingConflicts(bindings),
+	)
+
+	// 不同界面的操作可以绑定同一按键，同一界面内才算冲突
+	bindings = DefaultKeyBindings()
+	bindings[KeyActionReinforceConfirm] = "ArrowUp"
+	require.Equal(
+		t, []KeyAction{KeyActionReinforceNextPoint, KeyActionReinforceConfirm}, KeyBindingConflicts(bindings),
+	)
+	bindings = DefaultKeyBindings()
+	bindings[KeyActionBack] = "M"
+	require.Equal(t, []KeyAction{KeyActionMap, KeyActionBack}, KeyBindingConflicts(bindings))
+}
+
+func TestKeyBindingFallback(t *testing.T) {
+	origin := G
+	t.Cleanup(func() { G = origin })
+
+	G = nil
+	require.Equal(t, "Q", KeyBinding(KeyActionToggleAllWeapons))
+
+	G = NewDefaultGameSettings()
+	G.KeyBindings[KeyActionToggleAllWeapons] = "KeyA"
+	require.Equal(t, "KeyA", KeyBinding(KeyActionToggleAllWeapons))
+}
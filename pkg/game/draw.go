@@ -11,6 +11,8 @@ import (
 
 	"github.com/narasux/jutland/pkg/i18n"
 	"github.com/narasux/jutland/pkg/mission/metadata"
+	"github.com/narasux/jutland/pkg/mission/save"
+	"github.com/narasux/jutland/pkg/mission/state"
 	"github.com/narasux/jutland/pkg/resources/font"
 	abbrMapImg "github.com/narasux/jutland/pkg/resources/images/abbrmap"
 	bgImg "github.com/narasux/jutland/pkg/resources/images/background"
@@ -42,9 +44,18 @@ func (d *Drawer) drawBackground(screen *ebiten.Image, bg *ebiten.Image) {
 	screen.DrawImage(bg, opts)
 }
 
+// 电脑难度名称
+var difficultyMessages = map[state.Difficulty]i18n.MessageID{
+	state.DifficultyEasy:   i18n.MsgDifficultyEasy,
+	state.DifficultyNormal: i18n.MsgDifficultyNormal,
+	state.DifficultyHard:   i18n.MsgDifficultyHard,
+}
+
 func (d *Drawer) drawMissionSelect(
 	screen *ebiten.Image,
 	curMission string,
+	seed int64,
+	difficulty state.Difficulty,
 	category metadata.MissionCategory,
 	states *objStates,
 ) {
@@ -157,7 +168,46 @@ func (d *Drawer) drawMissionSelect(
 		)
 	}
 
-	curY += float64(len(battleLines))*statsLineHeight + 14
+	curY += float64(len(battleLines))*statsLineHeight + 4
+	// 随机种子
+	seedLines := wrapText(i18n.Format(i18n.MsgMissionSeed, map[string]any{"Seed": seed}), statsMaxWidth, statsFontSize)
+	for idx, line := range seedLines {
+		d.drawText(
+			screen, line, panelX, curY+float64(idx)*statsLineHeight,
+			statsFontSize, font.LocalizedUI(font.Kai), subtitleClr,
+		)
+	}
+
+	curY += float64(len(seedLines))*statsLineHeight + 4
+	// 电脑难度
+	difficultyLine := i18n.Format(i18n.MsgMissionDifficulty, map[string]any{
+		"Difficulty": i18n.Text(difficultyMessages[difficulty.OrDefault()]),
+	})
+	difficultyLines := wrapText(difficultyLine, statsMaxWidth, statsFontSize)
+	for idx, line := range difficultyLines {
+		d.drawText(
+			screen, line, panelX, curY+float64(idx)*statsLineHeight,
+			statsFontSize, font.LocalizedUI(font.Kai), subtitleClr,
+		)
+	}
+
+	curY += float64(len(difficultyLines))*statsLineHeight + 4
+	// 任务存档（存在时可以继续任务）
+	if savedAt, ok := save.SavedAt(curMission); ok {
+		savedLine := i18n.Format(i18n.MsgMissionSavedProgress, map[string]any{
+			"Time": savedAt.Format("2006-01-02 15:04"),
+		})
+		savedLines := wrapText(savedLine, statsMaxWidth, statsFontSize)
+		for idx, line := range savedLines {
+			d.drawText(
+				screen, line, panelX, curY+float64(idx)*statsLineHeight,
+				statsFontSize, font.LocalizedUI(font.Kai), labelClr,
+			)
+		}
+		curY += float64(len(savedLines)) * statsLineHeight
+	}
+
+	curY += 10
 	// 描述区
 	descFontSize := 18.0
 	descLineHeight := 28.0
@@ -406,6 +456,19 @@ func (d *Drawer) drawMissionResult(screen *ebiten.Image, textStr string, textCol
 	d.drawText(screen, textStr, posX, posY, fontSize, resultFont, textColor)
 }
 
+// drawReplayHint 任务结束界面提示可以观看本局录像
+func (d *Drawer) drawReplayHint(screen *ebiten.Image, hasReplay bool) {
+	if !hasReplay {
+		return
+	}
+	textStr := i18n.Text(i18n.MsgMissionWatchReplay)
+	fontSize := float64(32)
+	hintFont := font.LocalizedUI(font.Kai)
+	posX := float64(screen.Bounds().Dx()) - layout.CalcTextWidth(textStr, fontSize, hintFont) - 50
+	posY := float64(screen.Bounds().Dy()) / 10 * 9
+	d.drawText(screen, textStr, posX, posY, fontSize, hintFont, colorx.White)
+}
+
 // 绘制鸣谢
 func (d *Drawer) drawCredits(screen *ebiten.Image) {
 	textStr := i18n.Text(i18n.MsgGameCredits)
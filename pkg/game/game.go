@@ -19,7 +19,10 @@ import (
 	"github.com/narasux/jutland/pkg/i18n"
 	"github.com/narasux/jutland/pkg/mission/manager"
 	"github.com/narasux/jutland/pkg/mission/metadata"
+	"github.com/narasux/jutland/pkg/mission/netplay"
 	_ "github.com/narasux/jutland/pkg/mission/object/initialize"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/state"
 	audioRes "github.com/narasux/jutland/pkg/resources/audio"
 	"github.com/narasux/jutland/pkg/resources/font"
 	bgImg "github.com/narasux/jutland/pkg/resources/images/background"
@@ -40,10 +43,22 @@ type Game struct {
 	objStates *objStates
 
 	curMission string
+	// 下一局任务使用的随机种子
+	curSeed int64
+	// 下一局任务的电脑 AI 难度
+	curDifficulty state.Difficulty
 	// 当前任务分类
 	curMissionCategory metadata.MissionCategory
 	// 任务管理
 	missionMgr *manager.MissionManager
+	// 是否读取任务存档继续（而非开始新任务）
+	loadSavedMission bool
+	// 最近一局任务的录像
+	lastReplay *replay.Replay
+	// 退出回放 / 联机对战时恢复游戏设置
+	restoreSettings func()
+	// 联机会话（非联机对战时为 nil）
+	netplaySession *netplay.Session
 	// 设置界面
 	settingUI *settings.UI
 	// 游戏图鉴界面
@@ -62,6 +77,8 @@ func New() *Game {
 		player:             audio.NewPlayer(audio.Context),
 		objStates:          nil,
 		curMission:         "",
+		curSeed:            state.NewRandomSeed(),
+		curDifficulty:      state.DifficultyNormal,
 		curMissionCategory: metadata.MissionCategoryClassic,
 		missionMgr:         nil,
 		settingUI:          settings.New(),
@@ -109,6 +126,8 @@ func (g *Game) Update() error {
 		return g.handleGameCollection()
 	case GameModeGameSetting:
 		return g.handleGameSetting()
+	case GameModeReplay:
+		return g.handleReplay()
 	case GameModeEnd:
 		return g.handleGameEnd()
 	default:
@@ -130,7 +149,9 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.drawer.drawBackground(screen, bgImg.GameMenu)
 		g.drawer.drawGameMenu(screen, g.objStates.MenuButton)
 	case GameModeMissionSelect:
-		g.drawer.drawMissionSelect(screen, g.curMission, g.curMissionCategory, g.objStates)
+		g.drawer.drawMissionSelect(
+			screen, g.curMission, g.curSeed, g.curDifficulty, g.curMissionCategory, g.objStates,
+		)
 	case GameModeMissionLoading:
 		g.drawer.drawBackground(screen, bgImg.MissionStart)
 		g.drawer.drawGameTip(screen, i18n.Text(i18n.MsgLoading))
@@ -141,14 +162,18 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	case GameModeMissionSuccess:
 		g.drawer.drawBackground(screen, bgImg.MissionSuccess)
 		g.drawer.drawMissionResult(screen, i18n.Text(i18n.MsgMissionSuccess), colorx.Green)
+		g.drawer.drawReplayHint(screen, g.lastReplay != nil)
 	case GameModeMissionFailed:
 		g.drawer.drawBackground(screen, bgImg.MissionFailed)
 		g.drawer.drawMissionResult(screen, i18n.Text(i18n.MsgMissionFailed), colorx.Red)
+		g.drawer.drawReplayHint(screen, g.lastReplay != nil)
 	case GameModeCollection:
 		g.drawer.drawBackground(screen, bgImg.MissionWindow)
 		g.collectionUI.Draw(screen)
 	case GameModeGameSetting:
 		g.settingUI.Draw(screen)
+	case GameModeReplay:
+		g.missionMgr.DrawReplay(screen)
 	case GameModeEnd:
 		g.drawer.drawBackground(screen, bgImg.GameEnd)
 		g.drawer.drawCredits(screen)
@@ -193,6 +218,10 @@ func (g *Game) handleUIEscape() bool {
 		g.player.Close()
 		return true
 	case GameModeGameSetting:
+		// 正在等待绑定按键时，Esc 只取消等待，不退出设置界面
+		if g.settingUI.CancelKeyCapture() {
+			return true
+		}
 		g.settingUI.Reset()
 		g.mode = GameModeMenuSelect
 		return true
@@ -327,6 +356,7 @@ func (g *Game) startCollectionBGM() {
 
 // 游戏设置
 func (g *Game) handleGameSetting() error {
+	g.settingUI.Update()
 	if g.settingUI.BackPressed() {
 		g.applyLanguage(config.G.Language)
 		g.settingUI.Reset()
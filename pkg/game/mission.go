@@ -2,7 +2,9 @@ package game
 
 import (
 	"log"
+	"time"
 
+	"github.com/ebitenui/ebitenui"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/samber/lo"
@@ -10,6 +12,9 @@ import (
 	"github.com/narasux/jutland/pkg/audio"
 	"github.com/narasux/jutland/pkg/mission/manager"
 	"github.com/narasux/jutland/pkg/mission/metadata"
+	"github.com/narasux/jutland/pkg/mission/netplay"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/save"
 	"github.com/narasux/jutland/pkg/mission/state"
 	audioRes "github.com/narasux/jutland/pkg/resources/audio"
 )
@@ -64,10 +69,18 @@ func (g *Game) handleMissionSelect() error {
 	}
 
 	g.curMission = cycleMission(missions, g.curMission, offset)
+	g.curSeed = updateMissionSeed(g.curSeed)
+	// D 键切换电脑难度
+	if inpututil.IsKeyJustPressed(ebiten.KeyD) {
+		g.curDifficulty = g.curDifficulty.Next()
+	}
 
-	// 确定：Enter 键或点击「开始任务」
+	// 确定：Enter 键或点击「开始任务」，L 键读取任务存档继续
 	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
 		g.startMissionLoading()
+	} else if _, ok := save.SavedAt(g.curMission); ok && inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.startMissionLoading()
+		g.loadSavedMission = true
 	} else if ui != nil && isHoverArea(ui.StartButton) && isMouseButtonLeftJustPressed() {
 		g.startMissionLoading()
 	} else if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
@@ -78,6 +91,36 @@ func (g *Game) handleMissionSelect() error {
 	return nil
 }
 
+// 随机种子编辑键位（数字键输入）
+var seedDigitKeys = [10]ebiten.Key{
+	ebiten.KeyDigit0, ebiten.KeyDigit1, ebiten.KeyDigit2, ebiten.KeyDigit3, ebiten.KeyDigit4,
+	ebiten.KeyDigit5, ebiten.KeyDigit6, ebiten.KeyDigit7, ebiten.KeyDigit8, ebiten.KeyDigit9,
+}
+
+// updateMissionSeed 处理随机种子编辑：R 重新生成，数字键追加，Backspace 删除末位
+func updateMissionSeed(seed int64) int64 {
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		return state.NewRandomSeed()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+		return seed / 10
+	}
+	for digit, key := range seedDigitKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			seed = appendSeedDigit(seed, digit)
+		}
+	}
+	return seed
+}
+
+// appendSeedDigit 在种子末尾追加一位数字，超出上限时保持不变
+func appendSeedDigit(seed int64, digit int) int64 {
+	if next := seed*10 + int64(digit); next < state.MaxRandomSeed {
+		return next
+	}
+	return seed
+}
+
 func otherMissionCategory(category metadata.MissionCategory) metadata.MissionCategory {
 	if category == metadata.MissionCategoryTest {
 		return metadata.MissionCategoryClassic
@@ -119,8 +162,23 @@ func (g *Game) handleMissionLoading() error {
 	if !g.objStates.LoadingInterface.Ready {
 		return nil
 	}
+	if g.missionMgr == nil && g.loadSavedMission {
+		g.loadSavedMission = false
+		missionMgr, err := loadSavedMission(g.curMission, g.ui)
+		if err != nil {
+			log.Printf("[ERROR] Failed to load mission save: %v", err)
+			g.mode = GameModeMissionSelect
+			return nil
+		}
+		g.missionMgr = missionMgr
+	}
+	if g.missionMgr == nil && g.netplaySession != nil {
+		g.missionMgr = manager.NewNetplay(g.netplaySession, g.ui)
+	}
 	if g.missionMgr == nil {
-		g.missionMgr = manager.New(g.curMission, g.ui)
+		g.missionMgr = manager.New(g.curMission, g.ui, g.curSeed, g.curDifficulty)
+		// 下一局默认换一个新种子，需要复现时可在任务选择界面手动输入
+		g.curSeed = state.NewRandomSeed()
 	}
 	if !g.missionMgr.WarmupMapBlocks() {
 		return nil
@@ -129,10 +187,20 @@ func (g *Game) handleMissionLoading() error {
 	return nil
 }
 
+// loadSavedMission 读取任务存档，创建从存档进度继续的任务管理器
+func loadSavedMission(mission string, ui *ebitenui.UI) (*manager.MissionManager, error) {
+	f, err := save.Load(save.FilePath(mission))
+	if err != nil {
+		return nil, err
+	}
+	return manager.Load(f, ui)
+}
+
 // startMissionLoading 进入关卡加载状态，并重置加载界面与完成音效状态。
 func (g *Game) startMissionLoading() {
 	g.objStates.LoadingInterface.Reset()
 	g.missionMgr = nil
+	g.loadSavedMission = false
 	g.mode = GameModeMissionLoading
 	g.player.Close()
 }
@@ -146,20 +214,95 @@ func (g *Game) handleMissionRunning() error {
 		g.objStates.LoadingInterface.LoadedAudioPlayed = true
 	}
 	status, err := g.missionMgr.Update()
+	if err != nil && g.netplaySession != nil {
+		// 联机对战中断（对方离开，连接断开，状态不同步），返回主菜单
+		log.Printf("[ERROR] Netplay aborted: %v", err)
+		g.saveMissionReplay()
+		g.endNetplay()
+		g.missionMgr = nil
+		g.mode = GameModeMenuSelect
+		g.player.Close()
+		return nil
+	}
 	if err != nil {
 		log.Fatal("failed to update mission: ", err)
 	}
 	if status == state.MissionSuccess {
+		g.saveMissionReplay()
+		g.endNetplay()
 		g.mode = GameModeMissionSuccess
 	} else if status == state.MissionFailed {
+		g.saveMissionReplay()
+		g.endNetplay()
 		g.mode = GameModeMissionFailed
 	}
 	return nil
 }
 
+// StartNetplay 使用已建立的联机会话开始对战（任务，种子，游戏设置均以主机为准）
+func (g *Game) StartNetplay(session *netplay.Session) {
+	hello := session.Hello()
+	g.restoreSettings = hello.Settings.Apply()
+	g.curMission = hello.Mission
+	g.curSeed = hello.Seed
+	g.curDifficulty = hello.Difficulty.OrDefault()
+	g.startMissionLoading()
+	g.netplaySession = session
+}
+
+// endNetplay 结束联机对战：通知对方离开，并恢复本地游戏设置
+func (g *Game) endNetplay() {
+	if g.netplaySession == nil {
+		return
+	}
+	if err := g.netplaySession.Close(); err != nil {
+		log.Printf("[WARN] Failed to close netplay session: %v", err)
+	}
+	g.netplaySession = nil
+	g.restoreSettings()
+}
+
+// saveMissionReplay 任务结束时保存本局录像
+func (g *Game) saveMissionReplay() {
+	g.lastReplay = g.missionMgr.Replay()
+	if g.lastReplay == nil {
+		return
+	}
+	path := replay.NewFilePath(g.lastReplay, time.Now())
+	if err := replay.Save(path, g.lastReplay); err != nil {
+		log.Printf("[ERROR] Failed to save replay: %v", err)
+		return
+	}
+	log.Printf("[INFO] Replay saved: %s", path)
+}
+
+// StartReplay 进入录像回放模式
+func (g *Game) StartReplay(rp *replay.Replay) {
+	g.player.Close()
+	g.restoreSettings = rp.ApplySettings()
+	g.missionMgr = manager.NewReplay(rp)
+	g.mode = GameModeReplay
+}
+
+// 录像回放
+func (g *Game) handleReplay() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.restoreSettings()
+		g.missionMgr = nil
+		g.mode = GameModeMenuSelect
+		return nil
+	}
+	g.missionMgr.UpdateReplay()
+	return nil
+}
+
 // 任务成功
 func (g *Game) handleMissionSuccess() error {
 	g.player.PlayLazy(audioRes.NewMissionSuccess)
+	if g.lastReplay != nil && inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.StartReplay(g.lastReplay)
+		return nil
+	}
 	if isAnyNextInput() {
 		g.mode = GameModeMenuSelect
 		g.player.Close()
@@ -170,6 +313,10 @@ func (g *Game) handleMissionSuccess() error {
 // 任务失败
 func (g *Game) handleMissionFailed() error {
 	g.player.PlayLazy(audioRes.NewMissionFailed)
+	if g.lastReplay != nil && inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.StartReplay(g.lastReplay)
+		return nil
+	}
 	if isAnyNextInput() {
 		g.mode = GameModeMenuSelect
 		g.player.Close()
@@ -69,3 +69,9 @@ func TestStartMissionLoadingResetsPreviousMission(t *testing.T) {
 	require.False(t, g.objStates.LoadingInterface.MissionRunningDrawn)
 	require.False(t, g.objStates.LoadingInterface.LoadedAudioPlayed)
 }
+
+func TestAppendSeedDigitKeepsSeedInRange(t *testing.T) {
+	require.Equal(t, int64(7), appendSeedDigit(0, 7))
+	require.Equal(t, int64(123), appendSeedDigit(12, 3))
+	require.Equal(t, int64(999_999_999), appendSeedDigit(999_999_999, 9))
+}
@@ -0,0 +1,279 @@
+package settings
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/ebitenui/ebitenui/image"
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/samber/lo"
+
+	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/i18n"
+	"github.com/narasux/jutland/pkg/resources/font"
+	"github.com/narasux/jutland/pkg/utils/colorx"
+)
+
+// 按键绑定表
+const (
+	keyBindingFontSize = 18
+	keyBindingColumns  = 3 // 每行展示的操作数
+	keyButtonWidth     = 120
+)
+
+var (
+	colorKeyBorder    = color.RGBA{R: 212, G: 180, B: 112, A: 255}
+	colorKeyButtonBg  = color.RGBA{R: 20, G: 22, B: 25, A: 250}
+	colorKeyHoverBg   = color.RGBA{R: 45, G: 42, B: 35, A: 255}
+	colorKeyConflict  = color.RGBA{R: 200, G: 40, B: 40, A: 255}
+	colorKeyCapturing = color.RGBA{R: 76, G: 65, B: 47, A: 255}
+)
+
+// newKeyButtonImage 按键按钮样式（冲突时红色边框，等待按键时高亮背景）
+func newKeyButtonImage(conflict, capturing bool) *widget.ButtonImage {
+	border, bg := colorKeyBorder, colorKeyButtonBg
+	if conflict {
+		border = colorKeyConflict
+	}
+	if capturing {
+		bg = colorKeyCapturing
+	}
+	return &widget.ButtonImage{
+		Idle:    image.NewBorderedNineSliceColor(bg, border, 2),
+		Hover:   image.NewBorderedNineSliceColor(colorKeyHoverBg, border, 2),
+		Pressed: image.NewBorderedNineSliceColor(bg, colorx.Silver, 2),
+	}
+}
+
+// keyActionLabel 操作在按键绑定表中的展示名称
+func keyActionLabel(action config.KeyAction) string {
+	weaponLabel := func(id i18n.MessageID) string {
+		return i18n.Format(i18n.MsgKeyActionToggleWeapon, map[string]any{"Weapon": i18n.Text(id)})
+	}
+	switch action {
+	case config.KeyActionToggleAllWeapons:
+		return i18n.Text(i18n.MsgKeyActionToggleAllWeapons)
+	case config.KeyActionToggleMainGun:
+		return weaponLabel(i18n.MsgWeaponMainGun)
+	case config.KeyActionToggleSecondaryGun:
+		return weaponLabel(i18n.MsgWeaponSecondaryGun)
+	case config.KeyActionToggleAntiAircraftGun:
+		return weaponLabel(i18n.MsgWeaponAntiAircraftGun)
+	case config.KeyActionToggleTorpedo:
+		return weaponLabel(i18n.MsgWeaponTorpedo)
+	case config.KeyActionToggleRocket:
+		return weaponLabel(i18n.MsgWeaponRocket)
+	case config.KeyActionFireStance:
+		return i18n.Text(i18n.MsgKeyActionFireStance)
+	case config.KeyActionStandOff:
+		return i18n.Text(i18n.MsgKeyActionStandOff)
+	case config.KeyActionEngineFaster:
+		return i18n.Text(i18n.MsgKeyActionEngineFaster)
+	case config.KeyActionEngineSlower:
+		return i18n.Text(i18n.MsgKeyActionEngineSlower)
+	case config.KeyActionDamageControl:
+		return i18n.Text(i18n.MsgKeyActionDamageControl)
+	case config.KeyActionAttackMove:
+		return i18n.Text(i18n.MsgKeyActionAttackMove)
+	case config.KeyActionScatter:
+		return i18n.Text(i18n.MsgKeyActionScatter)
+	case config.KeyActionFormation:
+		return i18n.Text(i18n.MsgKeyActionFormation)
+	case config.KeyActionPatrol:
+		return i18n.Text(i18n.MsgKeyActionPatrol)
+	case config.KeyActionGuard:
+		return i18n.Text(i18n.MsgKeyActionGuard)
+	case config.KeyActionCancelOrder:
+		return i18n.Text(i18n.MsgKeyActionCancelOrder)
+	case config.KeyActionMoveUp:
+		return i18n.Text(i18n.MsgKeyActionMoveUp)
+	case config.KeyActionMoveDown:
+		return i18n.Text(i18n.MsgKeyActionMoveDown)
+	case config.KeyActionMoveLeft:
+		return i18n.Text(i18n.MsgKeyActionMoveLeft)
+	case config.KeyActionMoveRight:
+		return i18n.Text(i18n.MsgKeyActionMoveRight)
+	case config.KeyActionAirStrike:
+		return i18n.Text(i18n.MsgKeyActionAirStrike)
+	case config.KeyActionAirPatrol:
+		return i18n.Text(i18n.MsgKeyActionAirPatrol)
+	case config.KeyActionHoldAircraft:
+		return i18n.Text(i18n.MsgKeyActionHoldAircraft)
+	case config.KeyActionSelectAll:
+		return i18n.Text(i18n.MsgKeyActionSelectAll)
+	case config.KeyActionCycleIdleShips:
+		return i18n.Text(i18n.MsgKeyActionCycleIdleShips)
+	case config.KeyActionCycleDamagedShips:
+		return i18n.Text(i18n.MsgKeyActionCycleDamagedShips)
+	case config.KeyActionMap:
+		return i18n.Text(i18n.MsgKeyActionMap)
+	case config.KeyActionReinforce:
+		return i18n.Text(i18n.MsgKeyActionReinforce)
+	case config.KeyActionPause:
+		return i18n.Text(i18n.MsgKeyActionPause)
+	case config.KeyActionTacticalPause:
+		return i18n.Text(i18n.MsgKeyActionTacticalPause)
+	case config.KeyActionTerminal:
+		return i18n.Text(i18n.MsgKeyActionTerminal)
+	case config.KeyActionQuitMission:
+		return i18n.Text(i18n.MsgKeyActionQuitMission)
+	case config.KeyActionSaveProgress:
+		return i18n.Text(i18n.MsgKeyActionSaveProgress)
+	case config.KeyActionBack:
+		return i18n.Text(i18n.MsgKeyActionBack)
+	case config.KeyActionReinforceNextPoint:
+		return i18n.Text(i18n.MsgKeyActionReinforceNextPoint)
+	case config.KeyActionReinforcePrevPoint:
+		return i18n.Text(i18n.MsgKeyActionReinforcePrevPoint)
+	case config.KeyActionReinforcePrevShip:
+		return i18n.Text(i18n.MsgKeyActionReinforcePrevShip)
+	case config.KeyActionReinforceNextShip:
+		return i18n.Text(i18n.MsgKeyActionReinforceNextShip)
+	case config.KeyActionReinforceConfirm:
+		return i18n.Text(i18n.MsgKeyActionReinforceConfirm)
+	case config.KeyActionReinforceCancel:
+		return i18n.Text(i18n.MsgKeyActionReinforceCancel)
+	case config.KeyActionGroupModifier:
+		return i18n.Text(i18n.MsgKeyActionGroupModifier)
+	case config.KeyActionTerminalModifier:
+		return i18n.Text(i18n.MsgKeyActionTerminalModifier)
+	}
+	// 编组 group1 ~ group0
+	if num, ok := strings.CutPrefix(string(action), "group"); ok {
+		return i18n.Format(i18n.MsgKeyActionGroup, map[string]any{"Num": num})
+	}
+	return string(action)
+}
+
+// CancelKeyCapture 取消等待按键（Esc），返回是否有正在等待的按键
+func (s *UI) CancelKeyCapture() bool {
+	if s.capturing == "" {
+		return false
+	}
+	s.capturing = ""
+	s.refreshKeyBindings()
+	return true
+}
+
+// Update 等待按键时，将按下的第一个按键绑定到对应操作（修饰键只能绑定到修饰键操作，Esc 由外层用于取消）
+func (s *UI) Update() {
+	if s.capturing == "" {
+		return
+	}
+	for _, key := range inpututil.AppendJustPressedKeys(nil) {
+		name := key.String()
+		if key == ebiten.KeyEscape || (config.IsModifierKey(name) && !config.IsModifierAction(s.capturing)) {
+			continue
+		}
+		s.localBindings[s.capturing] = name
+		s.capturing = ""
+		s.refreshKeyBindings()
+		return
+	}
+}
+
+// startKeyCapture 点击按键按钮，开始等待玩家按下新的按键
+func (s *UI) startKeyCapture(action config.KeyAction) {
+	s.capturing = action
+	s.refreshKeyBindings()
+}
+
+// resetKeyBindings 恢复默认按键
+func (s *UI) resetKeyBindings() {
+	s.localBindings = config.DefaultKeyBindings()
+	s.capturing = ""
+	s.refreshKeyBindings()
+}
+
+// refreshKeyBindings 刷新按键按钮文字 & 冲突标记，存在冲突时不可保存
+func (s *UI) refreshKeyBindings() {
+	conflicts := config.KeyBindingConflicts(s.localBindings)
+	for action, btn := range s.keyButtons {
+		capturing := action == s.capturing
+		btn.SetText(lo.Ternary(capturing, i18n.Text(i18n.MsgSettingsKeyPress), s.localBindings[action]))
+		btn.SetImage(newKeyButtonImage(lo.Contains(conflicts, action), capturing))
+	}
+	if s.conflictLabel != nil {
+		keys := lo.Uniq(lo.Map(conflicts, func(action config.KeyAction, _ int) string {
+			return s.localBindings[action]
+		}))
+		s.conflictLabel.Label = lo.Ternary(
+			len(keys) == 0, "", i18n.Format(i18n.MsgSettingsKeyConflict, map[string]any{"Keys": strings.Join(keys, " / ")}),
+		)
+	}
+	if s.saveBtn != nil {
+		s.saveBtn.GetWidget().Disabled = len(conflicts) != 0
+	}
+}
+
+// buildKeyBindings 构建按键绑定表（操作名称 + 按键按钮，点击按钮后按下新的按键完成绑定）
+func (s *UI) buildKeyBindings(
+	labelFace *text.Face, btnImage *widget.ButtonImage, btnTextColor *widget.ButtonTextColor,
+) *widget.Container {
+	_face := text.Face(&text.GoTextFace{Source: font.LocalizedUI(font.Kai), Size: keyBindingFontSize})
+	face := &_face
+	labelColor := &widget.LabelColor{Idle: colorx.White, Disabled: colorx.White}
+	keyTextColor := &widget.ButtonTextColor{
+		Idle: colorx.White, Hover: colorx.Gold, Pressed: colorx.White,
+		Disabled: color.RGBA{R: 120, G: 110, B: 100, A: 255},
+	}
+
+	grid := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(keyBindingColumns*2),
+			widget.GridLayoutOpts.Spacing(16, 8),
+			widget.GridLayoutOpts.DefaultStretch(false, false),
+		)),
+	)
+	s.keyButtons = map[config.KeyAction]*widget.Button{}
+	for _, action := range config.KeyActions() {
+		grid.AddChild(widget.NewLabel(
+			widget.LabelOpts.Text(keyActionLabel(action), face, labelColor),
+		))
+		btn := widget.NewButton(
+			widget.ButtonOpts.WidgetOpts(widget.WidgetOpts.MinSize(keyButtonWidth, 0)),
+			// 等待按键时 Enter / Space 用于绑定，不能触发按钮点击
+			widget.ButtonOpts.DisableDefaultKeys(),
+			widget.ButtonOpts.Image(newKeyButtonImage(false, false)),
+			widget.ButtonOpts.Text(s.localBindings[action], face, keyTextColor),
+			widget.ButtonOpts.TextPadding(&widget.Insets{Left: 12, Right: 12, Top: 4, Bottom: 4}),
+			widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+				s.startKeyCapture(action)
+			}),
+		)
+		s.keyButtons[action] = btn
+		grid.AddChild(btn)
+	}
+
+	s.conflictLabel = widget.NewLabel(
+		widget.LabelOpts.Text("", face, &widget.LabelColor{Idle: colorKeyConflict, Disabled: colorKeyConflict}),
+	)
+	resetBtn := widget.NewButton(
+		widget.ButtonOpts.Image(btnImage),
+		widget.ButtonOpts.Text(i18n.Text(i18n.MsgSettingsKeyBindingsReset), face, btnTextColor),
+		widget.ButtonOpts.TextPadding(&widget.Insets{Left: 16, Right: 16, Top: 6, Bottom: 6}),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			s.resetKeyBindings()
+		}),
+	)
+
+	content := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewRowLayout(
+			widget.RowLayoutOpts.Direction(widget.DirectionVertical),
+			widget.RowLayoutOpts.Spacing(12),
+		)),
+	)
+	content.AddChild(widget.NewLabel(
+		widget.LabelOpts.Text(i18n.Text(i18n.MsgSettingsKeyBindings), labelFace, labelColor),
+	))
+	content.AddChild(widget.NewLabel(
+		widget.LabelOpts.Text(i18n.Text(i18n.MsgSettingsKeyBindingsHint), face, labelColor),
+	))
+	content.AddChild(grid)
+	content.AddChild(s.conflictLabel)
+	content.AddChild(resetBtn)
+	return content
+}
@@ -4,6 +4,7 @@ package settings
 import (
 	stdimg "image"
 	"image/color"
+	"maps"
 	"math"
 
 	"github.com/ebitenui/ebitenui/image"
@@ -49,6 +50,13 @@ type UI struct {
 	localValue    float64 // 本地副本，保存时才写回 config.G
 	localLanguage i18n.Language
 	backPressed   bool
+
+	// 按键绑定（本地副本，保存时才写回 config.G）
+	localBindings map[config.KeyAction]string
+	capturing     config.KeyAction // 正在等待按键的操作
+	keyButtons    map[config.KeyAction]*widget.Button
+	conflictLabel *widget.Label
+	saveBtn       *widget.Button
 }
 
 // New 创建设置界面
@@ -56,6 +64,7 @@ func New() *UI {
 	s := &UI{
 		localValue:    config.G.SpeedMultiplier,
 		localLanguage: i18n.NormalizeLanguage(config.G.Language),
+		localBindings: maps.Clone(config.G.KeyBindings),
 	}
 	s.buildUI()
 	return s
@@ -76,6 +85,8 @@ func (s *UI) BackPressed() bool { return s.backPressed }
 func (s *UI) Reset() {
 	s.localValue = config.G.SpeedMultiplier
 	s.localLanguage = i18n.NormalizeLanguage(config.G.Language)
+	s.localBindings = maps.Clone(config.G.KeyBindings)
+	s.capturing = ""
 	s.backPressed = false
 	s.buildUI()
 }
@@ -206,7 +217,7 @@ func (s *UI) buildUI() {
 	)
 
 	// ====== 按钮栏 ======
-	saveBtn := widget.NewButton(
+	s.saveBtn = widget.NewButton(
 		widget.ButtonOpts.Image(normalBtnImage),
 		widget.ButtonOpts.Text(i18n.Text(i18n.MsgSettingsSave), buttonFace, normalBtnTextColor),
 		widget.ButtonOpts.TextPadding(&widget.Insets{Left: 24, Right: 24, Top: 8, Bottom: 8}),
@@ -214,6 +225,7 @@ func (s *UI) buildUI() {
 			s.backPressed = true
 			config.G.SpeedMultiplier = s.localValue
 			config.G.Language = string(s.localLanguage)
+			config.G.KeyBindings = maps.Clone(s.localBindings)
 			_ = config.SaveGameSettings()
 		}),
 	)
@@ -233,7 +245,7 @@ func (s *UI) buildUI() {
 			widget.RowLayoutOpts.Spacing(40),
 		)),
 	)
-	buttonRow.AddChild(saveBtn)
+	buttonRow.AddChild(s.saveBtn)
 	buttonRow.AddChild(cancelBtn)
 
 	// ====== 顶部内容（标题 + 速度倍率 + 选项按钮） ======
@@ -282,6 +294,16 @@ func (s *UI) buildUI() {
 	bottomWidget := bottomContent.GetWidget()
 	bottomWidget.LayoutData = bottomData
 
+	// ====== 按键绑定（右侧） ======
+	keyBindingContent := s.buildKeyBindings(labelFace, normalBtnImage, normalBtnTextColor)
+	mainPanel.AddChild(keyBindingContent)
+	keyBindingWidget := keyBindingContent.GetWidget()
+	keyBindingWidget.LayoutData = widget.AnchorLayoutData{
+		HorizontalPosition: widget.AnchorLayoutPositionEnd,
+		VerticalPosition:   widget.AnchorLayoutPositionStart,
+		Padding:            &widget.Insets{Right: 160, Top: 120},
+	}
+
 	rootContainer := widget.NewContainer(
 		widget.ContainerOpts.Layout(widget.NewAnchorLayout()),
 	)
@@ -296,6 +318,7 @@ func (s *UI) buildUI() {
 	rootWidget.LayoutData = rootData
 
 	s.container = rootContainer
+	s.refreshKeyBindings()
 }
 
 func newSettingsCombo(
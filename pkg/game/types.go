@@ -21,6 +21,8 @@ const (
 	GameModeCollection
 	// 游戏设置
 	GameModeGameSetting
+	// 录像回放
+	GameModeReplay
 	// 游戏结束
 	GameModeEnd
 )
@@ -4,156 +4,264 @@ package i18n
 type MessageID string
 
 const (
-	MsgGameTitle                 MessageID = "GameTitle"
-	MsgGameCredits               MessageID = "GameCredits"
-	MsgMenuMissionSelect         MessageID = "MenuMissionSelect"
-	MsgMenuCollection            MessageID = "MenuCollection"
-	MsgMenuSettings              MessageID = "MenuSettings"
-	MsgMenuExit                  MessageID = "MenuExit"
-	MsgLoading                   MessageID = "Loading"
-	MsgMissionStarted            MessageID = "MissionStarted"
-	MsgMissionContinueHint       MessageID = "MissionContinueHint"
-	MsgMissionSuccess            MessageID = "MissionSuccess"
-	MsgMissionFailed             MessageID = "MissionFailed"
-	MsgMissionStart              MessageID = "MissionStart"
-	MsgMissionCategoryClassic    MessageID = "MissionCategoryClassic"
-	MsgMissionCategoryTest       MessageID = "MissionCategoryTest"
-	MsgBack                      MessageID = "Back"
-	MsgMissionStats              MessageID = "MissionStats"
-	MsgMissionBattleStats        MessageID = "MissionBattleStats"
-	MsgMissionPaused             MessageID = "MissionPaused"
-	MsgMissionDebugPauseHint     MessageID = "MissionDebugPauseHint"
-	MsgMissionResume             MessageID = "MissionResume"
-	MsgMissionAbandon            MessageID = "MissionAbandon"
-	MsgMissionConfirmAbandon     MessageID = "MissionConfirmAbandon"
-	MsgConfirm                   MessageID = "Confirm"
-	MsgSettingsTitle             MessageID = "SettingsTitle"
-	MsgSettingsSpeed             MessageID = "SettingsSpeed"
-	MsgSettingsLanguage          MessageID = "SettingsLanguage"
-	MsgSettingsSave              MessageID = "SettingsSave"
-	MsgSettingsCancel            MessageID = "SettingsCancel"
-	MsgSpeedVerySlow             MessageID = "SpeedVerySlow"
-	MsgSpeedSlow                 MessageID = "SpeedSlow"
-	MsgSpeedNormal               MessageID = "SpeedNormal"
-	MsgSpeedFast                 MessageID = "SpeedFast"
-	MsgSpeedVeryFast             MessageID = "SpeedVeryFast"
-	MsgNationAll                 MessageID = "NationAll"
-	MsgNationChina               MessageID = "NationChina"
-	MsgNationUnitedStates        MessageID = "NationUnitedStates"
-	MsgNationJapan               MessageID = "NationJapan"
-	MsgNationGermany             MessageID = "NationGermany"
-	MsgNationUnitedKingdom       MessageID = "NationUnitedKingdom"
-	MsgNationSovietUnion         MessageID = "NationSovietUnion"
-	MsgNationSpecial             MessageID = "NationSpecial"
-	MsgShipTypeDefault           MessageID = "ShipTypeDefault"
-	MsgShipTypeCarrier           MessageID = "ShipTypeCarrier"
-	MsgShipTypeBattleship        MessageID = "ShipTypeBattleship"
-	MsgShipTypeCruiser           MessageID = "ShipTypeCruiser"
-	MsgShipTypeDestroyer         MessageID = "ShipTypeDestroyer"
-	MsgShipTypeFrigate           MessageID = "ShipTypeFrigate"
-	MsgShipTypeHospital          MessageID = "ShipTypeHospital"
-	MsgShipTypeCargo             MessageID = "ShipTypeCargo"
-	MsgShipTypeTorpedoBoat       MessageID = "ShipTypeTorpedoBoat"
-	MsgPlaneTypeFighter          MessageID = "PlaneTypeFighter"
-	MsgPlaneTypeDiveBomber       MessageID = "PlaneTypeDiveBomber"
-	MsgPlaneTypeTorpedoBomber    MessageID = "PlaneTypeTorpedoBomber"
-	MsgUnknown                   MessageID = "Unknown"
-	MsgCollectionAll             MessageID = "CollectionAll"
-	MsgCollectionSpecial         MessageID = "CollectionSpecial"
-	MsgCollectionAuxiliary       MessageID = "CollectionAuxiliary"
-	MsgCollectionShip            MessageID = "CollectionShip"
-	MsgCollectionPlane           MessageID = "CollectionPlane"
-	MsgCollectionNation          MessageID = "CollectionNation"
-	MsgCollectionShipClass       MessageID = "CollectionShipClass"
-	MsgCollectionPlaneType       MessageID = "CollectionPlaneType"
-	MsgCollectionShipName        MessageID = "CollectionShipName"
-	MsgCollectionPlaneCount      MessageID = "CollectionPlaneCount"
-	MsgCollectionNationValue     MessageID = "CollectionNationValue"
-	MsgCollectionNoMatchingShip  MessageID = "CollectionNoMatchingShip"
-	MsgCollectionNoMatchingPlane MessageID = "CollectionNoMatchingPlane"
-	MsgCollectionShipArchive     MessageID = "CollectionShipArchive"
-	MsgCollectionNationLabel     MessageID = "CollectionNationLabel"
-	MsgCollectionTypeLabel       MessageID = "CollectionTypeLabel"
-	MsgCollectionYear            MessageID = "CollectionYear"
-	MsgCollectionTonnage         MessageID = "CollectionTonnage"
-	MsgCollectionSpeed           MessageID = "CollectionSpeed"
-	MsgCollectionCost            MessageID = "CollectionCost"
-	MsgCollectionHorizontalDR    MessageID = "CollectionHorizontalDR"
-	MsgCollectionVerticalDR      MessageID = "CollectionVerticalDR"
-	MsgCollectionArmaments       MessageID = "CollectionArmaments"
-	MsgCollectionNone            MessageID = "CollectionNone"
-	MsgCollectionCombat          MessageID = "CollectionCombat"
-	MsgCollectionTotalPower      MessageID = "CollectionTotalPower"
-	MsgCollectionRelativeNote    MessageID = "CollectionRelativeNote"
-	MsgCollectionHistorySource   MessageID = "CollectionHistorySource"
-	MsgCollectionNoHistory       MessageID = "CollectionNoHistory"
-	MsgCollectionAssetAuthor     MessageID = "CollectionAssetAuthor"
-	MsgCollectionBasicData       MessageID = "CollectionBasicData"
-	MsgCollectionDurability      MessageID = "CollectionDurability"
-	MsgCollectionDamageReduction MessageID = "CollectionDamageReduction"
-	MsgCollectionRange           MessageID = "CollectionRange"
-	MsgCollectionPlaneSpeed      MessageID = "CollectionPlaneSpeed"
-	MsgCollectionWeaponConfig    MessageID = "CollectionWeaponConfig"
-	MsgCollectionCombatAbility   MessageID = "CollectionCombatAbility"
-	MsgCollectionFormationNote   MessageID = "CollectionFormationNote"
-	MsgCollectionFormationPower  MessageID = "CollectionFormationPower"
-	MsgWeaponGun                 MessageID = "WeaponGun"
-	MsgWeaponBomb                MessageID = "WeaponBomb"
-	MsgWeaponTorpedo             MessageID = "WeaponTorpedo"
-	MsgWeaponRocket              MessageID = "WeaponRocket"
-	MsgRadarAntiShip             MessageID = "RadarAntiShip"
-	MsgRadarAntiAir              MessageID = "RadarAntiAir"
-	MsgRadarSurvival             MessageID = "RadarSurvival"
-	MsgRadarMobility             MessageID = "RadarMobility"
-	MsgRadarProjection           MessageID = "RadarProjection"
-	MsgRadarBurst                MessageID = "RadarBurst"
-	MsgRadarFormationScope       MessageID = "RadarFormationScope"
-	MsgRadarAbilityValue         MessageID = "RadarAbilityValue"
-	MsgRadarRelativePosition     MessageID = "RadarRelativePosition"
-	MsgRadarAntiShipDPS          MessageID = "RadarAntiShipDPS"
-	MsgRadarAntiAirDPS           MessageID = "RadarAntiAirDPS"
-	MsgRadarTargetingNote        MessageID = "RadarTargetingNote"
-	MsgRadarEffectiveHP          MessageID = "RadarEffectiveHP"
-	MsgRadarMobilityNote         MessageID = "RadarMobilityNote"
-	MsgRadarCombatRadius         MessageID = "RadarCombatRadius"
-	MsgRadarProjectionDistance   MessageID = "RadarProjectionDistance"
-	MsgRadarBurstDamage          MessageID = "RadarBurstDamage"
-	MsgRadarMainContributions    MessageID = "RadarMainContributions"
-	MsgRadarSubjectPower         MessageID = "RadarSubjectPower"
-	MsgRadarSubjectDimension     MessageID = "RadarSubjectDimension"
-	MsgRadarContribution         MessageID = "RadarContribution"
-	MsgRadarOverall              MessageID = "RadarOverall"
-	MsgRadarHull                 MessageID = "RadarHull"
-	MsgRadarAviation             MessageID = "RadarAviation"
-	MsgSidebarFunds              MessageID = "SidebarFunds"
-	MsgSidebarFleets             MessageID = "SidebarFleets"
-	MsgSidebarAllyFleet          MessageID = "SidebarAllyFleet"
-	MsgSidebarEnemyFleet         MessageID = "SidebarEnemyFleet"
-	MsgSidebarShowState          MessageID = "SidebarShowState"
-	MsgSidebarDamageNumbers      MessageID = "SidebarDamageNumbers"
-	MsgMapSelf                   MessageID = "MapSelf"
-	MsgMapEnemy                  MessageID = "MapEnemy"
-	MsgMapFleetCount             MessageID = "MapFleetCount"
-	MsgRallyLandBlocked          MessageID = "RallyLandBlocked"
-	MsgReinforceShipArchive      MessageID = "ReinforceShipArchive"
-	MsgReinforceWeaponConfig     MessageID = "ReinforceWeaponConfig"
-	MsgReinforceType             MessageID = "ReinforceType"
-	MsgReinforceHP               MessageID = "ReinforceHP"
-	MsgReinforceSpeed            MessageID = "ReinforceSpeed"
-	MsgReinforceCost             MessageID = "ReinforceCost"
-	MsgReinforceQueue            MessageID = "ReinforceQueue"
-	MsgReinforceStandby          MessageID = "ReinforceStandby"
-	MsgReinforceControl          MessageID = "ReinforceControl"
-	MsgReinforceCurrentFunds     MessageID = "ReinforceCurrentFunds"
-	MsgReinforceTipPoint         MessageID = "ReinforceTipPoint"
-	MsgReinforceTipShip          MessageID = "ReinforceTipShip"
-	MsgReinforceTipSummon        MessageID = "ReinforceTipSummon"
-	MsgReinforceTipCancel        MessageID = "ReinforceTipCancel"
-	MsgReinforceTipRally         MessageID = "ReinforceTipRally"
-	MsgValueKnots                MessageID = "ValueKnots"
-	MsgValueCost                 MessageID = "ValueCost"
-	MsgTypeWithAbbr              MessageID = "TypeWithAbbr"
-	MsgLabelValue                MessageID = "LabelValue"
-	MsgItemCount                 MessageID = "ItemCount"
-	MsgTypeSlash                 MessageID = "TypeSlash"
+	MsgGameTitle                   MessageID = "GameTitle"
+	MsgGameCredits                 MessageID = "GameCredits"
+	MsgMenuMissionSelect           MessageID = "MenuMissionSelect"
+	MsgMenuCollection              MessageID = "MenuCollection"
+	MsgMenuSettings                MessageID = "MenuSettings"
+	MsgMenuExit                    MessageID = "MenuExit"
+	MsgLoading                     MessageID = "Loading"
+	MsgMissionStarted              MessageID = "MissionStarted"
+	MsgMissionContinueHint         MessageID = "MissionContinueHint"
+	MsgMissionSuccess              MessageID = "MissionSuccess"
+	MsgMissionFailed               MessageID = "MissionFailed"
+	MsgMissionStart                MessageID = "MissionStart"
+	MsgMissionCategoryClassic      MessageID = "MissionCategoryClassic"
+	MsgMissionCategoryTest         MessageID = "MissionCategoryTest"
+	MsgBack                        MessageID = "Back"
+	MsgMissionStats                MessageID = "MissionStats"
+	MsgMissionBattleStats          MessageID = "MissionBattleStats"
+	MsgMissionSeed                 MessageID = "MissionSeed"
+	MsgMissionDifficulty           MessageID = "MissionDifficulty"
+	MsgDifficultyEasy              MessageID = "DifficultyEasy"
+	MsgDifficultyNormal            MessageID = "DifficultyNormal"
+	MsgDifficultyHard              MessageID = "DifficultyHard"
+	MsgFormationLineAhead          MessageID = "FormationLineAhead"
+	MsgFormationLineAbreast        MessageID = "FormationLineAbreast"
+	MsgFormationWedge              MessageID = "FormationWedge"
+	MsgFormationScreen             MessageID = "FormationScreen"
+	MsgFireStanceFireAtWill        MessageID = "FireStanceFireAtWill"
+	MsgFireStanceReturnFire        MessageID = "FireStanceReturnFire"
+	MsgFireStanceHoldFire          MessageID = "FireStanceHoldFire"
+	MsgTargetPolicyLargest         MessageID = "TargetPolicyLargest"
+	MsgTargetPolicyWeakest         MessageID = "TargetPolicyWeakest"
+	MsgTargetPolicyNearest         MessageID = "TargetPolicyNearest"
+	MsgTargetPolicyPlanesFirst     MessageID = "TargetPolicyPlanesFirst"
+	MsgWeaponMainGun               MessageID = "WeaponMainGun"
+	MsgWeaponSecondaryGun          MessageID = "WeaponSecondaryGun"
+	MsgWeaponAntiAircraftGun       MessageID = "WeaponAntiAircraftGun"
+	MsgStandOffRange               MessageID = "StandOffRange"
+	MsgEngineOrderAstern           MessageID = "EngineOrderAstern"
+	MsgEngineOrderStop             MessageID = "EngineOrderStop"
+	MsgEngineOrderOneThird         MessageID = "EngineOrderOneThird"
+	MsgEngineOrderTwoThirds        MessageID = "EngineOrderTwoThirds"
+	MsgEngineOrderFull             MessageID = "EngineOrderFull"
+	MsgEngineOrderFlank            MessageID = "EngineOrderFlank"
+	MsgAirOpsHold                  MessageID = "AirOpsHold"
+	MsgAirOpsStrike                MessageID = "AirOpsStrike"
+	MsgAirOpsPatrol                MessageID = "AirOpsPatrol"
+	MsgMissionPausedSeed           MessageID = "MissionPausedSeed"
+	MsgReplayPlaying               MessageID = "ReplayPlaying"
+	MsgReplayPaused                MessageID = "ReplayPaused"
+	MsgReplayFinished              MessageID = "ReplayFinished"
+	MsgReplayHint                  MessageID = "ReplayHint"
+	MsgReplayCheated               MessageID = "ReplayCheated"
+	MsgNetplayWaitingForPeer       MessageID = "NetplayWaitingForPeer"
+	MsgTacticalPaused              MessageID = "TacticalPaused"
+	MsgPlannedWeaponEnabled        MessageID = "PlannedWeaponEnabled"
+	MsgPlannedWeaponDisabled       MessageID = "PlannedWeaponDisabled"
+	MsgPlannedStandOffClose        MessageID = "PlannedStandOffClose"
+	MsgPlannedTargetPolicyDefault  MessageID = "PlannedTargetPolicyDefault"
+	MsgPlannedAirOpsAuto           MessageID = "PlannedAirOpsAuto"
+	MsgShipEngineDamaged           MessageID = "ShipEngineDamaged"
+	MsgShipRudderDamaged           MessageID = "ShipRudderDamaged"
+	MsgShipOnFire                  MessageID = "ShipOnFire"
+	MsgShipFlooding                MessageID = "ShipFlooding"
+	MsgShipDamageControlCooldown   MessageID = "ShipDamageControlCooldown"
+	MsgShipTorpedoFlooding         MessageID = "ShipTorpedoFlooding"
+	MsgShipResupplied              MessageID = "ShipResupplied"
+	MsgShipAmmo                    MessageID = "ShipAmmo"
+	MsgShipOutOfAmmo               MessageID = "ShipOutOfAmmo"
+	MsgShipFuel                    MessageID = "ShipFuel"
+	MsgShipOutOfFuel               MessageID = "ShipOutOfFuel"
+	MsgArmorRicochet               MessageID = "ArmorRicochet"
+	MsgArmorNonPenetration         MessageID = "ArmorNonPenetration"
+	MsgArmorOverPenetration        MessageID = "ArmorOverPenetration"
+	MsgMissionWatchReplay          MessageID = "MissionWatchReplay"
+	MsgMissionSaveHint             MessageID = "MissionSaveHint"
+	MsgMissionSaveSucceeded        MessageID = "MissionSaveSucceeded"
+	MsgMissionSaveFailed           MessageID = "MissionSaveFailed"
+	MsgMissionSavedProgress        MessageID = "MissionSavedProgress"
+	MsgMissionPaused               MessageID = "MissionPaused"
+	MsgMissionDebugPauseHint       MessageID = "MissionDebugPauseHint"
+	MsgMissionResume               MessageID = "MissionResume"
+	MsgMissionAbandon              MessageID = "MissionAbandon"
+	MsgMissionConfirmAbandon       MessageID = "MissionConfirmAbandon"
+	MsgConfirm                     MessageID = "Confirm"
+	MsgSettingsTitle               MessageID = "SettingsTitle"
+	MsgSettingsSpeed               MessageID = "SettingsSpeed"
+	MsgSettingsLanguage            MessageID = "SettingsLanguage"
+	MsgSettingsSave                MessageID = "SettingsSave"
+	MsgSettingsCancel              MessageID = "SettingsCancel"
+	MsgSettingsKeyBindings         MessageID = "SettingsKeyBindings"
+	MsgSettingsKeyBindingsHint     MessageID = "SettingsKeyBindingsHint"
+	MsgSettingsKeyBindingsReset    MessageID = "SettingsKeyBindingsReset"
+	MsgSettingsKeyPress            MessageID = "SettingsKeyPress"
+	MsgSettingsKeyConflict         MessageID = "SettingsKeyConflict"
+	MsgKeyActionToggleAllWeapons   MessageID = "KeyActionToggleAllWeapons"
+	MsgKeyActionToggleWeapon       MessageID = "KeyActionToggleWeapon"
+	MsgKeyActionFireStance         MessageID = "KeyActionFireStance"
+	MsgKeyActionStandOff           MessageID = "KeyActionStandOff"
+	MsgKeyActionEngineFaster       MessageID = "KeyActionEngineFaster"
+	MsgKeyActionEngineSlower       MessageID = "KeyActionEngineSlower"
+	MsgKeyActionDamageControl      MessageID = "KeyActionDamageControl"
+	MsgKeyActionAttackMove         MessageID = "KeyActionAttackMove"
+	MsgKeyActionScatter            MessageID = "KeyActionScatter"
+	MsgKeyActionFormation          MessageID = "KeyActionFormation"
+	MsgKeyActionPatrol             MessageID = "KeyActionPatrol"
+	MsgKeyActionGuard              MessageID = "KeyActionGuard"
+	MsgKeyActionCancelOrder        MessageID = "KeyActionCancelOrder"
+	MsgKeyActionMoveUp             MessageID = "KeyActionMoveUp"
+	MsgKeyActionMoveDown           MessageID = "KeyActionMoveDown"
+	MsgKeyActionMoveLeft           MessageID = "KeyActionMoveLeft"
+	MsgKeyActionMoveRight          MessageID = "KeyActionMoveRight"
+	MsgKeyActionAirStrike          MessageID = "KeyActionAirStrike"
+	MsgKeyActionAirPatrol          MessageID = "KeyActionAirPatrol"
+	MsgKeyActionHoldAircraft       MessageID = "KeyActionHoldAircraft"
+	MsgKeyActionSelectAll          MessageID = "KeyActionSelectAll"
+	MsgKeyActionCycleIdleShips     MessageID = "KeyActionCycleIdleShips"
+	MsgKeyActionCycleDamagedShips  MessageID = "KeyActionCycleDamagedShips"
+	MsgKeyActionGroup              MessageID = "KeyActionGroup"
+	MsgKeyActionMap                MessageID = "KeyActionMap"
+	MsgKeyActionReinforce          MessageID = "KeyActionReinforce"
+	MsgKeyActionPause              MessageID = "KeyActionPause"
+	MsgKeyActionTacticalPause      MessageID = "KeyActionTacticalPause"
+	MsgKeyActionTerminal           MessageID = "KeyActionTerminal"
+	MsgKeyActionQuitMission        MessageID = "KeyActionQuitMission"
+	MsgKeyActionSaveProgress       MessageID = "KeyActionSaveProgress"
+	MsgKeyActionBack               MessageID = "KeyActionBack"
+	MsgKeyActionReinforceNextPoint MessageID = "KeyActionReinforceNextPoint"
+	MsgKeyActionReinforcePrevPoint MessageID = "KeyActionReinforcePrevPoint"
+	MsgKeyActionReinforcePrevShip  MessageID = "KeyActionReinforcePrevShip"
+	MsgKeyActionReinforceNextShip  MessageID = "KeyActionReinforceNextShip"
+	MsgKeyActionReinforceConfirm   MessageID = "KeyActionReinforceConfirm"
+	MsgKeyActionReinforceCancel    MessageID = "KeyActionReinforceCancel"
+	MsgKeyActionGroupModifier      MessageID = "KeyActionGroupModifier"
+	MsgKeyActionTerminalModifier   MessageID = "KeyActionTerminalModifier"
+	MsgSpeedVerySlow               MessageID = "SpeedVerySlow"
+	MsgSpeedSlow                   MessageID = "SpeedSlow"
+	MsgSpeedNormal                 MessageID = "SpeedNormal"
+	MsgSpeedFast                   MessageID = "SpeedFast"
+	MsgSpeedVeryFast               MessageID = "SpeedVeryFast"
+	MsgNationAll                   MessageID = "NationAll"
+	MsgNationChina                 MessageID = "NationChina"
+	MsgNationUnitedStates          MessageID = "NationUnitedStates"
+	MsgNationJapan                 MessageID = "NationJapan"
+	MsgNationGermany               MessageID = "NationGermany"
+	MsgNationUnitedKingdom         MessageID = "NationUnitedKingdom"
+	MsgNationSovietUnion           MessageID = "NationSovietUnion"
+	MsgNationSpecial               MessageID = "NationSpecial"
+	MsgShipTypeDefault             MessageID = "ShipTypeDefault"
+	MsgShipTypeCarrier             MessageID = "ShipTypeCarrier"
+	MsgShipTypeBattleship          MessageID = "ShipTypeBattleship"
+	MsgShipTypeCruiser             MessageID = "ShipTypeCruiser"
+	MsgShipTypeDestroyer           MessageID = "ShipTypeDestroyer"
+	MsgShipTypeFrigate             MessageID = "ShipTypeFrigate"
+	MsgShipTypeHospital            MessageID = "ShipTypeHospital"
+	MsgShipTypeCargo               MessageID = "ShipTypeCargo"
+	MsgShipTypeTorpedoBoat         MessageID = "ShipTypeTorpedoBoat"
+	MsgPlaneTypeFighter            MessageID = "PlaneTypeFighter"
+	MsgPlaneTypeDiveBomber         MessageID = "PlaneTypeDiveBomber"
+	MsgPlaneTypeTorpedoBomber      MessageID = "PlaneTypeTorpedoBomber"
+	MsgUnknown                     MessageID = "Unknown"
+	MsgCollectionAll               MessageID = "CollectionAll"
+	MsgCollectionSpecial           MessageID = "CollectionSpecial"
+	MsgCollectionAuxiliary         MessageID = "CollectionAuxiliary"
+	MsgCollectionShip              MessageID = "CollectionShip"
+	MsgCollectionPlane             MessageID = "CollectionPlane"
+	MsgCollectionNation            MessageID = "CollectionNation"
+	MsgCollectionShipClass         MessageID = "CollectionShipClass"
+	MsgCollectionPlaneType         MessageID = "CollectionPlaneType"
+	MsgCollectionShipName          MessageID = "CollectionShipName"
+	MsgCollectionPlaneCount        MessageID = "CollectionPlaneCount"
+	MsgCollectionNationValue       MessageID = "CollectionNationValue"
+	MsgCollectionNoMatchingShip    MessageID = "CollectionNoMatchingShip"
+	MsgCollectionNoMatchingPlane   MessageID = "CollectionNoMatchingPlane"
+	MsgCollectionShipArchive       MessageID = "CollectionShipArchive"
+	MsgCollectionNationLabel       MessageID = "CollectionNationLabel"
+	MsgCollectionTypeLabel         MessageID = "CollectionTypeLabel"
+	MsgCollectionYear              MessageID = "CollectionYear"
+	MsgCollectionTonnage           MessageID = "CollectionTonnage"
+	MsgCollectionSpeed             MessageID = "CollectionSpeed"
+	MsgCollectionCost              MessageID = "CollectionCost"
+	MsgCollectionHorizontalDR      MessageID = "CollectionHorizontalDR"
+	MsgCollectionVerticalDR        MessageID = "CollectionVerticalDR"
+	MsgCollectionArmor             MessageID = "CollectionArmor"
+	MsgCollectionArmaments         MessageID = "CollectionArmaments"
+	MsgCollectionNone              MessageID = "CollectionNone"
+	MsgCollectionCombat            MessageID = "CollectionCombat"
+	MsgCollectionTotalPower        MessageID = "CollectionTotalPower"
+	MsgCollectionRelativeNote      MessageID = "CollectionRelativeNote"
+	MsgCollectionHistorySource     MessageID = "CollectionHistorySource"
+	MsgCollectionNoHistory         MessageID = "CollectionNoHistory"
+	MsgCollectionAssetAuthor       MessageID = "CollectionAssetAuthor"
+	MsgCollectionBasicData         MessageID = "CollectionBasicData"
+	MsgCollectionDurability        MessageID = "CollectionDurability"
+	MsgCollectionDamageReduction   MessageID = "CollectionDamageReduction"
+	MsgCollectionRange             MessageID = "CollectionRange"
+	MsgCollectionPlaneSpeed        MessageID = "CollectionPlaneSpeed"
+	MsgCollectionWeaponConfig      MessageID = "CollectionWeaponConfig"
+	MsgCollectionCombatAbility     MessageID = "CollectionCombatAbility"
+	MsgCollectionFormationNote     MessageID = "CollectionFormationNote"
+	MsgCollectionFormationPower    MessageID = "CollectionFormationPower"
+	MsgWeaponGun                   MessageID = "WeaponGun"
+	MsgWeaponBomb                  MessageID = "WeaponBomb"
+	MsgWeaponTorpedo               MessageID = "WeaponTorpedo"
+	MsgWeaponRocket                MessageID = "WeaponRocket"
+	MsgRadarAntiShip               MessageID = "RadarAntiShip"
+	MsgRadarAntiAir                MessageID = "RadarAntiAir"
+	MsgRadarSurvival               MessageID = "RadarSurvival"
+	MsgRadarMobility               MessageID = "RadarMobility"
+	MsgRadarProjection             MessageID = "RadarProjection"
+	MsgRadarBurst                  MessageID = "RadarBurst"
+	MsgRadarFormationScope         MessageID = "RadarFormationScope"
+	MsgRadarAbilityValue           MessageID = "RadarAbilityValue"
+	MsgRadarRelativePosition       MessageID = "RadarRelativePosition"
+	MsgRadarAntiShipDPS            MessageID = "RadarAntiShipDPS"
+	MsgRadarAntiAirDPS             MessageID = "RadarAntiAirDPS"
+	MsgRadarTargetingNote          MessageID = "RadarTargetingNote"
+	MsgRadarEffectiveHP            MessageID = "RadarEffectiveHP"
+	MsgRadarMobilityNote           MessageID = "RadarMobilityNote"
+	MsgRadarCombatRadius           MessageID = "RadarCombatRadius"
+	MsgRadarProjectionDistance     MessageID = "RadarProjectionDistance"
+	MsgRadarBurstDamage            MessageID = "RadarBurstDamage"
+	MsgRadarMainContributions      MessageID = "RadarMainContributions"
+	MsgRadarSubjectPower           MessageID = "RadarSubjectPower"
+	MsgRadarSubjectDimension       MessageID = "RadarSubjectDimension"
+	MsgRadarContribution           MessageID = "RadarContribution"
+	MsgRadarOverall                MessageID = "RadarOverall"
+	MsgRadarHull                   MessageID = "RadarHull"
+	MsgRadarAviation               MessageID = "RadarAviation"
+	MsgSidebarFunds                MessageID = "SidebarFunds"
+	MsgSidebarFleets               MessageID = "SidebarFleets"
+	MsgSidebarAllyFleet            MessageID = "SidebarAllyFleet"
+	MsgSidebarEnemyFleet           MessageID = "SidebarEnemyFleet"
+	MsgSidebarShowState            MessageID = "SidebarShowState"
+	MsgSidebarDamageNumbers        MessageID = "SidebarDamageNumbers"
+	MsgMapSelf                     MessageID = "MapSelf"
+	MsgMapEnemy                    MessageID = "MapEnemy"
+	MsgMapFleetCount               MessageID = "MapFleetCount"
+	MsgRallyLandBlocked            MessageID = "RallyLandBlocked"
+	MsgReinforceShipArchive        MessageID = "ReinforceShipArchive"
+	MsgReinforceWeaponConfig       MessageID = "ReinforceWeaponConfig"
+	MsgReinforceType               MessageID = "ReinforceType"
+	MsgReinforceHP                 MessageID = "ReinforceHP"
+	MsgReinforceSpeed              MessageID = "ReinforceSpeed"
+	MsgReinforceCost               MessageID = "ReinforceCost"
+	MsgReinforceQueue              MessageID = "ReinforceQueue"
+	MsgReinforceStandby            MessageID = "ReinforceStandby"
+	MsgReinforceControl            MessageID = "ReinforceControl"
+	MsgReinforceCurrentFunds       MessageID = "ReinforceCurrentFunds"
+	MsgReinforceTipPoint           MessageID = "ReinforceTipPoint"
+	MsgReinforceTipShip            MessageID = "ReinforceTipShip"
+	MsgReinforceTipSummon          MessageID = "ReinforceTipSummon"
+	MsgReinforceTipCancel          MessageID = "ReinforceTipCancel"
+	MsgReinforceTipRally           MessageID = "ReinforceTipRally"
+	MsgValueKnots                  MessageID = "ValueKnots"
+	MsgValueArmor                  MessageID = "ValueArmor"
+	MsgValueCost                   MessageID = "ValueCost"
+	MsgTypeWithAbbr                MessageID = "TypeWithAbbr"
+	MsgLabelValue                  MessageID = "LabelValue"
+	MsgItemCount                   MessageID = "ItemCount"
+	MsgTypeSlash                   MessageID = "TypeSlash"
 )
@@ -5,6 +5,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/samber/lo"
 
+	"github.com/narasux/jutland/pkg/config"
 	"github.com/narasux/jutland/pkg/mission/object"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
 	"github.com/narasux/jutland/pkg/mission/state"
@@ -109,29 +110,29 @@ func DetectCursorPosOnMap(misState *state.MissionState) *objPos.MapPos {
 	return lo.ToPtr(misState.ScreenToCameraPos(float64(sx), float64(sy)))
 }
 
-// 键盘按键与组 ID 的映射关系
+// 按键操作与组 ID 的映射关系
 type KeyGroupIDMapping struct {
-	Key     ebiten.Key
+	Action  config.KeyAction
 	GroupID object.GroupID
 }
 
 var keyGroupIDMap = []KeyGroupIDMapping{
-	{Key: ebiten.KeyDigit1, GroupID: object.GroupID1},
-	{Key: ebiten.KeyDigit2, GroupID: object.GroupID2},
-	{Key: ebiten.KeyDigit3, GroupID: object.GroupID3},
-	{Key: ebiten.KeyDigit4, GroupID: object.GroupID4},
-	{Key: ebiten.KeyDigit5, GroupID: object.GroupID5},
-	{Key: ebiten.KeyDigit6, GroupID: object.GroupID6},
-	{Key: ebiten.KeyDigit7, GroupID: object.GroupID7},
-	{Key: ebiten.KeyDigit8, GroupID: object.GroupID8},
-	{Key: ebiten.KeyDigit9, GroupID: object.GroupID9},
-	{Key: ebiten.KeyDigit0, GroupID: object.GroupID0},
+	{Action: config.KeyActionGroup1, GroupID: object.GroupID1},
+	{Action: config.KeyActionGroup2, GroupID: object.GroupID2},
+	{Action: config.KeyActionGroup3, GroupID: object.GroupID3},
+	{Action: config.KeyActionGroup4, GroupID: object.GroupID4},
+	{Action: config.KeyActionGroup5, GroupID: object.GroupID5},
+	{Action: config.KeyActionGroup6, GroupID: object.GroupID6},
+	{Action: config.KeyActionGroup7, GroupID: object.GroupID7},
+	{Action: config.KeyActionGroup8, GroupID: object.GroupID8},
+	{Action: config.KeyActionGroup9, GroupID: object.GroupID9},
+	{Action: config.KeyActionGroup0, GroupID: object.GroupID0},
 }
 
 // GetGroupIDByPressedKey 探测按键对应的组 ID
 func GetGroupIDByPressedKey() object.GroupID {
 	for _, mapping := range keyGroupIDMap {
-		if inpututil.IsKeyJustPressed(mapping.Key) {
+		if IsActionJustPressed(mapping.Action) {
 			return mapping.GroupID
 		}
 	}
@@ -0,0 +1,33 @@
+package action
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/narasux/jutland/pkg/config"
+)
+
+// ActionKey 操作当前绑定的按键，按键名称无法识别时使用默认按键
+func ActionKey(action config.KeyAction) ebiten.Key {
+	var key ebiten.Key
+	if err := key.UnmarshalText([]byte(config.KeyBinding(action))); err == nil {
+		return key
+	}
+	_ = key.UnmarshalText([]byte(config.DefaultKeyBindings()[action]))
+	return key
+}
+
+// IsActionJustPressed 操作绑定的按键是否刚被按下
+func IsActionJustPressed(action config.KeyAction) bool {
+	return inpututil.IsKeyJustPressed(ActionKey(action))
+}
+
+// IsActionPressed 操作绑定的按键是否被按住
+func IsActionPressed(action config.KeyAction) bool {
+	return ebiten.IsKeyPressed(ActionKey(action))
+}
+
+// IsActionJustReleased 操作绑定的按键是否刚被松开
+func IsActionJustReleased(action config.KeyAction) bool {
+	return inpututil.IsKeyJustReleased(ActionKey(action))
+}
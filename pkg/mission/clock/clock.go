@@ -0,0 +1,45 @@
+// clock 任务时钟
+package clock
+
+import (
+	"math"
+
+	"github.com/narasux/jutland/pkg/common/constants"
+	"github.com/narasux/jutland/pkg/config"
+)
+
+// Clock 按帧推进的任务时钟，替代墙上时间（time.Now）
+// 只有推进模拟时才会走时，因此暂停 / 终端模式下装填，起飞，增援等计时都会冻结；
+// 每帧走时受 config.G.SpeedMultiplier 影响，与移动速度的倍率保持一致
+type Clock struct {
+	// 已推进的帧数
+	Ticks int64
+	// 按倍率折算后的帧数，倍率可能中途变化，因此需要累加而非由帧数换算
+	// 注：累加帧数而非毫秒，避免 1000/60 这类无法精确表示的小数累积误差
+	ScaledTicks float64
+}
+
+// Advance 推进一帧
+func (c *Clock) Advance() {
+	c.AdvanceBy(config.G.SpeedMultiplier)
+}
+
+// AdvanceBy 按指定倍率推进一帧
+func (c *Clock) AdvanceBy(multiplier float64) {
+	c.Ticks++
+	c.ScaledTicks += multiplier
+}
+
+// Now 当前任务时间（毫秒），从 1 开始计数，0 保留用于表示“从未发生”
+func (c *Clock) Now() int64 {
+	return int64(c.ScaledTicks*1e3/constants.MaxTPS) + 1
+}
+
+// Since 计算自 at 起经过的任务时间（毫秒）
+// 注：at 为 0 表示从未发生过（如刚出厂的火炮），视为已经过去无限久
+func Since(now, at int64) int64 {
+	if at == 0 {
+		return math.MaxInt64
+	}
+	return now - at
+}
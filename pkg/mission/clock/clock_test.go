@@ -0,0 +1,29 @@
+package clock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockAdvanceScalesWithMultiplier(t *testing.T) {
+	c := Clock{}
+	require.Equal(t, int64(1), c.Now())
+
+	for range 60 {
+		c.AdvanceBy(1)
+	}
+	require.Equal(t, int64(60), c.Ticks)
+	require.Equal(t, int64(1001), c.Now())
+
+	for range 60 {
+		c.AdvanceBy(2)
+	}
+	require.Equal(t, int64(120), c.Ticks)
+	require.Equal(t, int64(3001), c.Now())
+}
+
+func TestSinceTreatsZeroAsNeverHappened(t *testing.T) {
+	require.Greater(t, Since(1, 0), int64(1e9))
+	require.Equal(t, int64(500), Since(1500, 1000))
+}
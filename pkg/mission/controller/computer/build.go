@@ -0,0 +1,209 @@
+package computer
+
+import (
+	"math"
+
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+// 克制关系：敌方舰种 -> 克制该舰种的己方舰种 & 评分倍率
+var counterBonus = map[objUnit.ShipType]map[objUnit.ShipType]float64{
+	// 战列舰：鱼雷艇 / 驱逐舰的鱼雷 & 己方战列舰的重炮
+	objUnit.ShipTypeBattleShip: {
+		objUnit.ShipTypeDestroyer:   1.5,
+		objUnit.ShipTypeTorpedoBoat: 1.5,
+		objUnit.ShipTypeBattleShip:  1.2,
+	},
+	// 航母：高速舰艇突击，巡洋舰防空
+	objUnit.ShipTypeAircraftCarrier: {
+		objUnit.ShipTypeDestroyer: 1.3,
+		objUnit.ShipTypeCruiser:   1.3,
+	},
+	objUnit.ShipTypeCruiser: {
+		objUnit.ShipTypeBattleShip: 1.3,
+		objUnit.ShipTypeDestroyer:  1.2,
+	},
+	// 轻型舰艇：巡洋舰的速射炮
+	objUnit.ShipTypeDestroyer: {
+		objUnit.ShipTypeCruiser: 1.4,
+		objUnit.ShipTypeFrigate: 1.2,
+	},
+	objUnit.ShipTypeFrigate: {
+		objUnit.ShipTypeCruiser:   1.3,
+		objUnit.ShipTypeDestroyer: 1.2,
+	},
+	objUnit.ShipTypeTorpedoBoat: {
+		objUnit.ShipTypeCruiser: 1.3,
+		objUnit.ShipTypeFrigate: 1.3,
+	},
+}
+
+// 克制关系中的敌方舰种（固定顺序）
+var counterTypes = []objUnit.ShipType{
+	objUnit.ShipTypeBattleShip,
+	objUnit.ShipTypeAircraftCarrier,
+	objUnit.ShipTypeCruiser,
+	objUnit.ShipTypeDestroyer,
+	objUnit.ShipTypeFrigate,
+	objUnit.ShipTypeTorpedoBoat,
+}
+
+const (
+	// 每架在场敌机带来的空中威胁占比
+	planeAirShare = 0.05
+	// 己方作战舰艇达到该数量后，补充一艘医疗船
+	hospitalFleetSize = 6
+)
+
+// fleetComposition 已知敌方舰队组成
+type fleetComposition struct {
+	// 各舰种战力占比
+	typeShares map[objUnit.ShipType]float64
+	// 空中威胁占比（敌方航母战力 & 在场敌机）
+	airShare float64
+}
+
+// enemyComposition 根据记忆中的敌舰与视野内的敌机估算敌方舰队组成
+func (h *ComputerDecisionHandler) enemyComposition(b *battlefield) fleetComposition {
+	total := 0.0
+	byType := map[objUnit.ShipType]float64{}
+	for _, uid := range h.rememberedUids() {
+		mem := h.memory[uid]
+		byType[mem.shipType] += mem.strength
+		total += mem.strength
+	}
+
+	comp := fleetComposition{typeShares: map[objUnit.ShipType]float64{}}
+	if total > 0 {
+		for shipType, s := range byType {
+			comp.typeShares[shipType] = s / total
+		}
+	}
+	comp.airShare = min(1, comp.typeShares[objUnit.ShipTypeAircraftCarrier]+planeAirShare*float64(len(b.enemyPlanes)))
+	return comp
+}
+
+// counterRate 己方舰种对敌方舰队组成的克制倍率
+func (c fleetComposition) counterRate(shipType objUnit.ShipType) float64 {
+	rate := 1.0
+	// 按固定顺序累加（浮点数求和结果与顺序有关）
+	for _, enemyType := range counterTypes {
+		if share := c.typeShares[enemyType]; share > 0 {
+			if bonus, ok := counterBonus[enemyType][shipType]; ok {
+				rate += share * (bonus - 1)
+			}
+		}
+	}
+	return rate
+}
+
+// buildScore 增援战舰的评分：按空中威胁加权的对舰 / 防空战力，乘以克制倍率，再按造价折算
+func (c fleetComposition) buildScore(ship *objUnit.BattleShip, fundsCost int64) float64 {
+	power := float64(ship.CombatPower.AntiShip)*(1-c.airShare) + float64(ship.CombatPower.AntiAir)*c.airShare
+	// 战力评估缺失时按吨位估算
+	if power <= 0 {
+		power = ship.Tonnage / 1000
+	}
+	return power * c.counterRate(ship.Type) / math.Sqrt(float64(max(fundsCost, 1)))
+}
+
+// summon 为己方增援点挑选增援战舰，预算为当前资金扣除已排队战舰的费用
+func (h *ComputerDecisionHandler) summon(b *battlefield) {
+	funds, ok := b.misState.FundsOf(h.player)
+	if !ok {
+		return
+	}
+
+	budget := funds
+	counts := map[objUnit.ShipType]int{}
+	for _, ship := range b.ships {
+		counts[ship.Type]++
+	}
+	for _, rp := range b.reinforcePoints {
+		for _, oncoming := range rp.OncomingShips {
+			budget -= oncoming.FundsCost
+			if ship, ok := objUnit.ShipMap[oncoming.Name]; ok {
+				counts[ship.Type]++
+			}
+		}
+	}
+
+	comp := h.enemyComposition(b)
+	for _, rp := range b.reinforcePoints {
+		if len(rp.OncomingShips) >= rp.MaxOncomingShip {
+			continue
+		}
+		summonInstr := instr.NewShipSummon(rp.Uid, "")
+		if _, ok := b.curInstructions[summonInstr.Uid()]; ok {
+			continue
+		}
+		shipName := h.planShip(b, rp, budget, comp, counts)
+		if shipName == "" {
+			continue
+		}
+		fundsCost, _ := objUnit.GetShipCost(shipName)
+		budget -= fundsCost
+		counts[objUnit.ShipMap[shipName].Type]++
+
+		summonInstr = instr.NewShipSummon(rp.Uid, shipName)
+		b.instructions[summonInstr.Uid()] = summonInstr
+	}
+}
+
+// planShip 挑选增援战舰：先补足采油的货轮与医疗船，再挑选评分最高（简单难度为随机）的作战舰艇，预算不足时返回空
+func (h *ComputerDecisionHandler) planShip(
+	b *battlefield,
+	rp *objBuilding.ReinforcePoint,
+	budget int64,
+	comp fleetComposition,
+	counts map[objUnit.ShipType]int,
+) string {
+	var cargo, hospital string
+	var candidates []string
+	for _, name := range rp.ProvidedShipNames {
+		ship, ok := objUnit.ShipMap[name]
+		if !ok {
+			continue
+		}
+		if fundsCost, _ := objUnit.GetShipCost(name); fundsCost > budget {
+			continue
+		}
+		switch ship.Type {
+		case objUnit.ShipTypeCargo:
+			if cargo == "" {
+				cargo = name
+			}
+		case objUnit.ShipTypeHospital:
+			if hospital == "" {
+				hospital = name
+			}
+		default:
+			candidates = append(candidates, name)
+		}
+	}
+
+	// 每座油井一艘货轮
+	if cargo != "" && counts[objUnit.ShipTypeCargo] < len(b.oilPlatforms) {
+		return cargo
+	}
+	if hospital != "" && counts[objUnit.ShipTypeHospital] == 0 && len(b.combatShips) >= hospitalFleetSize {
+		return hospital
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	if !b.params.planBuild {
+		return candidates[h.rng.IntN(len(candidates))]
+	}
+
+	best, bestScore := "", 0.0
+	for _, name := range candidates {
+		fundsCost, _ := objUnit.GetShipCost(name)
+		if score := comp.buildScore(objUnit.ShipMap[name], fundsCost); best == "" || score > bestScore {
+			best, bestScore = name, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,101 @@
+package computer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// registerTestShipTemplates 注册测试用战舰模板，测试结束后移除
+func registerTestShipTemplates(t *testing.T, templates ...*objUnit.BattleShip) {
+	for _, tmpl := range templates {
+		objUnit.ShipMap[tmpl.Name] = tmpl
+	}
+	t.Cleanup(func() {
+		for _, tmpl := range templates {
+			delete(objUnit.ShipMap, tmpl.Name)
+		}
+	})
+}
+
+// newBuildTestState 创建带有电脑增援点 & 油井的测试任务状态
+func newBuildTestState(funds int64, ships ...*objUnit.BattleShip) (*state.MissionState, *objBuilding.ReinforcePoint) {
+	misState := newTestState(state.DifficultyNormal, ships...)
+	misState.Player.Funds = map[faction.Player]int64{faction.HumanAlpha: 0, faction.ComputerAlpha: funds}
+	rp := objBuilding.NewReinforcePoint(
+		"CA/rp-0", objPos.New(30, 30), 0, objPos.New(25, 25), faction.ComputerAlpha, 5,
+		[]string{"test-battleship", "test-cruiser", "test-destroyer", "test-cargo"},
+	)
+	misState.Arena.ReinforcePoints = map[string]*objBuilding.ReinforcePoint{rp.Uid: rp}
+	op := objBuilding.NewOilPlatform(objPos.New(20, 5), 3, 10)
+	misState.Arena.OilPlatforms = map[string]*objBuilding.OilPlatform{op.Uid: op}
+	return misState, rp
+}
+
+func summonedShipName(t *testing.T, misState *state.MissionState, rp *objBuilding.ReinforcePoint) string {
+	record, ok := handle(t, misState)[instr.GenInstrUid(instr.NameShipSummon, rp.Uid)]
+	if !ok {
+		return ""
+	}
+	return record.ShipName
+}
+
+func TestSummonPlansByBudgetAndEnemyFleet(t *testing.T) {
+	registerTestShipTemplates(
+		t,
+		&objUnit.BattleShip{Name: "test-battleship", Type: objUnit.ShipTypeBattleShip, FundsCost: 5000},
+		&objUnit.BattleShip{Name: "test-cruiser", Type: objUnit.ShipTypeCruiser, FundsCost: 500, Tonnage: 10000},
+		&objUnit.BattleShip{Name: "test-destroyer", Type: objUnit.ShipTypeDestroyer, FundsCost: 500, Tonnage: 10000},
+		&objUnit.BattleShip{Name: "test-cargo", Type: objUnit.ShipTypeCargo, FundsCost: 100},
+	)
+
+	// 没有货轮时，优先召唤货轮去采油
+	misState, rp := newBuildTestState(1200)
+	require.Equal(t, "test-cargo", summonedShipName(t, misState, rp))
+
+	// 敌方是战列舰时召唤驱逐舰，战列舰超出预算
+	cargo := newTestShip("cargo", objUnit.ShipTypeCargo, faction.ComputerAlpha, 20, 5)
+	enemy := newTestShip("enemy", objUnit.ShipTypeBattleShip, faction.HumanAlpha, 5, 35)
+	misState, rp = newBuildTestState(1200, cargo, enemy)
+	require.Equal(t, "test-destroyer", summonedShipName(t, misState, rp))
+
+	// 敌方是驱逐舰时召唤巡洋舰
+	enemy.Type = objUnit.ShipTypeDestroyer
+	misState, rp = newBuildTestState(1200, cargo, enemy)
+	require.Equal(t, "test-cruiser", summonedShipName(t, misState, rp))
+
+	// 已排队战舰的费用计入预算，剩余资金不足时不召唤
+	misState, rp = newBuildTestState(1200, cargo, enemy)
+	rp.Summon("test-cruiser")
+	rp.Summon("test-cruiser")
+	require.Equal(t, "", summonedShipName(t, misState, rp))
+
+	// 没有资金的玩家不召唤
+	misState, rp = newBuildTestState(1200, cargo, enemy)
+	misState.Player.Funds = nil
+	require.Equal(t, "", summonedShipName(t, misState, rp))
+}
+
+func TestCargoHarvestsSafeOilPlatform(t *testing.T) {
+	cargo := newTestShip("cargo", objUnit.ShipTypeCargo, faction.ComputerAlpha, 20, 20)
+	misState, _ := newBuildTestState(0, cargo)
+
+	records := handle(t, misState)
+	record, ok := moveRecord(records, cargo.Uid)
+	require.True(t, ok)
+	require.Equal(t, 20, record.TargetPos.MX)
+	require.Equal(t, 5, record.TargetPos.MY)
+
+	// 油井附近有敌舰时不去采油
+	enemy := newTestShip("enemy", objUnit.ShipTypeBattleShip, faction.HumanAlpha, 20, 4)
+	misState, _ = newBuildTestState(0, cargo, enemy)
+	_, ok = moveRecord(handle(t, misState), cargo.Uid)
+	require.False(t, ok)
+}
@@ -1,13 +1,15 @@
 package computer
 
 import (
-	"math/rand"
+	"math/rand/v2"
+	"slices"
 
 	"github.com/samber/lo"
 
 	"github.com/narasux/jutland/pkg/mission/controller"
 	"github.com/narasux/jutland/pkg/mission/faction"
 	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
 	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 	"github.com/narasux/jutland/pkg/mission/state"
@@ -16,6 +18,20 @@ import (
 // ComputerDecisionHandler 电脑决策处理器
 type ComputerDecisionHandler struct {
 	player faction.Player
+	// 决策专用随机数，不消耗任务随机数源
+	rng *rand.Rand
+	// 离开视野的敌舰记忆（Key: Uid）
+	memory map[string]*enemyMemory
+	// 上一次下达的移动目标（Key: 战舰 Uid），目标变化不大时不重复下达寻路指令
+	moveTargets map[string]objPos.MapPos
+}
+
+// enemyMemory 敌舰最后一次被看到时的情况
+type enemyMemory struct {
+	pos      objPos.MapPos
+	shipType objUnit.ShipType
+	strength float64
+	seenTick int64
 }
 
 // NewHandler ...
@@ -25,105 +41,205 @@ func NewHandler(player faction.Player) *ComputerDecisionHandler {
 
 var _ controller.InputHandler = (*ComputerDecisionHandler)(nil)
 
+// battlefield 单次决策所需的战场信息
+type battlefield struct {
+	misState        *state.MissionState
+	params          difficultyParams
+	curInstructions map[string]instr.Instruction
+	instructions    map[string]instr.Instruction
+
+	// 己方战舰（按 Uid 排序）& 其中的作战舰艇（不含运输船，医疗船）
+	ships       []*objUnit.BattleShip
+	combatShips []*objUnit.BattleShip
+	// 己方 & 友军的医疗船
+	hospitals []*objUnit.BattleShip
+	// 视野内的敌舰 & 敌机
+	enemies     []*objUnit.BattleShip
+	enemyPlanes []*objUnit.Plane
+	// 敌方增援点的集结点（没有发现敌舰时，往这里搜索）
+	enemyRallyPositions []objPos.MapPos
+	// 己方增援点 & 集结点（防守时的据点）
+	reinforcePoints []*objBuilding.ReinforcePoint
+	rallyPos        *objPos.MapPos
+	// 油井（货轮采油的目标）
+	oilPlatforms []*objBuilding.OilPlatform
+	// 己方作战舰队 & 视野内敌舰的中心
+	center         objPos.MapPos
+	enemyCenter    objPos.MapPos
+	hasEnemyCenter bool
+}
+
 // Handle 处理计算机决策，更新指令集
 // Human is foolish!
 func (h *ComputerDecisionHandler) Handle(
 	curInstructions map[string]instr.Instruction, misState *state.MissionState,
 ) map[string]instr.Instruction {
 	instructions := map[string]instr.Instruction{}
+	if h.rng == nil {
+		h.rng = state.NewPlayerRand(misState.Core.Seed, h.player)
+		h.memory = map[string]*enemyMemory{}
+		h.moveTargets = map[string]objPos.MapPos{}
+	}
+
+	// 决策按难度间隔进行（反应速度），首帧即做一次决策
+	params := paramsOf(misState.Core.Difficulty)
+	if (misState.Core.Clock.Ticks-1)%params.decisionInterval != 0 {
+		return instructions
+	}
+
+	b := h.scan(misState, params)
+	b.curInstructions, b.instructions = curInstructions, instructions
+	h.updateMemory(b)
+	h.summon(b)
+	h.decide(b)
+	return instructions
+}
 
-	// AI 指令：扫描所有增援点，只要可用，就随机召唤增援
-	reinforcePointUid := ""
-	for _, rp := range misState.Arena.ReinforcePoints {
-		if rp.BelongPlayer != h.player {
+// scan 收集战场信息（按固定顺序遍历，保证同一种子下 AI 决策可复现）
+func (h *ComputerDecisionHandler) scan(misState *state.MissionState, params difficultyParams) *battlefield {
+	b := &battlefield{misState: misState, params: params}
+	for _, s := range misState.SortedShips() {
+		if s.Type == objUnit.ShipTypeHospital && misState.IsAlly(h.player, s.BelongPlayer) {
+			b.hospitals = append(b.hospitals, s)
+		}
+		if s.BelongPlayer == h.player {
+			b.ships = append(b.ships, s)
+			if s.Type != objUnit.ShipTypeCargo && s.Type != objUnit.ShipTypeHospital {
+				b.combatShips = append(b.combatShips, s)
+			}
 			continue
 		}
-		reinforcePointUid = rp.Uid
-		if len(rp.OncomingShips) < rp.MaxOncomingShip {
-			summonInstr := instr.NewShipSummon(rp.Uid, "")
-			instructions[summonInstr.Uid()] = summonInstr
+		// 友军 & 中立势力的战舰不是敌人，战争迷雾中的敌舰不能作为目标
+		if misState.IsEnemy(h.player, s.BelongPlayer) && misState.CanSee(h.player, s) {
+			b.enemies = append(b.enemies, s)
 		}
 	}
-
-	var ships, enemyShips []*objUnit.BattleShip
-	for _, s := range misState.Arena.Ships {
-		if s.BelongPlayer == h.player {
-			ships = append(ships, s)
-		} else {
-			enemyShips = append(enemyShips, s)
+	for _, p := range misState.SortedPlanes() {
+		if misState.IsEnemy(h.player, p.BelongPlayer) && p.CurHP > 0 && misState.CanSee(h.player, p) {
+			b.enemyPlanes = append(b.enemyPlanes, p)
 		}
 	}
+	for _, rp := range misState.SortedReinforcePoints() {
+		if rp.BelongPlayer == h.player {
+			// 最后一个己方增援点的集结点作为据点
+			b.reinforcePoints = append(b.reinforcePoints, rp)
+			b.rallyPos = &rp.RallyPos
+		} else if misState.IsEnemy(h.player, rp.BelongPlayer) {
+			b.enemyRallyPositions = append(b.enemyRallyPositions, rp.RallyPos)
+		}
+	}
+	b.oilPlatforms = misState.SortedOilPlatforms()
+
+	if center, ok := centroid(b.combatShips); ok {
+		b.center = center
+	} else if center, ok = centroid(b.ships); ok {
+		b.center = center
+	}
+	b.enemyCenter, b.hasEnemyCenter = centroid(b.enemies)
+	return b
+}
 
-	// 收集敌方飞机
-	var enemyPlanes []*objUnit.Plane
-	for _, p := range misState.Arena.Planes {
-		if p.BelongPlayer != h.player && p.CurHP > 0 {
-			enemyPlanes = append(enemyPlanes, p)
+// updateMemory 记住视野内的敌舰，遗忘已被击沉或太久没有看到的敌舰
+func (h *ComputerDecisionHandler) updateMemory(b *battlefield) {
+	tick := b.misState.Core.Clock.Ticks
+	for _, enemy := range b.enemies {
+		h.memory[enemy.Uid] = &enemyMemory{
+			pos: enemy.CurPos, shipType: enemy.Type, strength: strength(enemy), seenTick: tick,
+		}
+	}
+	for uid, mem := range h.memory {
+		if _, ok := b.misState.Arena.Ships[uid]; !ok || tick-mem.seenTick > b.params.memoryTicks {
+			delete(h.memory, uid)
 		}
 	}
+}
+
+// rememberedUids 按 Uid 排序的记忆中的敌舰
+func (h *ComputerDecisionHandler) rememberedUids() []string {
+	uids := lo.Keys(h.memory)
+	slices.Sort(uids)
+	return uids
+}
 
-	// AI 战舰够多，就开始莽一波
-	isAttackMode := lo.Ternary(len(ships) >= 24 || len(enemyShips) <= 5, true, false)
+// decide 为每艘己方战舰决定行动：撤退 > 医疗船支援 > 货轮采油 > 航母后撤 & 护航 > 货轮护航 > 交战 / 搜索 / 集结
+func (h *ComputerDecisionHandler) decide(b *battlefield) {
+	ownStrength, knownStrength := 0.0, 0.0
+	for _, ship := range b.combatShips {
+		ownStrength += strength(ship)
+	}
+	// 记忆中包含视野内的敌舰（按 Uid 顺序累加，浮点数求和结果与顺序有关）
+	for _, uid := range h.rememberedUids() {
+		knownStrength += h.memory[uid].strength
+	}
+	// 己方战力占优才进攻；没有发现敌舰时，舰船足够多就去敌方增援点附近搜索
+	attack := knownStrength > 0 && ownStrength >= b.params.attackStrengthRatio*knownStrength
+	scout := knownStrength == 0 && len(b.combatShips) >= b.params.scoutShipCount
 
-	for _, ship := range ships {
-		if isAttackMode && len(enemyShips) != 0 {
-			instrUid := instr.GenInstrUid(instr.NameShipMovePath, ship.Uid)
-			// 如果战舰已经在移动了，则跳过
-			if _, ok := curInstructions[instrUid]; !ok {
-				// 进攻模式，随机选一个敌人冲上去
-				enemy := enemyShips[rand.Intn(len(enemyShips))]
-				if ship.CanOnLand() {
-					instructions[instrUid] = instr.NewShipMove(ship.Uid, enemy.CurPos)
-				} else {
-					instructions[instrUid] = instr.NewShipMovePath(
-						ship.Uid, ship.CurPos, enemy.CurPos, ship.CurSpeed,
-					)
-				}
-			}
-		} else if ship.CurPos.OnBorder(
-			float64(misState.Core.MissionMD.MapCfg.Width-2),
-			float64(misState.Core.MissionMD.MapCfg.Height-2),
-		) && reinforcePointUid != "" {
-			// 如果到了边界，且存在增援点，则往自己的增援点的集结点走
-			moveInstr := instr.NewShipMove(
-				ship.Uid, misState.Arena.ReinforcePoints[reinforcePointUid].RallyPos,
-			)
-			instructions[moveInstr.Uid()] = moveInstr
-		} else {
-			shouldMove := false
-
-			// 防御模式，如果附近有敌方飞机，且攻击对象是自己，则需要主动机动规避
-			if ship.CurSpeed == 0 {
-				for _, ep := range enemyPlanes {
-					if ep.CurAttackTarget == ship.Uid && ship.CurPos.Distance(ep.CurPos) < 5 {
-						shouldMove = true
-						break
-					}
-				}
-			}
+	focus := h.focusTargets(b)
+	handled := map[string]bool{}
 
-			// 防御模式，如果附近有敌舰在移动，也主动机动
-			if !shouldMove {
-				for _, enemy := range enemyShips {
-					if ship.CurPos.Distance(enemy.CurPos) < 20 && ship.CurSpeed == 0 && enemy.CurSpeed != 0 {
-						shouldMove = true
-						break
-					}
-				}
-			}
+	for _, ship := range b.ships {
+		h.damageControl(b, ship)
+	}
 
-			if shouldMove {
-				x, y := rand.Intn(11)-5, rand.Intn(11)-5
-				moveInstr := instr.NewShipMove(
-					ship.Uid, objPos.New(
-						misState.Arena.Ships[ship.Uid].CurPos.MX+x,
-						misState.Arena.Ships[ship.Uid].CurPos.MY+y,
-					),
-				)
-				instructions[moveInstr.Uid()] = moveInstr
+	for _, ship := range b.ships {
+		if h.shouldRetreat(b, ship) {
+			h.retreat(b, ship)
+			handled[ship.Uid] = true
+		}
+	}
+	for _, ship := range b.combatShips {
+		if !handled[ship.Uid] && h.shouldResupply(b, ship) {
+			h.resupply(b, ship)
+			handled[ship.Uid] = true
+		}
+	}
+	for _, ship := range b.ships {
+		if ship.Type == objUnit.ShipTypeHospital && !handled[ship.Uid] {
+			h.supportFleet(b, ship)
+			handled[ship.Uid] = true
+		}
+	}
+	harvesting := map[*objBuilding.OilPlatform]int{}
+	for _, ship := range b.ships {
+		if ship.Type == objUnit.ShipTypeCargo {
+			h.harvest(b, ship, harvesting)
+			handled[ship.Uid] = true
+		}
+	}
+	for _, ship := range b.ships {
+		if ship.Type == objUnit.ShipTypeAircraftCarrier && !handled[ship.Uid] {
+			h.holdCarrier(b, ship, focus)
+			handled[ship.Uid] = true
+			for idx, escort := range h.pickEscorts(b, ship, b.params.escortsPerCarrier, handled) {
+				h.escort(b, escort, ship, idx)
+				handled[escort.Uid] = true
 			}
 		}
 	}
+	escorting := map[string]bool{}
+	for _, ship := range b.ships {
+		if ship.Type == objUnit.ShipTypeCargo {
+			h.escortCargo(b, ship, handled, escorting)
+		}
+	}
+	h.releaseDuties(b, escorting)
 
-	return instructions
+	for _, ship := range b.combatShips {
+		if handled[ship.Uid] {
+			continue
+		}
+		if h.evadePlanes(b, ship) {
+			continue
+		}
+		target := h.chooseTarget(b, ship, focus)
+		switch {
+		case target != nil && (attack || h.inDefenseZone(b, ship, target)):
+			h.engage(b, ship, target)
+		case attack || scout:
+			h.search(b, ship)
+		default:
+			h.gather(b, ship)
+		}
+	}
 }
@@ -0,0 +1,245 @@
+package computer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/resources/mapcfg"
+)
+
+// newTestShip 创建测试用战舰（生命值满，对舰射程 10）
+func newTestShip(uid string, shipType objUnit.ShipType, player faction.Player, x, y int) *objUnit.BattleShip {
+	return &objUnit.BattleShip{
+		Uid:          uid,
+		Type:         shipType,
+		Tonnage:      10000,
+		TotalHP:      100,
+		CurHP:        100,
+		CurPos:       objPos.New(x, y),
+		BelongPlayer: player,
+		Weapon:       objUnit.ShipWeapon{MaxToShipRange: 10},
+	}
+}
+
+// newTestState 创建 40x40 全部为海面的测试任务状态，ComputerAlpha 与 HumanAlpha 敌对
+func newTestState(difficulty state.Difficulty, ships ...*objUnit.BattleShip) *state.MissionState {
+	mapData := make(mapcfg.MapData, 40)
+	for idx := range mapData {
+		mapData[idx] = strings.Repeat(".", 40)
+	}
+	misState := &state.MissionState{
+		Core: state.MissionCoreState{
+			MissionMD: metadata.MissionMetadata{
+				MapCfg: &mapcfg.MapCfg{Width: 40, Height: 40, Map: mapData},
+			},
+			Difficulty: difficulty,
+		},
+		Arena: state.MissionArenaState{
+			Ships:  map[string]*objUnit.BattleShip{},
+			Planes: map[string]*objUnit.Plane{},
+		},
+	}
+	misState.Core.Clock.Ticks = 1
+	for _, ship := range ships {
+		misState.Arena.Ships[ship.Uid] = ship
+	}
+	return misState
+}
+
+// handle 执行一次电脑决策，返回生成的指令（按指令记录）
+func handle(t *testing.T, misState *state.MissionState) map[string]instr.Record {
+	h := NewHandler(faction.ComputerAlpha)
+	records := map[string]instr.Record{}
+	for uid, i := range h.Handle(map[string]instr.Instruction{}, misState) {
+		record, err := instr.Encode(i)
+		require.NoError(t, err)
+		records[uid] = record
+	}
+	return records
+}
+
+func attackRecord(records map[string]instr.Record, shipUid string) (instr.Record, bool) {
+	record, ok := records[instr.GenInstrUid(instr.NameShipAttack, shipUid)]
+	return record, ok
+}
+
+func moveRecord(records map[string]instr.Record, shipUid string) (instr.Record, bool) {
+	record, ok := records[instr.GenInstrUid(instr.NameShipMove, shipUid)]
+	return record, ok
+}
+
+func TestRankTargetsPrefersValuableTargets(t *testing.T) {
+	center := objPos.New(10, 10)
+	destroyer := newTestShip("destroyer", objUnit.ShipTypeDestroyer, faction.HumanAlpha, 15, 10)
+	carrier := newTestShip("carrier", objUnit.ShipTypeAircraftCarrier, faction.HumanAlpha, 15, 10)
+	damaged := newTestShip("damaged", objUnit.ShipTypeDestroyer, faction.HumanAlpha, 15, 10)
+	damaged.CurHP = 10
+
+	ranked := rankTargets([]*objUnit.BattleShip{destroyer, carrier, damaged}, center)
+	require.Equal(t, []string{"damaged", "carrier", "destroyer"}, []string{ranked[0].Uid, ranked[1].Uid, ranked[2].Uid})
+
+	// 距离舰队中心越远，价值越低
+	farCarrier := newTestShip("far-carrier", objUnit.ShipTypeAircraftCarrier, faction.HumanAlpha, 39, 39)
+	ranked = rankTargets([]*objUnit.BattleShip{farCarrier, destroyer}, center)
+	require.Equal(t, "destroyer", ranked[0].Uid)
+}
+
+func TestPointAtDistance(t *testing.T) {
+	pos := pointAtDistance(objPos.NewR(0, 0), objPos.NewR(10, 0), 4)
+	require.InDelta(t, 6, pos.RX, 1e-9)
+	require.InDelta(t, 0, pos.RY, 1e-9)
+}
+
+func TestFocusFireOnMostValuableTarget(t *testing.T) {
+	misState := newTestState(
+		state.DifficultyHard,
+		newTestShip("cruiser-1", objUnit.ShipTypeCruiser, faction.ComputerAlpha, 10, 10),
+		newTestShip("cruiser-2", objUnit.ShipTypeCruiser, faction.ComputerAlpha, 10, 12),
+		newTestShip("cruiser-3", objUnit.ShipTypeCruiser, faction.ComputerAlpha, 10, 14),
+		newTestShip("enemy-destroyer", objUnit.ShipTypeDestroyer, faction.HumanAlpha, 18, 10),
+		newTestShip("enemy-carrier", objUnit.ShipTypeAircraftCarrier, faction.HumanAlpha, 18, 12),
+	)
+
+	records := handle(t, misState)
+	for _, uid := range []string{"cruiser-1", "cruiser-2", "cruiser-3"} {
+		record, ok := attackRecord(records, uid)
+		require.True(t, ok, uid)
+		require.Equal(t, "enemy-carrier", record.TargetUid, uid)
+		// 已经在射程边缘，不需要移动
+		_, ok = moveRecord(records, uid)
+		require.False(t, ok, uid)
+	}
+}
+
+func TestBattleShipKeepsGunRange(t *testing.T) {
+	battleship := newTestShip("battleship", objUnit.ShipTypeBattleShip, faction.ComputerAlpha, 10, 10)
+	destroyer := newTestShip("destroyer", objUnit.ShipTypeDestroyer, faction.ComputerAlpha, 10, 16)
+	enemy := newTestShip("enemy", objUnit.ShipTypeDestroyer, faction.HumanAlpha, 12, 12)
+	misState := newTestState(state.DifficultyNormal, battleship, destroyer, enemy)
+
+	records := handle(t, misState)
+	record, ok := attackRecord(records, battleship.Uid)
+	require.True(t, ok)
+	require.Equal(t, enemy.Uid, record.TargetUid)
+
+	// 战列舰被贴近时后撤到射程边缘
+	record, ok = moveRecord(records, battleship.Uid)
+	require.True(t, ok)
+	require.Greater(t, record.TargetPos.Distance(enemy.CurPos), battleship.CurPos.Distance(enemy.CurPos))
+
+	// 驱逐舰不需要保持距离
+	_, ok = moveRecord(records, destroyer.Uid)
+	require.False(t, ok)
+}
+
+func TestDamagedShipRetreatsToHospital(t *testing.T) {
+	damaged := newTestShip("damaged", objUnit.ShipTypeCruiser, faction.ComputerAlpha, 10, 10)
+	damaged.CurHP = 20
+	hospital := newTestShip("hospital", objUnit.ShipTypeHospital, faction.ComputerAlpha, 30, 30)
+	enemy := newTestShip("enemy", objUnit.ShipTypeBattleShip, faction.HumanAlpha, 15, 10)
+
+	records := handle(t, newTestState(state.DifficultyNormal, damaged, hospital, enemy))
+	record, ok := moveRecord(records, damaged.Uid)
+	require.True(t, ok)
+	require.Equal(t, hospital.CurPos.MX, record.TargetPos.MX)
+	require.Equal(t, hospital.CurPos.MY, record.TargetPos.MY)
+	_, ok = attackRecord(records, damaged.Uid)
+	require.False(t, ok)
+
+	// 简单难度不撤退
+	records = handle(t, newTestState(state.DifficultyEasy, damaged, hospital, enemy))
+	record, ok = attackRecord(records, damaged.Uid)
+	require.True(t, ok)
+	require.Equal(t, enemy.Uid, record.TargetUid)
+}
+
+func TestBurningShipUsesDamageControl(t *testing.T) {
+	burning := newTestShip("burning", objUnit.ShipTypeCruiser, faction.ComputerAlpha, 10, 10)
+	burning.Hazards.Fires = []int64{10000, 20000}
+
+	records := handle(t, newTestState(state.DifficultyNormal, burning))
+	require.Contains(t, records, instr.GenInstrUid(instr.NameShipDamageControl, burning.Uid))
+
+	// 冷却中不损管，简单难度从不损管
+	burning.Hazards.DamageControlReadyAt = 5000
+	records = handle(t, newTestState(state.DifficultyNormal, burning))
+	require.NotContains(t, records, instr.GenInstrUid(instr.NameShipDamageControl, burning.Uid))
+	burning.Hazards.DamageControlReadyAt = 0
+	records = handle(t, newTestState(state.DifficultyEasy, burning))
+	require.NotContains(t, records, instr.GenInstrUid(instr.NameShipDamageControl, burning.Uid))
+}
+
+func TestLowAmmoShipReturnsToResupply(t *testing.T) {
+	destroyer := newTestShip("destroyer", objUnit.ShipTypeDestroyer, faction.ComputerAlpha, 30, 30)
+	destroyer.Weapon.Torpedoes = []*objUnit.TorpedoLauncher{{BulletCount: 4, AmmoCapacity: 8}}
+	cargo := newTestShip("cargo", objUnit.ShipTypeCargo, faction.ComputerAlpha, 10, 10)
+	enemy := newTestShip("enemy", objUnit.ShipTypeBattleShip, faction.HumanAlpha, 35, 30)
+
+	records := handle(t, newTestState(state.DifficultyNormal, destroyer, cargo, enemy))
+	record, ok := moveRecord(records, destroyer.Uid)
+	require.True(t, ok)
+	require.Equal(t, cargo.CurPos.MX, record.TargetPos.MX)
+	require.Equal(t, cargo.CurPos.MY, record.TargetPos.MY)
+	_, ok = attackRecord(records, destroyer.Uid)
+	require.False(t, ok)
+
+	// 简单难度不主动补给
+	records = handle(t, newTestState(state.DifficultyEasy, destroyer, cargo, enemy))
+	_, ok = attackRecord(records, destroyer.Uid)
+	require.True(t, ok)
+
+	// 燃油不足同样回去补给
+	destroyer.Weapon.Torpedoes[0].Ammo = 8
+	destroyer.FuelCapacity, destroyer.Fuel = 1500, 100
+	records = handle(t, newTestState(state.DifficultyNormal, destroyer, cargo, enemy))
+	record, ok = moveRecord(records, destroyer.Uid)
+	require.True(t, ok)
+	require.Equal(t, cargo.CurPos.MX, record.TargetPos.MX)
+}
+
+func TestEscortsScreenCarrier(t *testing.T) {
+	carrier := newTestShip("carrier", objUnit.ShipTypeAircraftCarrier, faction.ComputerAlpha, 10, 10)
+	escort1 := newTestShip("escort-1", objUnit.ShipTypeDestroyer, faction.ComputerAlpha, 25, 25)
+	escort2 := newTestShip("escort-2", objUnit.ShipTypeDestroyer, faction.ComputerAlpha, 26, 25)
+	other := newTestShip("other", objUnit.ShipTypeDestroyer, faction.ComputerAlpha, 35, 35)
+
+	records := handle(t, newTestState(state.DifficultyHard, carrier, escort1, escort2, other))
+	for _, escort := range []*objUnit.BattleShip{escort1, escort2} {
+		record, ok := moveRecord(records, escort.Uid)
+		require.True(t, ok, escort.Uid)
+		require.Less(t, record.TargetPos.Distance(carrier.CurPos), float64(escortDistance*2), escort.Uid)
+	}
+	// 每艘航母只分配两艘护航舰，其余战舰留在原地防守
+	_, ok := moveRecord(records, other.Uid)
+	require.False(t, ok)
+}
+
+func TestCargoGetsEscort(t *testing.T) {
+	cargo := newTestShip("cargo", objUnit.ShipTypeCargo, faction.ComputerAlpha, 10, 10)
+	escort := newTestShip("escort", objUnit.ShipTypeDestroyer, faction.ComputerAlpha, 25, 25)
+	other := newTestShip("other", objUnit.ShipTypeDestroyer, faction.ComputerAlpha, 35, 35)
+
+	records := handle(t, newTestState(state.DifficultyNormal, cargo, escort, other))
+	record, ok := records[instr.GenInstrUid(instr.NameShipEscort, escort.Uid)]
+	require.True(t, ok)
+	require.Equal(t, cargo.Uid, record.TargetUid)
+	_, ok = records[instr.GenInstrUid(instr.NameShipEscort, other.Uid)]
+	require.False(t, ok)
+
+	// 已经在护航的战舰不重复下达指令；货轮沉没后护航舰取消值守任务
+	escort.AssignDuty(objUnit.ShipDuty{Type: objUnit.DutyEscort, TargetUid: cargo.Uid})
+	records = handle(t, newTestState(state.DifficultyNormal, cargo, escort, other))
+	_, ok = records[instr.GenInstrUid(instr.NameShipEscort, escort.Uid)]
+	require.False(t, ok)
+	records = handle(t, newTestState(state.DifficultyNormal, escort, other))
+	_, ok = records[instr.GenInstrUid(instr.NameShipClearOrders, escort.Uid)]
+	require.True(t, ok)
+}
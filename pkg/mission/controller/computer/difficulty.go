@@ -0,0 +1,86 @@
+package computer
+
+import "github.com/narasux/jutland/pkg/mission/state"
+
+// difficultyParams 各难度下的 AI 决策参数
+type difficultyParams struct {
+	// 决策（战术 & 增援）间隔（帧）
+	decisionInterval int64
+	// 是否按预算与敌方舰队组成挑选增援战舰（否则随机挑选买得起的战舰）
+	planBuild bool
+	// 己方战力达到已知敌方战力的多少倍时转入进攻
+	attackStrengthRatio float64
+	// 没有发现任何敌舰时，己方战舰达到多少艘才去敌方增援点附近搜索
+	scoutShipCount int
+	// 集火目标数量（0 表示每艘战舰随机选择视野内的敌舰，不集火）
+	focusTargets int
+	// 生命值比例低于该值时撤退（0 表示从不撤退）
+	retreatHPRate float64
+	// 战列舰 / 重巡是否保持在火炮射程边缘，不主动贴近
+	keepGunRange bool
+	// 每艘航母分配的护航舰数量
+	escortsPerCarrier int
+	// 每艘离开据点采油的货轮分配的护航舰数量
+	escortsPerCargo int
+	// 是否规避正在攻击自己的敌机
+	evadePlanes bool
+	// 离开视野的敌舰在记忆中保留的帧数（0 表示不记忆）
+	memoryTicks int64
+	// 火灾 + 进水达到多少处时执行损管（0 表示从不损管）
+	damageControlHazards int
+	// 剩余弹药 / 燃油比例低于该值时回到增援点 / 货轮补给（0 表示从不主动补给）
+	resupplyRate float64
+}
+
+var difficultyParamsMap = map[state.Difficulty]difficultyParams{
+	state.DifficultyEasy: {
+		decisionInterval:     90,
+		planBuild:            false,
+		attackStrengthRatio:  0.8,
+		scoutShipCount:       16,
+		focusTargets:         0,
+		retreatHPRate:        0,
+		keepGunRange:         false,
+		escortsPerCarrier:    0,
+		escortsPerCargo:      0,
+		evadePlanes:          false,
+		memoryTicks:          0,
+		damageControlHazards: 0,
+		resupplyRate:         0,
+	},
+	state.DifficultyNormal: {
+		decisionInterval:     45,
+		planBuild:            true,
+		attackStrengthRatio:  1.2,
+		scoutShipCount:       10,
+		focusTargets:         2,
+		retreatHPRate:        0.3,
+		keepGunRange:         true,
+		escortsPerCarrier:    1,
+		escortsPerCargo:      1,
+		evadePlanes:          true,
+		memoryTicks:          600,
+		damageControlHazards: 2,
+		resupplyRate:         0.15,
+	},
+	state.DifficultyHard: {
+		decisionInterval:     15,
+		planBuild:            true,
+		attackStrengthRatio:  1.0,
+		scoutShipCount:       8,
+		focusTargets:         1,
+		retreatHPRate:        0.4,
+		keepGunRange:         true,
+		escortsPerCarrier:    2,
+		escortsPerCargo:      1,
+		evadePlanes:          true,
+		memoryTicks:          1800,
+		damageControlHazards: 1,
+		resupplyRate:         0.25,
+	},
+}
+
+// paramsOf 获取难度对应的决策参数
+func paramsOf(difficulty state.Difficulty) difficultyParams {
+	return difficultyParamsMap[difficulty.OrDefault()]
+}
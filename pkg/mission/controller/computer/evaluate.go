@@ -0,0 +1,109 @@
+package computer
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+// 各舰种的目标价值权重（航母会持续出动舰载机，医疗船会治疗敌军，优先击沉）
+var targetTypeWeights = map[objUnit.ShipType]float64{
+	objUnit.ShipTypeAircraftCarrier: 2.0,
+	objUnit.ShipTypeHospital:        1.5,
+	objUnit.ShipTypeBattleShip:      1.2,
+	objUnit.ShipTypeCargo:           0.5,
+}
+
+// firepower 战舰对舰火力（每秒伤害），战力评估缺失时（如单元测试中直接构造的战舰）按吨位估算
+func firepower(ship *objUnit.BattleShip) float64 {
+	if dps := ship.CombatPower.Details.AntiShipDPS; dps > 0 {
+		return dps
+	}
+	return max(1, ship.Tonnage/1000)
+}
+
+// strength 战舰当前战力：火力与剩余生命值的几何平均（兰彻斯特平方律下，集中兵力的总战力按平方增长）
+func strength(ship *objUnit.BattleShip) float64 {
+	return math.Sqrt(firepower(ship) * max(ship.CurHP, 0))
+}
+
+// hpRate 战舰剩余生命值比例
+func hpRate(ship *objUnit.BattleShip) float64 {
+	if ship.TotalHP <= 0 {
+		return 1
+	}
+	return ship.CurHP / ship.TotalHP
+}
+
+// targetValue 敌舰作为集火目标的价值：单位剩余生命值的火力越高（越快能击沉的威胁），价值越大
+func targetValue(enemy *objUnit.BattleShip) float64 {
+	weight, ok := targetTypeWeights[enemy.Type]
+	if !ok {
+		weight = 1
+	}
+	return weight * firepower(enemy) / max(enemy.CurHP, 1)
+}
+
+// threatTo 敌舰对指定位置的威胁（位置在其射程内时为其火力，否则为 0）
+func threatTo(enemy *objUnit.BattleShip, pos objPos.MapPos) float64 {
+	if enemy.CurPos.Distance(pos) > enemy.Weapon.MaxToShipRange+2 {
+		return 0
+	}
+	return firepower(enemy)
+}
+
+// rankTargets 按价值（距舰队中心越远，价值越低）从高到低排序敌舰，价值相同时按 Uid 排序保证结果稳定
+func rankTargets(enemies []*objUnit.BattleShip, center objPos.MapPos) []*objUnit.BattleShip {
+	type scored struct {
+		ship  *objUnit.BattleShip
+		score float64
+	}
+	scores := make([]scored, 0, len(enemies))
+	for _, enemy := range enemies {
+		scores = append(scores, scored{
+			ship:  enemy,
+			score: targetValue(enemy) / (1 + enemy.CurPos.Distance(center)/20),
+		})
+	}
+	slices.SortFunc(scores, func(a, b scored) int {
+		if a.score != b.score {
+			if a.score > b.score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.ship.Uid, b.ship.Uid)
+	})
+
+	ranked := make([]*objUnit.BattleShip, 0, len(scores))
+	for _, s := range scores {
+		ranked = append(ranked, s.ship)
+	}
+	return ranked
+}
+
+// centroid 计算战舰的几何中心（没有战舰时返回 false）
+func centroid(ships []*objUnit.BattleShip) (objPos.MapPos, bool) {
+	if len(ships) == 0 {
+		return objPos.MapPos{}, false
+	}
+	sumX, sumY := 0.0, 0.0
+	for _, ship := range ships {
+		sumX += ship.CurPos.RX
+		sumY += ship.CurPos.RY
+	}
+	return objPos.NewR(sumX/float64(len(ships)), sumY/float64(len(ships))), true
+}
+
+// pointAtDistance 计算从 from 指向 to 方向上，距离 to 为 distance 的位置（用于保持射程）
+func pointAtDistance(from, to objPos.MapPos, distance float64) objPos.MapPos {
+	total := from.Distance(to)
+	if total == 0 {
+		return from
+	}
+	ratio := distance / total
+	return objPos.NewR(to.RX+(from.RX-to.RX)*ratio, to.RY+(from.RY-to.RY)*ratio)
+}
@@ -0,0 +1,428 @@
+package computer
+
+import (
+	"slices"
+
+	"github.com/samber/lo"
+
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+const (
+	// 移动目标与上一次下达的目标相距小于该值时，不重复下达移动指令
+	moveRetargetDistance = 3
+	// 防守时，敌舰进入集结点周围该范围内即迎击
+	defenseRadius = 20
+	// 防守 / 集结时，离开据点超过该距离才会返回
+	gatherRadius = 10
+	// 航母在舰队中心后方保持的距离
+	carrierStandOff = 10
+	// 护航舰与航母保持的距离
+	escortDistance = 3
+	// 敌机距离小于该值且正在攻击自己时，需要机动规避
+	planeEvadeDistance = 5
+	// 选择油井时，每艘已分配的货轮相当于增加的距离
+	oilPlatformCrowdPenalty = 30
+)
+
+// focusTargets 集火目标：按价值排序的前若干艘敌舰（简单难度不集火，返回 nil）
+func (h *ComputerDecisionHandler) focusTargets(b *battlefield) []*objUnit.BattleShip {
+	if b.params.focusTargets == 0 || len(b.enemies) == 0 {
+		return nil
+	}
+	ranked := rankTargets(b.enemies, b.center)
+	return ranked[:min(b.params.focusTargets, len(ranked))]
+}
+
+// chooseTarget 为战舰选择攻击目标：集火目标中最近的一艘；不集火时沿用当前目标，或随机选择视野内的敌舰
+func (h *ComputerDecisionHandler) chooseTarget(
+	b *battlefield, ship *objUnit.BattleShip, focus []*objUnit.BattleShip,
+) *objUnit.BattleShip {
+	if len(b.enemies) == 0 {
+		return nil
+	}
+	if len(focus) == 0 {
+		for _, enemy := range b.enemies {
+			if enemy.Uid == ship.AttackTarget {
+				return enemy
+			}
+		}
+		return b.enemies[h.rng.IntN(len(b.enemies))]
+	}
+	target := focus[0]
+	for _, enemy := range focus[1:] {
+		if ship.CurPos.Distance(enemy.CurPos) < ship.CurPos.Distance(target.CurPos) {
+			target = enemy
+		}
+	}
+	return target
+}
+
+// inDefenseZone 防守时是否迎击该敌舰（已经进入己方射程，或逼近己方据点）
+func (h *ComputerDecisionHandler) inDefenseZone(b *battlefield, ship, enemy *objUnit.BattleShip) bool {
+	if ship.CurPos.Distance(enemy.CurPos) <= ship.Weapon.MaxToShipRange*1.5 {
+		return true
+	}
+	anchor := h.anchor(b)
+	return anchor.Distance(enemy.CurPos) <= defenseRadius
+}
+
+// engage 攻击目标：指定攻击目标，并按舰种保持交战距离（战列舰 / 重巡停在射程边缘，轻型舰艇抵近到鱼雷射程）
+func (h *ComputerDecisionHandler) engage(b *battlefield, ship, target *objUnit.BattleShip) {
+	if ship.AttackTarget != target.Uid {
+		attackInstr := instr.NewShipAttack(ship.Uid, target.Uid)
+		b.instructions[attackInstr.Uid()] = attackInstr
+	}
+
+	desired := max(ship.Weapon.MaxToShipRange*0.6, 2)
+	heavy := ship.Type == objUnit.ShipTypeBattleShip || ship.Type == objUnit.ShipTypeCruiser
+	if b.params.keepGunRange && heavy {
+		desired = max(ship.Weapon.MaxToShipRange*0.85, 2)
+	}
+	distance := ship.CurPos.Distance(target.CurPos)
+	if distance > desired+2 {
+		h.move(b, ship, pointAtDistance(ship.CurPos, target.CurPos, desired))
+	} else if b.params.keepGunRange && heavy && distance < desired*0.6 {
+		// 被敌舰贴近时后撤，拉开到射程边缘
+		h.move(b, ship, pointAtDistance(ship.CurPos, target.CurPos, desired))
+	}
+}
+
+// search 搜索敌舰：前往最近的记忆中的敌舰位置，没有记忆时前往最近的敌方集结点
+func (h *ComputerDecisionHandler) search(b *battlefield, ship *objUnit.BattleShip) {
+	var positions []objPos.MapPos
+	for _, uid := range h.rememberedUids() {
+		positions = append(positions, h.memory[uid].pos)
+	}
+	if len(positions) == 0 {
+		positions = b.enemyRallyPositions
+	}
+	if len(positions) == 0 {
+		h.gather(b, ship)
+		return
+	}
+	target := positions[0]
+	for _, pos := range positions[1:] {
+		if ship.CurPos.Distance(pos) < ship.CurPos.Distance(target) {
+			target = pos
+		}
+	}
+	h.move(b, ship, target)
+}
+
+// gather 防守：离据点太远（或到达地图边界）时返回据点
+func (h *ComputerDecisionHandler) gather(b *battlefield, ship *objUnit.BattleShip) {
+	if b.rallyPos == nil {
+		return
+	}
+	mapCfg := b.misState.Core.MissionMD.MapCfg
+	if ship.CurPos.Distance(*b.rallyPos) > gatherRadius ||
+		ship.CurPos.OnBorder(float64(mapCfg.Width-2), float64(mapCfg.Height-2)) {
+		h.move(b, ship, *b.rallyPos)
+	}
+}
+
+// damageControl 火灾 / 进水达到一定数量且损管冷却完毕时执行损管（不影响其他决策）
+func (h *ComputerDecisionHandler) damageControl(b *battlefield, ship *objUnit.BattleShip) {
+	if b.params.damageControlHazards <= 0 || !ship.CanDamageControl() ||
+		ship.OnFire()+ship.Flooding() < b.params.damageControlHazards {
+		return
+	}
+	controlInstr := instr.NewShipDamageControl(ship.Uid)
+	b.instructions[controlInstr.Uid()] = controlInstr
+}
+
+// shouldRetreat 受损严重的战舰撤退（运输船，医疗船不参与）
+func (h *ComputerDecisionHandler) shouldRetreat(b *battlefield, ship *objUnit.BattleShip) bool {
+	if b.params.retreatHPRate <= 0 ||
+		ship.Type == objUnit.ShipTypeCargo || ship.Type == objUnit.ShipTypeHospital {
+		return false
+	}
+	return hpRate(ship) < b.params.retreatHPRate
+}
+
+// retreat 撤退到最近的医疗船，没有医疗船时撤回集结点
+func (h *ComputerDecisionHandler) retreat(b *battlefield, ship *objUnit.BattleShip) {
+	var hospital *objUnit.BattleShip
+	for _, s := range b.hospitals {
+		if hospital == nil || ship.CurPos.Distance(s.CurPos) < ship.CurPos.Distance(hospital.CurPos) {
+			hospital = s
+		}
+	}
+	if hospital != nil {
+		if ship.CurPos.Distance(hospital.CurPos) > objUnit.HospitalShipEffectRange*0.7 {
+			h.move(b, ship, hospital.CurPos)
+		}
+		return
+	}
+	if b.rallyPos != nil && ship.CurPos.Distance(*b.rallyPos) > moveRetargetDistance {
+		h.move(b, ship, *b.rallyPos)
+	}
+}
+
+// supplyPositions 己方增援点 & 货轮的位置（可以补给弹药 & 燃油）
+func (h *ComputerDecisionHandler) supplyPositions(b *battlefield) []objPos.MapPos {
+	positions := []objPos.MapPos{}
+	for _, rp := range b.reinforcePoints {
+		positions = append(positions, rp.Pos)
+	}
+	for _, ship := range b.ships {
+		if ship.Type == objUnit.ShipTypeCargo {
+			positions = append(positions, ship.CurPos)
+		}
+	}
+	return positions
+}
+
+// shouldResupply 弹药 / 燃油不足的战舰回去补给，已经在补给范围内的补满再走
+func (h *ComputerDecisionHandler) shouldResupply(b *battlefield, ship *objUnit.BattleShip) bool {
+	if b.params.resupplyRate <= 0 {
+		return false
+	}
+	rate := min(ship.Weapon.AmmoRate(), ship.FuelRate())
+	if rate >= 1 {
+		return false
+	}
+	positions := h.supplyPositions(b)
+	if len(positions) == 0 {
+		return false
+	}
+	return rate < b.params.resupplyRate || lo.ContainsBy(positions, func(pos objPos.MapPos) bool {
+		return ship.CurPos.Near(pos, objUnit.ResupplyRange)
+	})
+}
+
+// resupply 前往最近的己方增援点 / 货轮补给弹药 & 燃油
+func (h *ComputerDecisionHandler) resupply(b *battlefield, ship *objUnit.BattleShip) {
+	positions := h.supplyPositions(b)
+	target := positions[0]
+	for _, pos := range positions[1:] {
+		if ship.CurPos.Distance(pos) < ship.CurPos.Distance(target) {
+			target = pos
+		}
+	}
+	if ship.CurPos.Distance(target) > objUnit.ResupplyRange*0.7 {
+		h.move(b, ship, target)
+	}
+}
+
+// supportFleet 医疗船：跟随受损最严重、且不在敌舰射程内的己方战舰，否则留在据点
+func (h *ComputerDecisionHandler) supportFleet(b *battlefield, hospital *objUnit.BattleShip) {
+	var patient *objUnit.BattleShip
+	for _, ship := range b.ships {
+		if ship.Uid == hospital.Uid || hpRate(ship) >= 1 || h.threatAt(b, ship.CurPos) > 0 {
+			continue
+		}
+		if patient == nil || hpRate(ship) < hpRate(patient) {
+			patient = ship
+		}
+	}
+	if patient != nil {
+		if hospital.CurPos.Distance(patient.CurPos) > objUnit.HospitalShipEffectRange*0.5 {
+			h.move(b, hospital, patient.CurPos)
+		}
+		return
+	}
+	h.gather(b, hospital)
+}
+
+// harvest 货轮：前往最近的、没有敌舰威胁的油井采油（已分配货轮越多的油井越靠后），没有安全的油井时返回据点
+func (h *ComputerDecisionHandler) harvest(b *battlefield, cargo *objUnit.BattleShip, harvesting map[*objBuilding.OilPlatform]int) {
+	var target *objBuilding.OilPlatform
+	bestScore := 0.0
+	for _, op := range b.oilPlatforms {
+		if h.threatAt(b, op.Pos) > 0 {
+			continue
+		}
+		score := cargo.CurPos.Distance(op.Pos) + oilPlatformCrowdPenalty*float64(harvesting[op])
+		if target == nil || score < bestScore {
+			target, bestScore = op, score
+		}
+	}
+	if target == nil {
+		if b.rallyPos != nil && h.threatAt(b, cargo.CurPos) > 0 {
+			h.move(b, cargo, *b.rallyPos)
+		}
+		return
+	}
+	harvesting[target]++
+	// 留在油井半径内即可装载
+	if cargo.CurPos.Distance(target.Pos) > float64(target.Radius)*0.6 {
+		h.move(b, cargo, target.Pos)
+	}
+}
+
+// holdCarrier 航母：留在舰队中心远离敌舰的一侧，舰载机攻击集火目标
+func (h *ComputerDecisionHandler) holdCarrier(b *battlefield, carrier *objUnit.BattleShip, focus []*objUnit.BattleShip) {
+	if len(focus) != 0 && carrier.AttackTarget != focus[0].Uid {
+		attackInstr := instr.NewShipAttack(carrier.Uid, focus[0].Uid)
+		b.instructions[attackInstr.Uid()] = attackInstr
+	}
+	if !b.hasEnemyCenter {
+		h.gather(b, carrier)
+		return
+	}
+	pos := pointAtDistance(b.center, b.enemyCenter, b.center.Distance(b.enemyCenter)+carrierStandOff)
+	if carrier.CurPos.Distance(pos) > gatherRadius/2 {
+		h.move(b, carrier, pos)
+	}
+}
+
+// pickEscorts 为航母 / 货轮挑选最近的若干艘驱逐舰 / 护卫舰 / 巡洋舰护航（优先沿用正在护航的战舰）
+func (h *ComputerDecisionHandler) pickEscorts(
+	b *battlefield, protectee *objUnit.BattleShip, count int, handled map[string]bool,
+) []*objUnit.BattleShip {
+	if count == 0 {
+		return nil
+	}
+	var candidates []*objUnit.BattleShip
+	for _, ship := range b.combatShips {
+		if handled[ship.Uid] {
+			continue
+		}
+		switch ship.Type {
+		case objUnit.ShipTypeDestroyer, objUnit.ShipTypeFrigate, objUnit.ShipTypeCruiser:
+			candidates = append(candidates, ship)
+		}
+	}
+	escorting := func(s *objUnit.BattleShip) bool {
+		return s.Duty.Type == objUnit.DutyEscort && s.Duty.TargetUid == protectee.Uid
+	}
+	slices.SortStableFunc(candidates, func(a, b *objUnit.BattleShip) int {
+		if ea, eb := escorting(a), escorting(b); ea != eb {
+			return lo.Ternary(ea, -1, 1)
+		}
+		da, db := a.CurPos.Distance(protectee.CurPos), b.CurPos.Distance(protectee.CurPos)
+		switch {
+		case da < db:
+			return -1
+		case da > db:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return candidates[:min(count, len(candidates))]
+}
+
+// escort 护航：攻击逼近航母的敌舰，否则在航母周围保持队形
+func (h *ComputerDecisionHandler) escort(b *battlefield, escort, carrier *objUnit.BattleShip, idx int) {
+	for _, enemy := range b.enemies {
+		if enemy.CurPos.Distance(carrier.CurPos) <= escort.Weapon.MaxToShipRange+escortDistance {
+			h.engage(b, escort, enemy)
+			return
+		}
+	}
+	// 护航舰分布在航母两侧
+	offset := float64(escortDistance * (idx/2 + 1))
+	if idx%2 == 1 {
+		offset = -offset
+	}
+	pos := objPos.NewR(carrier.CurPos.RX+offset, carrier.CurPos.RY+offset/2)
+	if escort.CurPos.Distance(pos) > escortDistance {
+		h.move(b, escort, pos)
+	}
+}
+
+// escortCargo 货轮护航：离开据点的货轮由护航舰跟随（通过护航指令交给任务管理器保持阵位、迎击敌舰）
+func (h *ComputerDecisionHandler) escortCargo(b *battlefield, cargo *objUnit.BattleShip, handled, escorting map[string]bool) {
+	if b.rallyPos != nil && cargo.CurPos.Distance(*b.rallyPos) <= gatherRadius {
+		return
+	}
+	for _, escort := range h.pickEscorts(b, cargo, b.params.escortsPerCargo, handled) {
+		handled[escort.Uid], escorting[escort.Uid] = true, true
+		if escort.Duty.Type == objUnit.DutyEscort && escort.Duty.TargetUid == cargo.Uid {
+			continue
+		}
+		escortInstr := instr.NewShipEscort(escort.Uid, cargo.Uid)
+		b.instructions[escortInstr.Uid()] = escortInstr
+	}
+}
+
+// releaseDuties 不再护航的战舰取消值守任务，交还给战术决策
+func (h *ComputerDecisionHandler) releaseDuties(b *battlefield, escorting map[string]bool) {
+	for _, ship := range b.ships {
+		if ship.HasDuty() && !escorting[ship.Uid] {
+			clearInstr := instr.NewShipClearOrders(ship.Uid)
+			b.instructions[clearInstr.Uid()] = clearInstr
+		}
+	}
+}
+
+// evadePlanes 静止的战舰被敌机近距离攻击时，机动规避（返回是否已下达规避指令）
+func (h *ComputerDecisionHandler) evadePlanes(b *battlefield, ship *objUnit.BattleShip) bool {
+	if !b.params.evadePlanes || ship.CurSpeed != 0 {
+		return false
+	}
+	for _, plane := range b.enemyPlanes {
+		if plane.CurAttackTarget == ship.Uid && ship.CurPos.Distance(plane.CurPos) < planeEvadeDistance {
+			x, y := h.rng.IntN(11)-5, h.rng.IntN(11)-5
+			h.move(b, ship, objPos.New(ship.CurPos.MX+x, ship.CurPos.MY+y))
+			return true
+		}
+	}
+	return false
+}
+
+// anchor 己方据点（集结点，没有增援点时为舰队中心）
+func (h *ComputerDecisionHandler) anchor(b *battlefield) objPos.MapPos {
+	if b.rallyPos != nil {
+		return *b.rallyPos
+	}
+	return b.center
+}
+
+// threatAt 视野内敌舰对指定位置的总威胁
+func (h *ComputerDecisionHandler) threatAt(b *battlefield, pos objPos.MapPos) float64 {
+	threat := 0.0
+	for _, enemy := range b.enemies {
+		threat += threatTo(enemy, pos)
+	}
+	return threat
+}
+
+// move 下达移动指令（目标与上一次相近且仍在移动时跳过），目标在陆地上时改为附近的海面
+func (h *ComputerDecisionHandler) move(b *battlefield, ship *objUnit.BattleShip, pos objPos.MapPos) {
+	pos = h.seaPosNear(b, ship, pos)
+	moving := false
+	for _, name := range []string{instr.NameShipMove, instr.NameShipMovePath} {
+		if _, ok := b.curInstructions[instr.GenInstrUid(name, ship.Uid)]; ok {
+			moving = true
+		}
+	}
+	if last, ok := h.moveTargets[ship.Uid]; ok && moving && last.Distance(pos) < moveRetargetDistance {
+		return
+	}
+	h.moveTargets[ship.Uid] = pos
+
+	var moveInstr instr.Instruction
+	if ship.CanOnLand() {
+		moveInstr = instr.NewShipMove(ship.Uid, pos)
+	} else {
+		moveInstr = instr.NewShipMovePath(ship.Uid, ship.CurPos, pos, ship.CurSpeed)
+	}
+	b.instructions[moveInstr.Uid()] = moveInstr
+}
+
+// seaPosNear 将位置限制在地图内，并在陆地上时就近寻找海面（找不到时保持原位置，由寻路处理）
+func (h *ComputerDecisionHandler) seaPosNear(b *battlefield, ship *objUnit.BattleShip, pos objPos.MapPos) objPos.MapPos {
+	mapCfg := b.misState.Core.MissionMD.MapCfg
+	pos.EnsureBorder(float64(mapCfg.Width-2), float64(mapCfg.Height-2))
+	if ship.CanOnLand() || !mapCfg.Map.IsLand(pos.MX, pos.MY) {
+		return pos
+	}
+	for r := 1; r <= 4; r++ {
+		for dx := -r; dx <= r; dx++ {
+			for dy := -r; dy <= r; dy++ {
+				x, y := pos.MX+dx, pos.MY+dy
+				if x > 0 && y > 0 && x < mapCfg.Width-1 && y < mapCfg.Height-1 && mapCfg.Map.IsSea(x, y) {
+					return objPos.New(x, y)
+				}
+			}
+		}
+	}
+	return pos
+}
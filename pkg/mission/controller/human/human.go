@@ -1,13 +1,16 @@
 package human
 
 import (
-	"math/rand"
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/samber/lo"
 
-	"github.com/narasux/jutland/pkg/common/constants"
+	"github.com/narasux/jutland/pkg/config"
 	"github.com/narasux/jutland/pkg/mission/action"
 	"github.com/narasux/jutland/pkg/mission/controller"
 	"github.com/narasux/jutland/pkg/mission/faction"
@@ -17,12 +20,13 @@ import (
 	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 	"github.com/narasux/jutland/pkg/mission/state"
 	textureImg "github.com/narasux/jutland/pkg/resources/images/texture"
-	"github.com/narasux/jutland/pkg/utils/geometry"
 )
 
 // HumanInputHandler 人类输入处理器
 type HumanInputHandler struct {
 	player faction.Player
+	// 输入专用随机数（如多舰落点抖动），不消耗任务随机数源
+	rng *rand.Rand
 }
 
 // NewHandler ...
@@ -43,8 +47,17 @@ func (h *HumanInputHandler) Handle(
 		return instructions
 	}
 
+	if h.rng == nil {
+		h.rng = state.NewPlayerRand(misState.Core.Seed, h.player)
+	}
 	instructions = lo.Assign(instructions, h.handleShipMove(misState))
+	instructions = lo.Assign(instructions, h.handleFormation(misState))
+	instructions = lo.Assign(instructions, h.handleDuty(misState))
 	instructions = lo.Assign(instructions, h.handleWeapon(misState))
+	instructions = lo.Assign(instructions, h.handleEngagement(misState))
+	instructions = lo.Assign(instructions, h.handleEngineOrder(misState))
+	instructions = lo.Assign(instructions, h.handleDamageControl(misState))
+	instructions = lo.Assign(instructions, h.handleAirOps(misState))
 
 	return instructions
 }
@@ -66,72 +79,99 @@ func (h *HumanInputHandler) handleShipMove(misState *state.MissionState) map[str
 	var lockOnEnemy *objUnit.BattleShip
 	if selectedShipCount != 0 {
 		pos := action.DetectCursorPosOnMap(misState)
-		for _, ship := range misState.Arena.Ships {
-			if ship.BelongPlayer == misState.Player.CurPlayer {
-				continue
-			}
-			if geometry.IsPointInRotatedRectangle(
-				pos.RX, pos.RY,
-				ship.CurPos.RX, ship.CurPos.RY,
-				ship.Length/constants.MapBlockSize,
-				ship.Width/constants.MapBlockSize,
-				ship.CurRotation,
-			) {
-				lockOnEnemy = ship
-
-				// 默认为锁定标志
-				markID, markImg := objMark.IDLockOn, textureImg.LockOnTarget
-				// 如果选中战舰中某艘已经设置该战舰为攻击目标，则应显示攻击标志而非锁定标志
-				for _, shipUid := range misState.Interaction.SelectedShips {
-					if s, ok := misState.Arena.Ships[shipUid]; ok {
-						if s.AttackTarget == lockOnEnemy.Uid {
-							markID, markImg = objMark.IDAttack, textureImg.AttackTarget
-							break
-						}
+		// 不能锁定己方 / 友军战舰，以及战争迷雾中的敌舰（中立船只可以被手动锁定）
+		lockOnEnemy = misState.ShipAtPos(*pos, func(ship *objUnit.BattleShip) bool {
+			return !misState.IsAlly(misState.Player.CurPlayer, ship.BelongPlayer) &&
+				misState.CanSee(misState.Player.CurPlayer, ship)
+		})
+		if lockOnEnemy != nil {
+			// 默认为锁定标志
+			markID, markImg := objMark.IDLockOn, textureImg.LockOnTarget
+			// 如果选中战舰中某艘已经设置该战舰为攻击目标，则应显示攻击标志而非锁定标志
+			for _, shipUid := range misState.Interaction.SelectedShips {
+				if s, ok := misState.Arena.Ships[shipUid]; ok {
+					if s.AttackTarget == lockOnEnemy.Uid {
+						markID, markImg = objMark.IDAttack, textureImg.AttackTarget
+						break
 					}
 				}
-				mark := objMark.NewImg(markID, *pos, markImg, 2)
-				misState.UI.GameMarks[mark.ID] = mark
-				break
 			}
+			mark := objMark.NewImg(markID, *pos, markImg, 2)
+			misState.UI.GameMarks[mark.ID] = mark
+		}
+	}
+
+	// 编队跟随舰由编队控制移动：向导舰同时被选中时只移动向导舰，否则跟随舰单独行动时脱离编队
+	followsSelectedGuide := func(ship *objUnit.BattleShip) bool {
+		return ship.InFormation() && !ship.IsFormationGuide() &&
+			slices.Contains(misState.Interaction.SelectedShips, ship.Formation.GuideUid)
+	}
+	leaveFormation := func(ship *objUnit.BattleShip) {
+		if ship.InFormation() && !ship.IsFormationGuide() {
+			leaveInstr := instr.NewShipLeaveFormation(ship.Uid)
+			instructions[leaveInstr.Uid()] = leaveInstr
 		}
 	}
 
-	// 按下鼠标右键，如果有选中战舰，则移动选中战舰到指定位置
+	// 按下鼠标右键，如果有选中战舰，则移动选中战舰到指定位置（按住攻击移动键（默认 A）为攻击移动，途中迎击遭遇的敌舰）
+	// 按住 Shift 时不打断当前命令，而是追加为排队命令（再按住 Alt 为巡逻航点）
 	if pos := action.DetectMouseButtonClickOnMap(
 		misState, ebiten.MouseButtonRight,
 	); pos != nil && selectedShipCount != 0 {
+		queued := ebiten.IsKeyPressed(ebiten.KeyShift)
+		attackMove := lockOnEnemy == nil && action.IsActionPressed(config.KeyActionAttackMove)
 		for _, shipUid := range misState.Interaction.SelectedShips {
-			// 如果是多艘战舰，则需要区分下终点位置，不要聚在一起挨揍
+			ship, ok := misState.Arena.Ships[shipUid]
+			if !ok {
+				continue
+			}
+			if followsSelectedGuide(ship) {
+				// 跟随舰与向导舰攻击同一目标
+				if lockOnEnemy != nil && !queued {
+					attackInstr := instr.NewShipAttack(ship.Uid, lockOnEnemy.Uid)
+					instructions[attackInstr.Uid()] = attackInstr
+				}
+				continue
+			}
+			leaveFormation(ship)
+			// 如果是多艘战舰，则需要区分下终点位置，不要聚在一起挨揍（编队向导舰直接前往目标位置）
 			targetPos := pos.Copy()
-			if selectedShipCount > 1 {
-				targetPos.AddRx(float64(rand.Intn(5) - 2))
-				targetPos.AddRy(float64(rand.Intn(5) - 2))
-				// 多舰编队去同一个点时，用小幅抖动分散落点，防止战舰堆积。
-				// 抖动后需要确保目标仍在海面上，否则路径计算会因终点在
-				// 陆地或海岸地图格上而失败，导致该艘战舰原地不动。
-				if misState.Core.MissionMD.MapCfg.Map.IsLand(targetPos.MX, targetPos.MY) {
-					targetPos = pos.Copy()
+			if !ship.IsFormationGuide() {
+				targetPos = h.spreadTargetPos(misState, *pos, selectedShipCount)
+			}
+			if queued {
+				orderInstr := instr.NewShipEnqueueOrder(ship.Uid, objUnit.OrderTypeMove, targetPos, "")
+				if lockOnEnemy != nil {
+					orderInstr = instr.NewShipEnqueueOrder(ship.Uid, objUnit.OrderTypeAttack, lockOnEnemy.CurPos, lockOnEnemy.Uid)
+				} else if ebiten.IsKeyPressed(ebiten.KeyAlt) {
+					orderInstr = instr.NewShipEnqueueOrder(ship.Uid, objUnit.OrderTypePatrol, targetPos, "")
+				} else if attackMove {
+					orderInstr = instr.NewShipEnqueueOrder(ship.Uid, objUnit.OrderTypeAttackMove, targetPos, "")
 				}
+				instructions[orderInstr.Uid()] = orderInstr
+				continue
 			}
-			// 通过 ShipMovePath 指令实现移动行为
-			ship, ok := misState.Arena.Ships[shipUid]
-			if !ok {
+			// 攻击移动取代所有排队命令 & 值守任务，同时立即前往目标位置（由排队命令推进迎击）
+			if attackMove {
+				attackMoveInstr := instr.NewShipAttackMove(ship.Uid, targetPos)
+				instructions[attackMoveInstr.Uid()] = attackMoveInstr
+				moveInstr := newShipMoveInstr(ship, targetPos)
+				instructions[moveInstr.Uid()] = moveInstr
 				continue
 			}
+			// 直接下达的命令替换所有排队命令 & 值守任务
+			if len(ship.Orders) != 0 || ship.HasDuty() {
+				clearInstr := instr.NewShipClearOrders(ship.Uid)
+				instructions[clearInstr.Uid()] = clearInstr
+			}
 			// 右键点击前往并攻击指定目标
 			if lockOnEnemy != nil {
-				ship.Attack(lockOnEnemy.Uid)
+				attackInstr := instr.NewShipAttack(ship.Uid, lockOnEnemy.Uid)
+				instructions[attackInstr.Uid()] = attackInstr
 			}
-			// 航母攻击的话，不要移动过去突脸
-			// FIXME 可以有其他的逻辑，比如战列舰就不该直接突脸
-			if lockOnEnemy == nil || ship.Type != objUnit.ShipTypeAircraftCarrier {
-				var moveInstr instr.Instruction
-				if ship.CanOnLand() {
-					moveInstr = instr.NewShipMove(ship.Uid, targetPos)
-				} else {
-					moveInstr = instr.NewShipMovePath(ship.Uid, ship.CurPos, targetPos, ship.CurSpeed)
-				}
+			// 航母攻击的话，不要移动过去突脸；保持距离的战舰由任务管理器驶向保持距离处
+			if lockOnEnemy == nil || (ship.Type != objUnit.ShipTypeAircraftCarrier && ship.StandOffRate <= 0) {
+				moveInstr := newShipMoveInstr(ship, targetPos)
 				instructions[moveInstr.Uid()] = moveInstr
 			}
 		}
@@ -144,108 +184,242 @@ func (h *HumanInputHandler) handleShipMove(misState *state.MissionState) map[str
 		misState.UI.GameMarks[markID] = mark
 	}
 
+	// 按下撤销键（默认 Backspace）撤销选中战舰的最后一条排队命令，按住 Shift 时清空排队命令 & 值守任务
+	if action.IsActionJustPressed(config.KeyActionCancelOrder) {
+		for _, shipUid := range misState.Interaction.SelectedShips {
+			ship, ok := misState.Arena.Ships[shipUid]
+			if !ok || (len(ship.Orders) == 0 && !ship.HasDuty()) {
+				continue
+			}
+			var orderInstr instr.Instruction = instr.NewShipCancelOrder(ship.Uid)
+			if ebiten.IsKeyPressed(ebiten.KeyShift) {
+				orderInstr = instr.NewShipClearOrders(ship.Uid)
+			}
+			instructions[orderInstr.Uid()] = orderInstr
+		}
+	}
+
 	// 通过 ShipMove 指令实现移动
-	handleMove := func(shipUid string, curPos objPos.MapPos, dx, dy int) {
+	handleMove := func(ship *objUnit.BattleShip, dx, dy int) {
 		moveInstr := instr.NewShipMove(
-			shipUid,
+			ship.Uid,
 			objPos.NewR(
-				curPos.RX+float64(dx),
-				curPos.RY+float64(dy),
+				ship.CurPos.RX+float64(dx),
+				ship.CurPos.RY+float64(dy),
 			),
 		)
 		instructions[moveInstr.Uid()] = moveInstr
 	}
 
-	// 随机散开，用于战舰重叠的情况（按下 X 键）
-	if inpututil.IsKeyJustPressed(ebiten.KeyX) {
+	// 随机散开，用于战舰重叠的情况（按下散开键，默认 X），散开的战舰脱离编队
+	if action.IsActionJustPressed(config.KeyActionScatter) {
 		for _, shipUid := range misState.Interaction.SelectedShips {
+			ship, ok := misState.Arena.Ships[shipUid]
 			// 如果战舰不是静止状态，则散开指令无效
-			if misState.Arena.Ships[shipUid].CurSpeed != 0 {
+			if !ok || ship.CurSpeed != 0 {
 				continue
 			}
+			if ship.InFormation() {
+				leaveInstr := instr.NewShipLeaveFormation(ship.Uid)
+				instructions[leaveInstr.Uid()] = leaveInstr
+			}
 			// 随机散开 [-3, 3] 的范围
-			dx, dy := rand.Intn(7)-3, rand.Intn(7)-3
+			dx, dy := h.rng.IntN(7)-3, h.rng.IntN(7)-3
 			// 通过 ShipMove 指令实现散开行为
-			handleMove(shipUid, misState.Arena.Ships[shipUid].CurPos, dx, dy)
+			handleMove(ship, dx, dy)
 		}
 	}
 
-	// 方向键，让选中的战舰往对应方向移动一个单位
+	// 方向键（默认上下左右），让选中的战舰往对应方向移动一个单位
 	dx, dy := 0, 0
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+	if action.IsActionJustPressed(config.KeyActionMoveUp) {
 		dx, dy = 0, -1
-	} else if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+	} else if action.IsActionJustPressed(config.KeyActionMoveDown) {
 		dx, dy = 0, 1
-	} else if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+	} else if action.IsActionJustPressed(config.KeyActionMoveLeft) {
 		dx, dy = -1, 0
-	} else if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+	} else if action.IsActionJustPressed(config.KeyActionMoveRight) {
 		dx, dy = 1, 0
 	}
 	if dx != 0 || dy != 0 {
 		for _, shipUid := range misState.Interaction.SelectedShips {
-			if ship, ok := misState.Arena.Ships[shipUid]; ok {
-				handleMove(shipUid, ship.CurPos, dx, dy)
+			if ship, ok := misState.Arena.Ships[shipUid]; ok && !followsSelectedGuide(ship) {
+				leaveFormation(ship)
+				handleMove(ship, dx, dy)
 			}
 		}
 	}
 	return instructions
 }
 
+// handleFormation 按下编队键（默认 F），选中的战舰按 单纵阵 -> 单横阵 -> 楔形阵 -> 环形警戒阵 -> 解散 的顺序切换编队
+// 吨位最大的战舰作为向导舰负责寻路，其余战舰按吨位依次占据编队位置，编队按最慢的成员航行
+func (h *HumanInputHandler) handleFormation(misState *state.MissionState) map[string]instr.Instruction {
+	instructions := map[string]instr.Instruction{}
+	if !action.IsActionJustPressed(config.KeyActionFormation) {
+		return instructions
+	}
+
+	ships := []*objUnit.BattleShip{}
+	for _, shipUid := range misState.Interaction.SelectedShips {
+		if ship, ok := misState.Arena.Ships[shipUid]; ok {
+			ships = append(ships, ship)
+		}
+	}
+	if len(ships) < 2 {
+		return instructions
+	}
+	slices.SortFunc(ships, func(a, b *objUnit.BattleShip) int {
+		if a.Tonnage != b.Tonnage {
+			return cmp.Compare(b.Tonnage, a.Tonnage)
+		}
+		return strings.Compare(a.Uid, b.Uid)
+	})
+	guide := ships[0]
+
+	// 选中的战舰已经是同一个编队时切换到下一个阵型，最后一个阵型之后解散编队
+	formationType := objUnit.FormationLineAhead
+	if guide.IsFormationGuide() && lo.EveryBy(ships, func(s *objUnit.BattleShip) bool {
+		return s.Formation.GuideUid == guide.Uid && s.Formation.Type == guide.Formation.Type
+	}) {
+		idx := slices.Index(objUnit.Formations, guide.Formation.Type)
+		formationType = objUnit.FormationNone
+		if idx+1 < len(objUnit.Formations) {
+			formationType = objUnit.Formations[idx+1]
+		}
+	}
+	if formationType == objUnit.FormationNone {
+		for _, ship := range ships {
+			leaveInstr := instr.NewShipLeaveFormation(ship.Uid)
+			instructions[leaveInstr.Uid()] = leaveInstr
+		}
+		return instructions
+	}
+
+	speedLimit := lo.MinBy(ships, func(a, b *objUnit.BattleShip) bool { return a.MaxSpeed < b.MaxSpeed }).MaxSpeed
+	guideInstr := instr.NewShipFormation(
+		guide.Uid, objUnit.ShipFormation{Type: formationType, GuideUid: guide.Uid}, speedLimit,
+	)
+	instructions[guideInstr.Uid()] = guideInstr
+	slots := objUnit.FormationSlots(formationType, len(ships)-1, objUnit.FormationSpacing(ships))
+	for idx, ship := range ships[1:] {
+		followInstr := instr.NewShipFormation(
+			ship.Uid, objUnit.ShipFormation{Type: formationType, GuideUid: guide.Uid, Slot: slots[idx]}, 0,
+		)
+		instructions[followInstr.Uid()] = followInstr
+	}
+	return instructions
+}
+
+// 警戒区域半径（地图格）
+const guardAreaRadius = 10
+
+// handleDuty 按下巡逻键（默认 P），选中的战舰在当前位置与鼠标位置之间往返巡逻（编队向导舰巡逻时跟随舰保持编队）
+// 按下警戒键（默认 G），鼠标在己方 / 友军战舰上时为其护航，否则警戒鼠标所在区域，敌舰离开后返回警戒点
+func (h *HumanInputHandler) handleDuty(misState *state.MissionState) map[string]instr.Instruction {
+	instructions := map[string]instr.Instruction{}
+	patrol, guard := action.IsActionJustPressed(config.KeyActionPatrol), action.IsActionJustPressed(config.KeyActionGuard)
+	selectedShipCount := len(misState.Interaction.SelectedShips)
+	if (!patrol && !guard) || selectedShipCount == 0 || misState.UI.SidebarConsumesCursor {
+		return instructions
+	}
+	pos := *action.DetectCursorPosOnMap(misState)
+
+	var escortTarget *objUnit.BattleShip
+	if guard {
+		escortTarget = misState.ShipAtPos(pos, func(ship *objUnit.BattleShip) bool {
+			return misState.IsAlly(misState.Player.CurPlayer, ship.BelongPlayer)
+		})
+	}
+	for _, shipUid := range misState.Interaction.SelectedShips {
+		ship, ok := misState.Arena.Ships[shipUid]
+		if !ok {
+			continue
+		}
+		var dutyInstr instr.Instruction
+		switch {
+		case patrol:
+			if ship.InFormation() && !ship.IsFormationGuide() &&
+				slices.Contains(misState.Interaction.SelectedShips, ship.Formation.GuideUid) {
+				continue
+			}
+			targetPos := pos
+			if !ship.IsFormationGuide() {
+				targetPos = h.spreadTargetPos(misState, pos, selectedShipCount)
+			}
+			dutyInstr = instr.NewShipPatrol(ship.Uid, []objPos.MapPos{ship.CurPos.Copy(), targetPos})
+		case escortTarget != nil:
+			if escortTarget.Uid == ship.Uid {
+				continue
+			}
+			dutyInstr = instr.NewShipEscort(ship.Uid, escortTarget.Uid)
+		default:
+			dutyInstr = instr.NewShipGuard(ship.Uid, pos, guardAreaRadius)
+		}
+		instructions[dutyInstr.Uid()] = dutyInstr
+	}
+	mark := objMark.NewImg(objMark.IDTarget, pos, textureImg.TargetPos, 20)
+	misState.UI.GameMarks[mark.ID] = mark
+	return instructions
+}
+
+// newShipMoveInstr 通过 ShipMovePath 指令实现移动行为（可以上岸的单位直线移动）
+func newShipMoveInstr(ship *objUnit.BattleShip, targetPos objPos.MapPos) instr.Instruction {
+	if ship.CanOnLand() {
+		return instr.NewShipMove(ship.Uid, targetPos)
+	}
+	return instr.NewShipMovePath(ship.Uid, ship.CurPos, targetPos, ship.CurSpeed)
+}
+
+// spreadTargetPos 多舰编队去同一个点时，用小幅抖动分散落点，防止战舰堆积
+func (h *HumanInputHandler) spreadTargetPos(misState *state.MissionState, pos objPos.MapPos, shipCount int) objPos.MapPos {
+	targetPos := pos.Copy()
+	if shipCount <= 1 {
+		return targetPos
+	}
+	targetPos.AddRx(float64(h.rng.IntN(5) - 2))
+	targetPos.AddRy(float64(h.rng.IntN(5) - 2))
+	// 抖动后需要确保目标仍在海面上，否则路径计算会因终点在
+	// 陆地或海岸地图格上而失败，导致该艘战舰原地不动。
+	if misState.Core.MissionMD.MapCfg.Map.IsLand(targetPos.MX, targetPos.MY) {
+		return pos.Copy()
+	}
+	return targetPos
+}
+
 func (h *HumanInputHandler) handleWeapon(misState *state.MissionState) map[string]instr.Instruction {
 	instructions := map[string]instr.Instruction{}
 
-	type operation struct {
-		key        ebiten.Key
+	ops := []struct {
+		action     config.KeyAction
 		weaponType objUnit.WeaponType
-		isDisabled func(s *objUnit.BattleShip) bool
+	}{
+		{config.KeyActionToggleAllWeapons, objUnit.WeaponTypeAll},
+		{config.KeyActionToggleMainGun, objUnit.WeaponTypeMainGun},
+		{config.KeyActionToggleSecondaryGun, objUnit.WeaponTypeSecondaryGun},
+		{config.KeyActionToggleAntiAircraftGun, objUnit.WeaponTypeAntiAircraftGun},
+		{config.KeyActionToggleTorpedo, objUnit.WeaponTypeTorpedo},
+		{config.KeyActionToggleRocket, objUnit.WeaponTypeRocket},
 	}
 
-	// 按下 q 键，如果任意选中战舰任意武器被禁用，则启用所有，否则禁用所有
+	// 按下 q 键（默认，可重新绑定，下同），如果任意选中战舰任意武器被禁用，则启用所有，否则禁用所有
 	// 按下 w 键，如果任意选中战舰任意主炮被禁用，则启用所有，否则禁用所有
 	// 按下 e 键，如果任意选中战舰任意副炮被禁用，则启用所有，否则禁用所有
 	// 按下 r 键，如果任意选中战舰任意防空炮被禁用，则启用所有，否则禁用所有
 	// 按下 t 键，如果任意选中战舰任意鱼雷被禁用，则启用所有，否则禁用所有
-	ops := []operation{
-		{
-			ebiten.KeyQ,
-			objUnit.WeaponTypeAll,
-			func(s *objUnit.BattleShip) bool {
-				return s.Weapon.MainGunDisabled ||
-					s.Weapon.SecondaryGunDisabled ||
-					s.Weapon.AntiAircraftGunDisabled ||
-					s.Weapon.TorpedoDisabled ||
-					s.Weapon.RocketDisabled
-			},
-		},
-		{
-			ebiten.KeyW,
-			objUnit.WeaponTypeMainGun,
-			func(s *objUnit.BattleShip) bool { return s.Weapon.MainGunDisabled },
-		},
-		{
-			ebiten.KeyE,
-			objUnit.WeaponTypeSecondaryGun,
-			func(s *objUnit.BattleShip) bool { return s.Weapon.SecondaryGunDisabled },
-		},
-		{
-			ebiten.KeyR,
-			objUnit.WeaponTypeAntiAircraftGun,
-			func(s *objUnit.BattleShip) bool { return s.Weapon.AntiAircraftGunDisabled },
-		},
-		{
-			ebiten.KeyT,
-			objUnit.WeaponTypeTorpedo,
-			func(s *objUnit.BattleShip) bool { return s.Weapon.TorpedoDisabled },
-		},
-	}
-
-	if len(misState.Arena.Ships) > 0 {
+	// 按下 y 键，如果任意选中战舰任意火箭炮被禁用，则启用所有，否则禁用所有
+	// 注：战术暂停时按尚未生效的设置判断，以便连续切换
+	// 按住 Shift 时为切换目标策略（见 handleEngagement）
+	if len(misState.Arena.Ships) > 0 && !ebiten.IsKeyPressed(ebiten.KeyShift) {
 		for _, op := range ops {
-			if inpututil.IsKeyJustPressed(op.key) {
+			if action.IsActionJustPressed(op.action) {
 				anyDisabled := false
 				for _, shipUid := range misState.Interaction.SelectedShips {
-					ship := misState.Arena.Ships[shipUid]
-					if op.isDisabled(ship) {
+					ship, ok := misState.Arena.Ships[shipUid]
+					if !ok {
+						continue
+					}
+					if settings := misState.ShipSettings(ship); settings.WeaponDisabled(op.weaponType) {
 						anyDisabled = true
 						break
 					}
@@ -265,3 +439,182 @@ func (h *HumanInputHandler) handleWeapon(misState *state.MissionState) map[strin
 	}
 	return instructions
 }
+
+// handleEngagement 按下 H 键（默认，可重新绑定，下同），切换选中战舰的交战规则（自由开火 -> 还击 -> 停火）
+// 按下 K 键，切换选中战舰与攻击目标保持的距离（逼近 -> 射程的 50% / 70% / 85%）
+// 按住 Shift 再按 W / E / R / T / Y 键，切换选中战舰主炮 / 副炮 / 防空炮 / 鱼雷 / 火箭炮的目标策略，Shift + Q 全部恢复默认
+// 注：以第一艘选中战舰的当前设置（战术暂停时包含尚未生效的设置）为准，所有选中战舰切换为同一设置
+func (h *HumanInputHandler) handleEngagement(misState *state.MissionState) map[string]instr.Instruction {
+	instructions := map[string]instr.Instruction{}
+	ships := lo.FilterMap(misState.Interaction.SelectedShips, func(uid string, _ int) (*objUnit.BattleShip, bool) {
+		ship, ok := misState.Arena.Ships[uid]
+		return ship, ok
+	})
+	if len(ships) == 0 {
+		return instructions
+	}
+	settings := misState.ShipSettings(ships[0])
+
+	if action.IsActionJustPressed(config.KeyActionFireStance) {
+		stance := settings.FireStance.Next()
+		for _, ship := range ships {
+			stanceInstr := instr.NewShipFireStance(ship.Uid, stance)
+			instructions[stanceInstr.Uid()] = stanceInstr
+		}
+	}
+
+	if action.IsActionJustPressed(config.KeyActionStandOff) {
+		rate := objUnit.NextStandOffRate(settings.StandOffRate)
+		for _, ship := range ships {
+			standOffInstr := instr.NewShipStandOff(ship.Uid, rate)
+			instructions[standOffInstr.Uid()] = standOffInstr
+		}
+	}
+
+	if !ebiten.IsKeyPressed(ebiten.KeyShift) {
+		return instructions
+	}
+	if action.IsActionJustPressed(config.KeyActionToggleAllWeapons) {
+		for _, ship := range ships {
+			policyInstr := instr.NewShipTargetPolicy(ship.Uid, objUnit.WeaponTypeAll, objUnit.TargetPolicyDefault)
+			instructions[policyInstr.Uid()] = policyInstr
+		}
+		return instructions
+	}
+	ops := []struct {
+		action     config.KeyAction
+		weaponType objUnit.WeaponType
+	}{
+		{config.KeyActionToggleMainGun, objUnit.WeaponTypeMainGun},
+		{config.KeyActionToggleSecondaryGun, objUnit.WeaponTypeSecondaryGun},
+		{config.KeyActionToggleAntiAircraftGun, objUnit.WeaponTypeAntiAircraftGun},
+		{config.KeyActionToggleTorpedo, objUnit.WeaponTypeTorpedo},
+		{config.KeyActionToggleRocket, objUnit.WeaponTypeRocket},
+	}
+	for _, op := range ops {
+		if !action.IsActionJustPressed(op.action) {
+			continue
+		}
+		policy := settings.TargetPolicyOf(op.weaponType).Next()
+		for _, ship := range ships {
+			policyInstr := instr.NewShipTargetPolicy(ship.Uid, op.weaponType, policy)
+			instructions[policyInstr.Uid()] = policyInstr
+		}
+		// 同一帧只处理一种武器（指令 Uid 按战舰区分）
+		break
+	}
+	return instructions
+}
+
+// handleEngineOrder 按下 ] 键（默认，可重新绑定），选中战舰的车钟加一档，按下 [ 键减一档（后退 -> 停车 -> 1/3 -> 2/3 -> 全速 -> 最大航速）
+// 注：以第一艘选中战舰的当前档位（战术暂停时包含尚未生效的档位）为准，所有选中战舰切换为同一档位
+func (h *HumanInputHandler) handleEngineOrder(misState *state.MissionState) map[string]instr.Instruction {
+	instructions := map[string]instr.Instruction{}
+	faster, slower := action.IsActionJustPressed(config.KeyActionEngineFaster), action.IsActionJustPressed(config.KeyActionEngineSlower)
+	if faster == slower {
+		return instructions
+	}
+	ships := lo.FilterMap(misState.Interaction.SelectedShips, func(uid string, _ int) (*objUnit.BattleShip, bool) {
+		ship, ok := misState.Arena.Ships[uid]
+		return ship, ok
+	})
+	if len(ships) == 0 {
+		return instructions
+	}
+
+	current := misState.ShipSettings(ships[0]).EngineOrder
+	order := current.Slower()
+	if faster {
+		order = current.Faster()
+	}
+	for _, ship := range ships {
+		engineInstr := instr.NewShipEngineOrder(ship.Uid, order)
+		instructions[engineInstr.Uid()] = engineInstr
+	}
+	return instructions
+}
+
+// handleDamageControl 按下 D 键（默认，可重新绑定），选中的战舰中存在火灾 / 进水且损管冷却完毕的执行损管
+func (h *HumanInputHandler) handleDamageControl(misState *state.MissionState) map[string]instr.Instruction {
+	instructions := map[string]instr.Instruction{}
+	if !action.IsActionJustPressed(config.KeyActionDamageControl) {
+		return instructions
+	}
+	for _, shipUid := range misState.Interaction.SelectedShips {
+		if ship, ok := misState.Arena.Ships[shipUid]; ok && ship.CanDamageControl() {
+			controlInstr := instr.NewShipDamageControl(ship.Uid)
+			instructions[controlInstr.Uid()] = controlInstr
+		}
+	}
+	return instructions
+}
+
+// handleAirOps 航母舰载机指令（按键为默认，可重新绑定）：鼠标指向敌舰按 V 键，以对舰分组发起空袭（对同一目标再按切换下一个分组）；
+// 按 C 键在鼠标位置（指向友舰时为该友舰）上空执行战斗空中巡逻；按 Z 键切换留在甲板上 / 自动出击，Shift + Z 召回全部战机
+func (h *HumanInputHandler) handleAirOps(misState *state.MissionState) map[string]instr.Instruction {
+	instructions := map[string]instr.Instruction{}
+	strike, patrol, hold := action.IsActionJustPressed(config.KeyActionAirStrike),
+		action.IsActionJustPressed(config.KeyActionAirPatrol), action.IsActionJustPressed(config.KeyActionHoldAircraft)
+	if (!strike && !patrol && !hold) || misState.UI.SidebarConsumesCursor {
+		return instructions
+	}
+	carriers := []*objUnit.BattleShip{}
+	for _, shipUid := range misState.Interaction.SelectedShips {
+		if ship, ok := misState.Arena.Ships[shipUid]; ok && ship.Aircraft.HasPlane {
+			carriers = append(carriers, ship)
+		}
+	}
+	if len(carriers) == 0 {
+		return instructions
+	}
+	pos := *action.DetectCursorPosOnMap(misState)
+
+	switch {
+	case strike:
+		target := misState.ShipAtPos(pos, func(ship *objUnit.BattleShip) bool {
+			return !misState.IsAlly(misState.Player.CurPlayer, ship.BelongPlayer) &&
+				misState.CanSee(misState.Player.CurPlayer, ship)
+		})
+		if target == nil {
+			return instructions
+		}
+		for _, ship := range carriers {
+			group := ship.Aircraft.NextStrikeGroup(target.Uid)
+			if group == "" {
+				continue
+			}
+			strikeInstr := instr.NewShipLaunchStrike(ship.Uid, group, target.Uid)
+			instructions[strikeInstr.Uid()] = strikeInstr
+		}
+		mark := objMark.NewImg(objMark.IDAttack, target.CurPos, textureImg.AttackTarget, 20)
+		misState.UI.GameMarks[mark.ID] = mark
+	case patrol:
+		guardUid := ""
+		if guard := misState.ShipAtPos(pos, func(ship *objUnit.BattleShip) bool {
+			return misState.IsAlly(misState.Player.CurPlayer, ship.BelongPlayer)
+		}); guard != nil {
+			guardUid, pos = guard.Uid, guard.CurPos
+		}
+		for _, ship := range carriers {
+			patrolInstr := instr.NewShipAirPatrol(ship.Uid, pos, guardUid)
+			instructions[patrolInstr.Uid()] = patrolInstr
+		}
+		mark := objMark.NewImg(objMark.IDTarget, pos, textureImg.TargetPos, 20)
+		misState.UI.GameMarks[mark.ID] = mark
+	case ebiten.IsKeyPressed(ebiten.KeyShift):
+		for _, ship := range carriers {
+			recallInstr := instr.NewShipRecallAircraft(ship.Uid)
+			instructions[recallInstr.Uid()] = recallInstr
+		}
+	default:
+		// 全部留在甲板上（战术暂停时包含尚未生效的设置）时恢复自动出击，否则全部留在甲板上
+		holdAll := !lo.EveryBy(carriers, func(ship *objUnit.BattleShip) bool {
+			return misState.ShipSettings(ship).HoldOnDeck
+		})
+		for _, ship := range carriers {
+			holdInstr := instr.NewShipHoldAircraft(ship.Uid, holdAll)
+			instructions[holdInstr.Uid()] = holdInstr
+		}
+	}
+	return instructions
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/narasux/jutland/pkg/i18n"
-	"github.com/narasux/jutland/pkg/mission/faction"
 	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 	"github.com/narasux/jutland/pkg/mission/state"
 	"github.com/narasux/jutland/pkg/resources/font"
@@ -29,7 +28,8 @@ func (d *Drawer) drawAbbreviationMap(screen *ebiten.Image, ms *state.MissionStat
 	d.drawAbbrCameraBox(screen, ms)
 	// 绘制建筑物
 	d.drawAbbrBuildings(screen, ms)
-	// 绘制敌我战舰
+	// 绘制敌我战舰（含战争迷雾中敌舰的最后已知位置）
+	d.drawAbbrLastKnownShips(screen, ms)
 	d.drawAbbrShips(screen, ms)
 	// 绘制敌我战机
 	d.drawAbbrPlanes(screen, ms)
@@ -71,14 +71,9 @@ func (d *Drawer) drawAbbrFleetOverview(screen *ebiten.Image, ms *state.MissionSt
 	abbrMapWidth, abbrMapHeight := d.abbrMap.Bounds().Dx(), d.abbrMap.Bounds().Dy()
 	windowWidth := float64(ms.View.Layout.Width-abbrMapWidth) / 2
 
-	drawShips := func(player faction.Player, xOffset, yOffset, scaleX float64) {
-		fleet := ms.Fleet(player)
-
+	drawShips := func(fleet state.Fleet, isSelf bool, xOffset, yOffset, scaleX float64) {
 		// 绘制敌我标识
-		side := i18n.Text(i18n.MsgMapEnemy)
-		if player == ms.Player.CurPlayer {
-			side = i18n.Text(i18n.MsgMapSelf)
-		}
+		side := lo.Ternary(isSelf, i18n.Text(i18n.MsgMapSelf), i18n.Text(i18n.MsgMapEnemy))
 		d.drawText(
 			screen,
 			i18n.Format(i18n.MsgMapFleetCount, map[string]any{"Side": side, "Count": fleet.Total}),
@@ -116,9 +111,9 @@ func (d *Drawer) drawAbbrFleetOverview(screen *ebiten.Image, ms *state.MissionSt
 	}
 
 	// 己方舰队概览
-	drawShips(ms.Player.CurPlayer, float64(windowWidth)/4, 80, 1)
-	// 敌方舰队概览
-	drawShips(ms.Player.CurEnemy, float64(abbrMapWidth)+float64(windowWidth)*1.6, 80, -1)
+	drawShips(ms.Fleet(ms.Player.CurPlayer), true, float64(windowWidth)/4, 80, 1)
+	// 敌方舰队概览（所有敌对势力）
+	drawShips(ms.HostileFleet(ms.Player.CurPlayer), false, float64(abbrMapWidth)+float64(windowWidth)*1.6, 80, -1)
 }
 
 // 绘制当前视野范围
@@ -144,7 +139,7 @@ func (d *Drawer) drawAbbrBuildings(screen *ebiten.Image, ms *state.MissionState)
 
 	for _, rp := range ms.Arena.ReinforcePoints {
 		img := lo.Ternary(
-			rp.BelongPlayer == ms.Player.CurPlayer,
+			ms.IsAlly(ms.Player.CurPlayer, rp.BelongPlayer),
 			textureImg.AbbrReinforcePoint,
 			textureImg.AbbrEnemyReinforcePoint,
 		)
@@ -173,7 +168,10 @@ func (d *Drawer) drawAbbrShips(screen *ebiten.Image, ms *state.MissionState) {
 	xOffset := float64(ms.View.Layout.Width-abbrMapWidth) / 2
 
 	for _, s := range ms.Arena.Ships {
-		sImg := textureImg.GetAbbrShip(s.Tonnage, s.BelongPlayer != ms.Player.CurPlayer)
+		if !ms.CanSee(ms.Player.CurPlayer, s) {
+			continue
+		}
+		sImg := textureImg.GetAbbrShip(s.Tonnage, !ms.IsAlly(ms.Player.CurPlayer, s.BelongPlayer))
 		opts := d.genDefaultDrawImageOptions()
 		ebutil.SetOptsCenterRotation(opts, sImg, s.CurRotation)
 
@@ -185,13 +183,39 @@ func (d *Drawer) drawAbbrShips(screen *ebiten.Image, ms *state.MissionState) {
 	}
 }
 
+// 绘制战争迷雾中敌舰的最后已知位置（逐渐淡出）
+func (d *Drawer) drawAbbrLastKnownShips(screen *ebiten.Image, ms *state.MissionState) {
+	if ms.Vision.Revealed {
+		return
+	}
+	abbrMapWidth, abbrMapHeight := d.abbrMap.Bounds().Dx(), d.abbrMap.Bounds().Dy()
+	xOffset := float64(ms.View.Layout.Width-abbrMapWidth) / 2
+
+	now := ms.Core.Clock.Now()
+	for _, s := range ms.Vision.LastKnown {
+		sImg := textureImg.GetAbbrShip(s.Tonnage, true)
+		opts := d.genDefaultDrawImageOptions()
+		ebutil.SetOptsCenterRotation(opts, sImg, s.Rotation)
+
+		xIndex := s.Pos.RX / float64(ms.Core.MissionMD.MapCfg.Width) * float64(abbrMapWidth)
+		yIndex := s.Pos.RY / float64(ms.Core.MissionMD.MapCfg.Height) * float64(abbrMapHeight)
+
+		opts.GeoM.Translate(xIndex+xOffset, yIndex)
+		opts.ColorScale.ScaleAlpha(float32(s.Alpha(now) * 0.5))
+		screen.DrawImage(sImg, opts)
+	}
+}
+
 // 绘制敌我战机
 func (d *Drawer) drawAbbrPlanes(screen *ebiten.Image, ms *state.MissionState) {
 	abbrMapWidth, abbrMapHeight := d.abbrMap.Bounds().Dx(), d.abbrMap.Bounds().Dy()
 	xOffset := float64(ms.View.Layout.Width-abbrMapWidth) / 2
 
 	for _, p := range ms.Arena.Planes {
-		pImg := textureImg.GetAbbrPlane(p.BelongPlayer != ms.Player.CurPlayer)
+		if !ms.CanSee(ms.Player.CurPlayer, p) {
+			continue
+		}
+		pImg := textureImg.GetAbbrPlane(!ms.IsAlly(ms.Player.CurPlayer, p.BelongPlayer))
 		opts := d.genDefaultDrawImageOptions()
 		ebutil.SetOptsCenterRotation(opts, pImg, p.CurRotation)
 
@@ -3,7 +3,6 @@ package drawer
 import (
 	"fmt"
 	"image/color"
-	"math"
 	"strconv"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -11,6 +10,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/samber/lo"
 
+	"github.com/narasux/jutland/pkg/config"
 	"github.com/narasux/jutland/pkg/i18n"
 	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
 	objRef "github.com/narasux/jutland/pkg/mission/object/reference"
@@ -67,7 +67,7 @@ func (d *Drawer) drawBuildingsInCamera(screen *ebiten.Image, ms *state.MissionSt
 			continue
 		}
 		img := lo.Ternary(
-			rp.BelongPlayer == ms.Player.CurPlayer,
+			ms.IsAlly(ms.Player.CurPlayer, rp.BelongPlayer),
 			buildingImg.ReinforcePoint,
 			buildingImg.EnemyReinforcePoint,
 		)
@@ -119,7 +119,7 @@ func (d *Drawer) drawBuildingInterface(screen *ebiten.Image, ms *state.MissionSt
 	d.drawReinforcePanel(screen, ui.Console, reinforcePanelFill, false)
 	d.drawAbbrMapInRPInterface(screen, ms, ui)
 	tooltip := d.drawSelectedProvidedShips(screen, ms, ui)
-	d.drawSummonOperationTips(screen, ui, ms.Player.CurFunds)
+	d.drawSummonOperationTips(screen, ui, ms.CurFunds())
 	d.drawReinforceTooltip(screen, tooltip)
 }
 
@@ -609,10 +609,18 @@ func (d *Drawer) drawSummonOperationTips(screen *ebiten.Image, ui reinforceUILay
 	)
 
 	tips := []string{
-		i18n.Text(i18n.MsgReinforceTipPoint),
-		i18n.Text(i18n.MsgReinforceTipShip),
-		i18n.Text(i18n.MsgReinforceTipSummon),
-		i18n.Text(i18n.MsgReinforceTipCancel),
+		i18n.Format(i18n.MsgReinforceTipPoint, map[string]any{"Keys": keyBindingPair(
+			config.KeyActionReinforceNextPoint, config.KeyActionReinforcePrevPoint,
+		)}),
+		i18n.Format(i18n.MsgReinforceTipShip, map[string]any{"Keys": keyBindingPair(
+			config.KeyActionReinforcePrevShip, config.KeyActionReinforceNextShip,
+		)}),
+		i18n.Format(i18n.MsgReinforceTipSummon, map[string]any{
+			"Key": config.KeyBinding(config.KeyActionReinforceConfirm),
+		}),
+		i18n.Format(i18n.MsgReinforceTipCancel, map[string]any{
+			"Key": config.KeyBinding(config.KeyActionReinforceCancel),
+		}),
 		i18n.Text(i18n.MsgReinforceTipRally),
 	}
 	tipX := card.X + 20
@@ -837,39 +845,14 @@ func (d *Drawer) drawRallyLine(screen *ebiten.Image, ms *state.MissionState) {
 	const dashLen = 8.0
 	const gapLen = 6.0
 
-	// 用分段线段绘制虚线
-	dx := lineEndX - lineStartX
-	dy := lineEndY - lineStartY
-	totalDist := math.Sqrt(dx*dx + dy*dy)
-	ux, uy := dx/totalDist, dy/totalDist
-	progress := 0.0
-	drawing := true
 	lineColor := color.RGBA{R: 72, G: 206, B: 128, A: 180}
-
-	for progress < totalDist {
-		seg := dashLen
-		if !drawing {
-			seg = gapLen
-		}
-		if progress+seg > totalDist {
-			seg = totalDist - progress
-		}
-		if drawing {
-			segStartX := lineStartX + ux*progress
-			segStartY := lineStartY + uy*progress
-			segEndX := segStartX + ux*seg
-			segEndY := segStartY + uy*seg
-			vector.StrokeLine(
-				screen,
-				float32(segStartX), float32(segStartY),
-				float32(segEndX), float32(segEndY),
-				2, lineColor, false,
-			)
-		}
-		progress += seg
-		drawing = !drawing
-	}
+	ebutil.DrawDashedLine(screen, lineStartX, lineStartY, lineEndX, lineEndY, dashLen, gapLen, 2, lineColor)
 
 	// 在集结点位置绘制旗帜标记
 	ebutil.DrawFlagMarker(screen, endX, endY, rallyFlagPoleHeight, colorx.Green)
 }
+
+// keyBindingPair 一对操作当前绑定的按键（如增援点界面的上 / 下方向键），用于操作提示
+func keyBindingPair(first, second config.KeyAction) string {
+	return fmt.Sprintf("%s / %s", config.KeyBinding(first), config.KeyBinding(second))
+}
@@ -0,0 +1,85 @@
+package drawer
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/samber/lo"
+
+	"github.com/narasux/jutland/pkg/i18n"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/resources/font"
+	"github.com/narasux/jutland/pkg/utils/colorx"
+	"github.com/narasux/jutland/pkg/utils/ebutil"
+)
+
+// drawShipDamage 绘制战舰部件战损：战损中的武器位置标记红叉，引擎 / 舵机受损、火灾 / 进水、损管冷却、燃油 & 弹药在舰体下方标注
+func (d *Drawer) drawShipDamage(screen *ebiten.Image, ms *state.MissionState, s *objUnit.BattleShip, shipX, shipY float64) {
+	sceneScale := ms.ZoomScale()
+	for _, pos := range s.DamagedMountPositions() {
+		x, y := ms.CameraPosToScreen(pos)
+		ebutil.DrawCrossMarker(screen, x, y, 4*sceneScale, 2, colorx.Red)
+	}
+
+	type label struct {
+		text string
+		clr  color.Color
+	}
+	labels := []label{}
+	if s.EngineDamaged() {
+		labels = append(labels, label{i18n.Text(i18n.MsgShipEngineDamaged), colorx.Orange})
+	}
+	if s.RudderDamaged() {
+		labels = append(labels, label{i18n.Text(i18n.MsgShipRudderDamaged), colorx.Orange})
+	}
+	if fires := s.OnFire(); fires > 0 {
+		labels = append(labels, label{i18n.Format(i18n.MsgShipOnFire, map[string]any{"Count": fires}), colorx.Red})
+	}
+	if floods := s.Flooding(); floods > 0 {
+		labels = append(labels, label{i18n.Format(i18n.MsgShipFlooding, map[string]any{"Count": floods}), colorx.SkyBlue})
+	}
+	if readyAt := s.Hazards.DamageControlReadyAt; readyAt != 0 {
+		seconds := (readyAt - ms.Core.Clock.Now() + 999) / 1000
+		labels = append(labels, label{
+			i18n.Format(i18n.MsgShipDamageControlCooldown, map[string]any{"Seconds": max(0, seconds)}), colorx.Silver,
+		})
+	}
+	// 弹药 & 燃油只展示己方战舰的（未满的）
+	if s.BelongPlayer == ms.Player.CurPlayer {
+		if s.OutOfFuel() {
+			labels = append(labels, label{i18n.Text(i18n.MsgShipOutOfFuel), colorx.Red})
+		} else if rate := s.FuelRate(); rate < 1 {
+			labels = append(labels, label{
+				i18n.Format(i18n.MsgShipFuel, map[string]any{"Percent": int(math.Ceil(rate * 100))}),
+				lo.Ternary[color.Color](s.LowFuel(), colorx.Orange, colorx.Gold),
+			})
+		}
+		for _, t := range objUnit.AmmoWeaponTypes {
+			ammo, capacity := s.Weapon.Ammo(t)
+			if ammo >= capacity {
+				continue
+			}
+			weapon := objUnit.WeaponDisplayName(t)
+			if s.Weapon.OutOfAmmo(t) {
+				labels = append(labels, label{
+					i18n.Format(i18n.MsgShipOutOfAmmo, map[string]any{"Weapon": weapon}), colorx.Red,
+				})
+				continue
+			}
+			labels = append(labels, label{
+				i18n.Format(i18n.MsgShipAmmo, map[string]any{"Weapon": weapon, "Ammo": ammo, "Capacity": capacity}),
+				colorx.Gold,
+			})
+		}
+	}
+	fontSize := 14 * sceneScale
+	for idx, l := range labels {
+		d.drawText(
+			screen, l.text,
+			shipX-25*sceneScale, shipY+25*sceneScale+float64(idx)*(fontSize+2),
+			fontSize, font.LocalizedUI(font.Kai), l.clr,
+		)
+	}
+}
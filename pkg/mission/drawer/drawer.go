@@ -62,6 +62,7 @@ func (d *Drawer) Draw(
 		d.drawObjectTrails(screen, misState)
 		d.drawExplosions(screen, misState)
 		d.drawHospitalShipHealRange(screen, misState)
+		d.drawLastKnownShips(screen, misState)
 		d.drawBattleShips(screen, misState)
 		d.drawDestroyedShips(screen, misState)
 		d.drawFlyingPlanes(screen, misState)
@@ -69,9 +70,17 @@ func (d *Drawer) Draw(
 		// 用户行为
 		d.drawArrowOnMapWhenHover(screen, misState)
 		d.drawSelectedArea(screen, misState)
+		d.drawShipOrders(screen, misState)
+		d.drawPlannedOrders(screen, misState)
+		d.drawShipFormations(screen, misState)
+		d.drawShipDuties(screen, misState)
+		d.drawShipEngagements(screen, misState)
+		d.drawShipAirOps(screen, misState)
 		d.drawMarks(screen, misState)
 		d.drawRallyLine(screen, misState)
 		d.drawPauseOverlay(screen, misState)
+		d.drawNetplayWaiting(screen, misState)
+		d.drawTacticalPause(screen, misState)
 		// 调试信息
 		d.drawDebugPrint(screen, misState)
 	}
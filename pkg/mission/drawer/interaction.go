@@ -1,11 +1,17 @@
 package drawer
 
 import (
+	"fmt"
+	"image/color"
+	"strings"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	"github.com/narasux/jutland/pkg/mission/action"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/resources/font"
 	textureImg "github.com/narasux/jutland/pkg/resources/images/texture"
 	"github.com/narasux/jutland/pkg/utils/colorx"
 	"github.com/narasux/jutland/pkg/utils/ebutil"
@@ -60,3 +66,175 @@ func (d *Drawer) drawMarks(screen *ebiten.Image, ms *state.MissionState) {
 		drawImageCenteredAtMapPos(screen, ms, mark.Img, mark.Pos, 0, ms.ZoomScale())
 	}
 }
+
+// 排队命令航线颜色
+var shipOrderColors = map[objUnit.OrderType]color.RGBA{
+	objUnit.OrderTypeMove:   {R: 96, G: 180, B: 255, A: 180},
+	objUnit.OrderTypeAttack: {R: 255, G: 72, B: 72, A: 180},
+	objUnit.OrderTypePatrol: {R: 255, G: 208, B: 64, A: 180},
+	// 攻击移动
+	objUnit.OrderTypeAttackMove: {R: 255, G: 128, B: 64, A: 180},
+}
+
+// 绘制选中战舰的排队命令航线（巡逻航线首尾相连）
+func (d *Drawer) drawShipOrders(screen *ebiten.Image, ms *state.MissionState) {
+	const (
+		dashLen  = 8.0
+		gapLen   = 6.0
+		crossLen = 5.0
+	)
+	for _, shipUid := range ms.Interaction.SelectedShips {
+		ship, ok := ms.Arena.Ships[shipUid]
+		if !ok || ship.BelongPlayer != ms.Player.CurPlayer || len(ship.Orders) == 0 {
+			continue
+		}
+
+		prevX, prevY := ms.CameraPosToScreen(ship.CurPos)
+		firstPatrolX, firstPatrolY, hasPatrol := 0.0, 0.0, false
+		for _, order := range ship.Orders {
+			pos := order.Pos
+			// 攻击目标可见时，航线指向目标当前位置
+			if order.Type == objUnit.OrderTypeAttack {
+				if target, ok := ms.Arena.Ships[order.TargetUid]; ok && ms.CanSee(ms.Player.CurPlayer, target) {
+					pos = target.CurPos
+				}
+			}
+			x, y := ms.CameraPosToScreen(pos)
+			clr := shipOrderColors[order.Type]
+			ebutil.DrawDashedLine(screen, prevX, prevY, x, y, dashLen, gapLen, 2, clr)
+			ebutil.DrawCrossMarker(screen, x, y, crossLen, 2, clr)
+			if order.Type == objUnit.OrderTypePatrol && !hasPatrol {
+				firstPatrolX, firstPatrolY, hasPatrol = x, y, true
+			}
+			prevX, prevY = x, y
+		}
+		if hasPatrol {
+			ebutil.DrawDashedLine(
+				screen, prevX, prevY, firstPatrolX, firstPatrolY,
+				dashLen, gapLen, 2, shipOrderColors[objUnit.OrderTypePatrol],
+			)
+		}
+	}
+}
+
+// 绘制选中编队的阵型名称（向导舰下方）与跟随舰的编队位置
+func (d *Drawer) drawShipFormations(screen *ebiten.Image, ms *state.MissionState) {
+	slotColor := color.RGBA{R: 140, G: 220, B: 255, A: 160}
+	for _, shipUid := range ms.Interaction.SelectedShips {
+		guide, ok := ms.Arena.Ships[shipUid]
+		if !ok || guide.BelongPlayer != ms.Player.CurPlayer || !guide.IsFormationGuide() {
+			continue
+		}
+		sceneScale := ms.ZoomScale()
+		for _, ship := range ms.Arena.Ships {
+			if ship.IsFormationGuide() || ship.Formation.GuideUid != guide.Uid {
+				continue
+			}
+			x, y := ms.CameraPosToScreen(ship.Formation.Slot.Pos(guide.CurPos, guide.CurRotation))
+			vector.StrokeCircle(screen, float32(x), float32(y), float32(6*sceneScale), 1.5, slotColor, true)
+		}
+		guideX, guideY := ms.CameraPosToScreen(guide.CurPos)
+		d.drawText(
+			screen, guide.Formation.Type.ToDisplay(),
+			guideX-30*sceneScale, guideY+40*sceneScale, 20*sceneScale,
+			font.LocalizedUI(font.Kai), colorx.White,
+		)
+	}
+}
+
+// 值守任务标识颜色
+var shipDutyColors = map[objUnit.DutyType]color.RGBA{
+	objUnit.DutyEscort: {R: 96, G: 230, B: 128, A: 180},
+	objUnit.DutyGuard:  {R: 255, G: 160, B: 64, A: 160},
+}
+
+// 绘制选中战舰的值守任务（护航：指向护航对象的虚线；警戒：警戒区域范围圈）
+func (d *Drawer) drawShipDuties(screen *ebiten.Image, ms *state.MissionState) {
+	for _, shipUid := range ms.Interaction.SelectedShips {
+		ship, ok := ms.Arena.Ships[shipUid]
+		if !ok || ship.BelongPlayer != ms.Player.CurPlayer || !ship.HasDuty() {
+			continue
+		}
+		clr := shipDutyColors[ship.Duty.Type]
+		x, y := ms.CameraPosToScreen(ship.CurPos)
+		switch ship.Duty.Type {
+		case objUnit.DutyEscort:
+			if target, ok := ms.Arena.Ships[ship.Duty.TargetUid]; ok {
+				tx, ty := ms.CameraPosToScreen(target.CurPos)
+				ebutil.DrawDashedLine(screen, x, y, tx, ty, 4, 4, 1.5, clr)
+			}
+		case objUnit.DutyGuard:
+			ax, ay := ms.CameraPosToScreen(ship.Duty.Anchor)
+			ebutil.DrawDashedLine(screen, x, y, ax, ay, 4, 4, 1.5, clr)
+			vector.StrokeCircle(
+				screen, float32(ax), float32(ay),
+				float32(ship.Duty.Radius*ms.MapBlockDisplaySize()), 1.5, clr, true,
+			)
+		}
+	}
+}
+
+// 目标策略展示顺序
+var targetPolicyWeaponTypes = []objUnit.WeaponType{
+	objUnit.WeaponTypeMainGun,
+	objUnit.WeaponTypeSecondaryGun,
+	objUnit.WeaponTypeAntiAircraftGun,
+	objUnit.WeaponTypeTorpedo,
+	objUnit.WeaponTypeRocket,
+}
+
+// 绘制选中战舰的车钟、交战规则、保持距离与非默认的目标策略（战舰下方，没有需要展示的设置时不绘制）
+func (d *Drawer) drawShipEngagements(screen *ebiten.Image, ms *state.MissionState) {
+	for _, shipUid := range ms.Interaction.SelectedShips {
+		ship, ok := ms.Arena.Ships[shipUid]
+		if !ok || ship.BelongPlayer != ms.Player.CurPlayer {
+			continue
+		}
+		labels := []string{}
+		if ship.EngineOrder != objUnit.EngineOrderFlank {
+			labels = append(labels, ship.EngineOrder.ToDisplay())
+		}
+		if ship.FireStance != objUnit.FireStanceFireAtWill {
+			labels = append(labels, ship.FireStance.ToDisplay())
+		}
+		if ship.StandOffRate > 0 {
+			labels = append(labels, objUnit.StandOffDisplay(ship.StandOffRate))
+		}
+		for _, weaponType := range targetPolicyWeaponTypes {
+			if policy := ship.TargetPolicies[weaponType]; policy != objUnit.TargetPolicyDefault {
+				labels = append(labels, fmt.Sprintf("%s: %s", objUnit.WeaponDisplayName(weaponType), policy.ToDisplay()))
+			}
+		}
+		if len(labels) == 0 {
+			continue
+		}
+		sceneScale := ms.ZoomScale()
+		x, y := ms.CameraPosToScreen(ship.CurPos)
+		d.drawText(
+			screen, strings.Join(labels, " / "),
+			x-30*sceneScale, y+60*sceneScale, 16*sceneScale,
+			font.LocalizedUI(font.Kai), colorx.White,
+		)
+	}
+}
+
+// drawShipAirOps 选中航母的舰载机指令 & 各分组剩余数量
+func (d *Drawer) drawShipAirOps(screen *ebiten.Image, ms *state.MissionState) {
+	for _, shipUid := range ms.Interaction.SelectedShips {
+		ship, ok := ms.Arena.Ships[shipUid]
+		if !ok || ship.BelongPlayer != ms.Player.CurPlayer || !ship.Aircraft.HasPlane {
+			continue
+		}
+		labels := []string{ship.Aircraft.GroupsDisplay()}
+		if ops := ship.Aircraft.OpsDisplay(); ops != "" {
+			labels = append(labels, ops)
+		}
+		sceneScale := ms.ZoomScale()
+		x, y := ms.CameraPosToScreen(ship.CurPos)
+		d.drawText(
+			screen, strings.Join(labels, " / "),
+			x-30*sceneScale, y+80*sceneScale, 16*sceneScale,
+			font.LocalizedUI(font.Kai), colorx.White,
+		)
+	}
+}
@@ -0,0 +1,26 @@
+package drawer
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/narasux/jutland/pkg/i18n"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/utils/colorx"
+)
+
+// drawNetplayWaiting 联机对战中等待对方指令时，在屏幕顶部绘制提示
+func (d *Drawer) drawNetplayWaiting(screen *ebiten.Image, ms *state.MissionState) {
+	if !ms.UI.WaitingForPeer {
+		return
+	}
+	centerX := float64(ms.View.Layout.Width) / 2
+	panelW, panelH := 360.0, 44.0
+	vector.FillRect(
+		screen, float32(centerX-panelW/2), 8, float32(panelW), float32(panelH),
+		color.RGBA{R: 10, G: 25, B: 31, A: 200}, false,
+	)
+	d.drawCenteredPauseText(screen, i18n.Text(i18n.MsgNetplayWaitingForPeer), centerX, 16, 20, colorx.White)
+}
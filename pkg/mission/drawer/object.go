@@ -107,7 +107,7 @@ func drawUnitHitBox(screen *ebiten.Image, ms *state.MissionState, battleUnit obj
 	for idx, corner := range corners {
 		nextCorner := corners[(idx+1)%len(corners)]
 		clr := color.RGBA{R: 255, A: 80}
-		if battleUnit.Player() == ms.Player.CurPlayer {
+		if ms.IsAlly(ms.Player.CurPlayer, battleUnit.Player()) {
 			clr = color.RGBA{G: 255, A: 80}
 		}
 		vector.StrokeLine(screen,
@@ -150,6 +150,10 @@ func (d *Drawer) drawObjectTrails(screen *ebiten.Image, ms *state.MissionState)
 		if !(ms.View.Camera.Contains(trail.Pos) && trail.IsActive()) {
 			continue
 		}
+		// 战争迷雾中的尾流不渲染，避免暴露敌方位置
+		if !ms.PosInVision(ms.Player.CurPlayer, trail.Pos) {
+			continue
+		}
 
 		trailImg := textureImg.GetTrail(trail.Shape, trail.CurSize, trail.CurLife, trail.Color)
 		drawImageCenteredAtMapPos(screen, ms, trailImg, trail.Pos, trail.Rotation, ms.ZoomScale())
@@ -159,7 +163,7 @@ func (d *Drawer) drawObjectTrails(screen *ebiten.Image, ms *state.MissionState)
 // drawExplosions 绘制火箭弹等局部爆炸效果
 func (d *Drawer) drawExplosions(screen *ebiten.Image, ms *state.MissionState) {
 	for _, explosion := range ms.Arena.Explosions {
-		if !ms.View.Camera.Contains(explosion.Pos) {
+		if !ms.View.Camera.Contains(explosion.Pos) || !ms.PosInVision(ms.Player.CurPlayer, explosion.Pos) {
 			continue
 		}
 		explodeImg := textureImg.GetPlaneExplode(explosion.FrameHP())
@@ -176,9 +180,10 @@ func (d *Drawer) drawBattleShips(screen *ebiten.Image, ms *state.MissionState) {
 		return strings.Compare(a.Uid, b.Uid)
 	})
 
+	now := ms.Core.Clock.Now()
 	for _, s := range ships {
-		// 只有在屏幕中的才渲染
-		if !ms.View.Camera.Contains(s.CurPos) {
+		// 只有在屏幕中，且不在战争迷雾中的才渲染
+		if !ms.View.Camera.Contains(s.CurPos) || !ms.CanSee(ms.Player.CurPlayer, s) {
 			continue
 		}
 
@@ -230,9 +235,10 @@ func (d *Drawer) drawBattleShips(screen *ebiten.Image, ms *state.MissionState) {
 			// 绘制主炮状态
 			if s.Weapon.HasMainGun {
 				status := weaponImg.WeaponStatusReloading
-				if s.Weapon.MainGunDisabled {
+				if s.Weapon.MainGunDisabled || s.Weapon.AllDamaged(objUnit.WeaponTypeMainGun) ||
+					s.Weapon.OutOfAmmo(objUnit.WeaponTypeMainGun) {
 					status = weaponImg.WeaponStatusDisabled
-				} else if s.Weapon.MainGunReloaded() {
+				} else if s.Weapon.MainGunReloaded(now) {
 					status = weaponImg.WeaponStatusLoaded
 				}
 
@@ -243,9 +249,10 @@ func (d *Drawer) drawBattleShips(screen *ebiten.Image, ms *state.MissionState) {
 			// 绘制副炮状态
 			if s.Weapon.HasSecondaryGun {
 				status := weaponImg.WeaponStatusReloading
-				if s.Weapon.SecondaryGunDisabled {
+				if s.Weapon.SecondaryGunDisabled || s.Weapon.AllDamaged(objUnit.WeaponTypeSecondaryGun) ||
+					s.Weapon.OutOfAmmo(objUnit.WeaponTypeSecondaryGun) {
 					status = weaponImg.WeaponStatusDisabled
-				} else if s.Weapon.SecondaryGunReloaded() {
+				} else if s.Weapon.SecondaryGunReloaded(now) {
 					status = weaponImg.WeaponStatusLoaded
 				}
 
@@ -261,7 +268,8 @@ func (d *Drawer) drawBattleShips(screen *ebiten.Image, ms *state.MissionState) {
 			// 绘制防空炮状态（注：由于防空炮装填速度很快，所以不需要绘制装填中的状态，即只有红绿两种）
 			if s.Weapon.HasAntiAircraftGun {
 				status := weaponImg.WeaponStatusLoaded
-				if s.Weapon.AntiAircraftGunDisabled {
+				if s.Weapon.AntiAircraftGunDisabled || s.Weapon.AllDamaged(objUnit.WeaponTypeAntiAircraftGun) ||
+					s.Weapon.OutOfAmmo(objUnit.WeaponTypeAntiAircraftGun) {
 					status = weaponImg.WeaponStatusDisabled
 				}
 
@@ -277,9 +285,10 @@ func (d *Drawer) drawBattleShips(screen *ebiten.Image, ms *state.MissionState) {
 			// 绘制鱼雷发射器状态
 			if s.Weapon.HasTorpedo {
 				status := weaponImg.WeaponStatusReloading
-				if s.Weapon.TorpedoDisabled {
+				if s.Weapon.TorpedoDisabled || s.Weapon.AllDamaged(objUnit.WeaponTypeTorpedo) ||
+					s.Weapon.OutOfAmmo(objUnit.WeaponTypeTorpedo) {
 					status = weaponImg.WeaponStatusDisabled
-				} else if s.Weapon.TorpedoLauncherReloaded() {
+				} else if s.Weapon.TorpedoLauncherReloaded(now) {
 					status = weaponImg.WeaponStatusLoaded
 				}
 
@@ -291,9 +300,10 @@ func (d *Drawer) drawBattleShips(screen *ebiten.Image, ms *state.MissionState) {
 			// 绘制火箭炮发射器状态
 			if s.Weapon.HasRocket {
 				status := weaponImg.WeaponStatusReloading
-				if s.Weapon.RocketDisabled {
+				if s.Weapon.RocketDisabled || s.Weapon.AllDamaged(objUnit.WeaponTypeRocket) ||
+					s.Weapon.OutOfAmmo(objUnit.WeaponTypeRocket) {
 					status = weaponImg.WeaponStatusDisabled
-				} else if s.Weapon.RocketLauncherReloaded() {
+				} else if s.Weapon.RocketLauncherReloaded(now) {
 					status = weaponImg.WeaponStatusLoaded
 				}
 
@@ -311,22 +321,57 @@ func (d *Drawer) drawBattleShips(screen *ebiten.Image, ms *state.MissionState) {
 			}
 		}
 
-		// 如果全局启用状态展示，则敌方战舰也要绘制 HP 值
+		// 如果全局启用状态展示，则友军 / 敌方战舰也要绘制 HP 值
 		if ms.UI.GameOpts.ForceDisplayState && s.BelongPlayer != ms.Player.CurPlayer {
 			sceneScale := ms.ZoomScale()
-			hpImg := textureImg.GetEnemyHP(s.CurHP, s.TotalHP)
+			hpImg := lo.Ternary(
+				ms.IsAlly(ms.Player.CurPlayer, s.BelongPlayer),
+				textureImg.GetHP(s.CurHP, s.TotalHP),
+				textureImg.GetEnemyHP(s.CurHP, s.TotalHP),
+			)
 			drawImageAtScale(screen, hpImg, shipX-25*sceneScale, shipY-30*sceneScale, sceneScale)
 		}
 
-		// TODO 绘制战损情况，开火情况
+		// 己方战舰被选中 或 全局启用状态展示时，绘制部件战损 & 火灾 / 进水情况
+		if ms.UI.GameOpts.ForceDisplayState ||
+			(s.BelongPlayer == ms.Player.CurPlayer && slices.Contains(ms.Interaction.SelectedShips, s.Uid)) {
+			d.drawShipDamage(screen, ms, s, shipX, shipY)
+		}
+
+		// TODO 绘制开火情况
+	}
+}
+
+// 绘制敌舰在战争迷雾中的最后已知位置（逐渐淡出）
+func (d *Drawer) drawLastKnownShips(screen *ebiten.Image, ms *state.MissionState) {
+	if ms.Vision.Revealed {
+		return
+	}
+	now := ms.Core.Clock.Now()
+	for _, s := range ms.Vision.LastKnown {
+		if !ms.View.Camera.Contains(s.Pos) {
+			continue
+		}
+		sImg, sImgScale := shipResource(s.ImageName, ms.UI.GameOpts.Zoom)
+		w, h := sImg.Bounds().Dx(), sImg.Bounds().Dy()
+		shipX, shipY := ms.CameraPosToScreen(s.Pos)
+
+		opts := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+		opts.GeoM.Translate(-float64(w)/2, -float64(h)/2)
+		opts.GeoM.Rotate(s.Rotation * degToRad)
+		opts.GeoM.Scale(sImgScale, sImgScale)
+		opts.GeoM.Translate(shipX, shipY)
+		// 残影只保留一半的不透明度，随离开视野的时长继续淡出
+		opts.ColorScale.ScaleAlpha(float32(s.Alpha(now) * 0.5))
+		screen.DrawImage(sImg, opts)
 	}
 }
 
 // 绘制消亡中的战舰
 func (d *Drawer) drawDestroyedShips(screen *ebiten.Image, ms *state.MissionState) {
 	for _, s := range ms.Arena.DestroyedShips {
-		// 只有在屏幕中的才渲染
-		if !ms.View.Camera.Contains(s.CurPos) {
+		// 只有在屏幕中，且不在战争迷雾中的才渲染
+		if !ms.View.Camera.Contains(s.CurPos) || !ms.PosInVision(ms.Player.CurPlayer, s.CurPos) {
 			continue
 		}
 
@@ -349,8 +394,8 @@ func (d *Drawer) drawFlyingPlanes(screen *ebiten.Image, ms *state.MissionState)
 	})
 
 	for _, p := range planes {
-		// 只有在屏幕中的才渲染
-		if !ms.View.Camera.Contains(p.CurPos) {
+		// 只有在屏幕中，且不在战争迷雾中的才渲染
+		if !ms.View.Camera.Contains(p.CurPos) || !ms.CanSee(ms.Player.CurPlayer, p) {
 			continue
 		}
 
@@ -373,7 +418,7 @@ func (d *Drawer) drawFlyingPlanes(screen *ebiten.Image, ms *state.MissionState)
 			posY := planeY - float64(pImg.Bounds().Dy())*pImgScale/2 - 20*ms.ZoomScale()
 			// 根据阵营选择颜色：友军绿色，敌军红色
 			textColor := colorx.Green
-			if p.BelongPlayer != ms.Player.CurPlayer {
+			if !ms.IsAlly(ms.Player.CurPlayer, p.BelongPlayer) {
 				textColor = colorx.Red
 			}
 			d.drawText(screen, hpText, posX, posY, fontSize, font.Hang, textColor)
@@ -384,8 +429,8 @@ func (d *Drawer) drawFlyingPlanes(screen *ebiten.Image, ms *state.MissionState)
 // 绘制消亡中的战机
 func (d *Drawer) drawDestroyedPlanes(screen *ebiten.Image, ms *state.MissionState) {
 	for _, p := range ms.Arena.DestroyedPlanes {
-		// 只有在屏幕中的才渲染
-		if !ms.View.Camera.Contains(p.CurPos) {
+		// 只有在屏幕中，且不在战争迷雾中的才渲染
+		if !ms.View.Camera.Contains(p.CurPos) || !ms.PosInVision(ms.Player.CurPlayer, p.CurPos) {
 			continue
 		}
 
@@ -402,6 +447,10 @@ func (d *Drawer) drawDestroyedPlanes(screen *ebiten.Image, ms *state.MissionStat
 // 绘制已发射的弹丸
 func (d *Drawer) drawShotBullets(screen *ebiten.Image, ms *state.MissionState) {
 	for _, b := range ms.Arena.ForwardingBullets {
+		// 战争迷雾中的敌方弹药不渲染
+		if !ms.IsAlly(ms.Player.CurPlayer, b.BelongPlayer) && !ms.PosInVision(ms.Player.CurPlayer, b.CurPos) {
+			continue
+		}
 		img := objBullet.GetImg(b.Type, b.Diameter)
 
 		rotation := b.Rotation
@@ -0,0 +1,60 @@
+package drawer
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/samber/lo"
+
+	"github.com/narasux/jutland/pkg/common/constants"
+	"github.com/narasux/jutland/pkg/i18n"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/utils/colorx"
+)
+
+// ReplayStatus 录像回放状态
+type ReplayStatus struct {
+	Paused   bool
+	Finished bool
+	// 回放倍速
+	Speed int
+	// 录像结束帧
+	EndTick int64
+	// 录制期间是否使用过作弊指令
+	Cheated bool
+}
+
+// DrawReplayStatus 在屏幕顶部绘制录像回放进度与操作提示
+func (d *Drawer) DrawReplayStatus(screen *ebiten.Image, ms *state.MissionState, rs ReplayStatus) {
+	params := map[string]any{
+		"Speed":   rs.Speed,
+		"Current": formatReplayTime(ms.Core.Clock.Ticks),
+		"Total":   formatReplayTime(rs.EndTick),
+	}
+	msgID := i18n.MsgReplayPlaying
+	if rs.Finished {
+		msgID = i18n.MsgReplayFinished
+	} else if rs.Paused {
+		msgID = i18n.MsgReplayPaused
+	}
+
+	centerX := float64(ms.View.Layout.Width) / 2
+	panelW, panelH := 640.0, lo.Ternary(rs.Cheated, 100.0, 72.0)
+	vector.FillRect(
+		screen, float32(centerX-panelW/2), 8, float32(panelW), float32(panelH),
+		color.RGBA{R: 10, G: 25, B: 31, A: 200}, false,
+	)
+	d.drawCenteredPauseText(screen, i18n.Format(msgID, params), centerX, 16, 24, colorx.White)
+	d.drawCenteredPauseText(screen, i18n.Text(i18n.MsgReplayHint), centerX, 48, 16, colorx.Silver)
+	if rs.Cheated {
+		d.drawCenteredPauseText(screen, i18n.Text(i18n.MsgReplayCheated), centerX, 76, 16, colorx.Gold)
+	}
+}
+
+// formatReplayTime 将帧数格式化为 mm:ss
+func formatReplayTime(ticks int64) string {
+	seconds := ticks / constants.MaxTPS
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
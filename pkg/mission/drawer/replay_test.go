@@ -0,0 +1,14 @@
+package drawer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatReplayTime(t *testing.T) {
+	require.Equal(t, "00:00", formatReplayTime(0))
+	require.Equal(t, "00:01", formatReplayTime(60))
+	require.Equal(t, "01:30", formatReplayTime(90*60))
+	require.Equal(t, "100:00", formatReplayTime(6000*60))
+}
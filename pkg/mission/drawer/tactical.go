@@ -0,0 +1,90 @@
+package drawer
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/i18n"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/resources/font"
+	"github.com/narasux/jutland/pkg/utils/colorx"
+	"github.com/narasux/jutland/pkg/utils/ebutil"
+)
+
+// plannedOrderPos 尚未生效的命令的位置（攻击目标可见时为目标当前位置，不可见且没有记录位置时不绘制）
+func plannedOrderPos(ms *state.MissionState, order objUnit.ShipOrder) (objPos.MapPos, bool) {
+	if order.Type == objUnit.OrderTypeAttack {
+		if target, ok := ms.Arena.Ships[order.TargetUid]; ok && ms.CanSee(ms.Player.CurPlayer, target) {
+			return target.CurPos, true
+		}
+		return order.Pos, order.Pos != objPos.MapPos{}
+	}
+	return order.Pos, true
+}
+
+// drawPlannedOrders 绘制战术暂停时下达、尚未生效的命令（从现有航线末端或战舰当前位置出发，圆圈标记航点）
+// 以及尚未生效的设置（武器开关、交战规则、车钟等，在战舰上方标注）
+func (d *Drawer) drawPlannedOrders(screen *ebiten.Image, ms *state.MissionState) {
+	const (
+		dashLen = 4.0
+		gapLen  = 4.0
+		radius  = 6.0
+	)
+	for shipUid, planned := range ms.UI.PlannedOrders {
+		ship, ok := ms.Arena.Ships[shipUid]
+		if !ok || ship.BelongPlayer != ms.Player.CurPlayer {
+			continue
+		}
+		prevX, prevY := ms.CameraPosToScreen(ship.CurPos)
+		if planned.Settings != nil {
+			if labels := planned.Settings.ChangesDisplay(ship.Settings()); len(labels) != 0 {
+				sceneScale := ms.ZoomScale()
+				d.drawText(
+					screen, "→ "+strings.Join(labels, " / "),
+					prevX-30*sceneScale, prevY-85*sceneScale, 16*sceneScale,
+					font.LocalizedUI(font.Kai), colorx.Gold,
+				)
+			}
+		}
+		if !planned.Replace {
+			for _, order := range ship.Orders {
+				if pos, ok := plannedOrderPos(ms, order); ok {
+					prevX, prevY = ms.CameraPosToScreen(pos)
+				}
+			}
+		}
+		for _, order := range planned.Orders {
+			pos, ok := plannedOrderPos(ms, order)
+			if !ok {
+				continue
+			}
+			x, y := ms.CameraPosToScreen(pos)
+			clr := shipOrderColors[order.Type]
+			clr.A = 255
+			ebutil.DrawDashedLine(screen, prevX, prevY, x, y, dashLen, gapLen, 2, clr)
+			vector.StrokeCircle(screen, float32(x), float32(y), radius, 2, clr, true)
+			prevX, prevY = x, y
+		}
+	}
+}
+
+// drawTacticalPause 战术暂停时，在屏幕顶部绘制提示
+func (d *Drawer) drawTacticalPause(screen *ebiten.Image, ms *state.MissionState) {
+	if !ms.UI.TacticalPaused || ms.Core.MissionStatus == state.MissionPaused {
+		return
+	}
+	centerX := float64(ms.View.Layout.Width) / 2
+	panelW, panelH := 360.0, 44.0
+	vector.FillRect(
+		screen, float32(centerX-panelW/2), 8, float32(panelW), float32(panelH),
+		color.RGBA{R: 31, G: 25, B: 10, A: 200}, false,
+	)
+	hint := i18n.Format(i18n.MsgTacticalPaused, map[string]any{"Key": config.KeyBinding(config.KeyActionTacticalPause)})
+	d.drawCenteredPauseText(screen, hint, centerX, 16, 20, colorx.Gold)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/narasux/jutland/pkg/i18n"
 
 	"github.com/narasux/jutland/pkg/common/constants"
+	"github.com/narasux/jutland/pkg/config"
 	"github.com/narasux/jutland/pkg/mission/action"
 	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 	"github.com/narasux/jutland/pkg/mission/state"
@@ -52,6 +53,11 @@ func (d *Drawer) drawPauseOverlay(screen *ebiten.Image, ms *state.MissionState)
 	}
 
 	d.drawCenteredPauseText(screen, title, ui.Panel.X+ui.Panel.W/2, ui.Panel.Y+46, 30, colorx.White)
+	// 展示随机种子，便于反馈问题时复现战斗
+	d.drawCenteredPauseText(
+		screen, i18n.Format(i18n.MsgMissionPausedSeed, map[string]any{"Seed": ms.Core.Seed}),
+		ui.Panel.X+ui.Panel.W/2, ui.Panel.Y+90, 16, colorx.Silver,
+	)
 	d.drawPauseButton(
 		screen, ui.PrimaryButton, primaryText,
 		color.RGBA{R: 33, G: 63, B: 69, A: 235},
@@ -62,11 +68,30 @@ func (d *Drawer) drawPauseOverlay(screen *ebiten.Image, ms *state.MissionState)
 		color.RGBA{R: 77, G: 37, B: 33, A: 235},
 		color.RGBA{R: 214, G: 118, B: 91, A: 255},
 	)
+	if !ms.Core.ConfirmQuitMission {
+		d.drawPauseSaveHint(screen, ms, ui)
+	}
+}
+
+// drawPauseSaveHint 绘制暂停面板底部的保存进度提示 / 结果
+func (d *Drawer) drawPauseSaveHint(screen *ebiten.Image, ms *state.MissionState, ui state.PauseUILayout) {
+	hint := i18n.Format(i18n.MsgMissionSaveHint, map[string]any{"Key": config.KeyBinding(config.KeyActionSaveProgress)})
+	clr := color.Color(colorx.Silver)
+	switch ms.UI.PauseSaveStatus {
+	case state.PauseSaveSucceeded:
+		hint, clr = i18n.Text(i18n.MsgMissionSaveSucceeded), colorx.Green
+	case state.PauseSaveFailed:
+		hint, clr = i18n.Text(i18n.MsgMissionSaveFailed), colorx.Red
+	}
+	d.drawCenteredPauseText(screen, hint, ui.Panel.X+ui.Panel.W/2, ui.Panel.Y+180, 16, clr)
 }
 
 // drawDebugPauseHint 在 debug 模式下绘制简洁的暂停提示文字（无遮罩）
 func (d *Drawer) drawDebugPauseHint(screen *ebiten.Image, ms *state.MissionState) {
-	hint := i18n.Text(i18n.MsgMissionDebugPauseHint)
+	hint := i18n.Format(i18n.MsgMissionDebugPauseHint, map[string]any{
+		"QuitKey":   config.KeyBinding(config.KeyActionQuitMission),
+		"ResumeKey": config.KeyBinding(config.KeyActionPause),
+	})
 
 	textFont := font.LocalizedUI(font.Kai)
 	textFace := text.GoTextFace{Source: textFont, Size: 22}
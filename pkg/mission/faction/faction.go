@@ -1,6 +1,12 @@
 // faction 游戏阵营
 package faction
 
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
 type Player string
 
 const (
@@ -12,4 +18,60 @@ const (
 	ComputerAlpha Player = "CA"
 	// ComputerBeta 电脑 AI 玩家二
 	ComputerBeta Player = "CB"
+	// ComputerGamma 电脑 AI 玩家三
+	ComputerGamma Player = "CC"
+	// ComputerDelta 电脑 AI 玩家四
+	ComputerDelta Player = "CD"
+	// Neutral 中立势力（如民用船只），不与任何玩家敌对，也不参与胜负判定
+	Neutral Player = "NE"
 )
+
+// AllPlayers 所有玩家（固定顺序）
+var AllPlayers = []Player{
+	HumanAlpha, HumanBeta, ComputerAlpha, ComputerBeta, ComputerGamma, ComputerDelta, Neutral,
+}
+
+// IsValid 是否为已知的玩家
+func (p Player) IsValid() bool {
+	return slices.Contains(AllPlayers, p)
+}
+
+// Alliances 同盟关系（Key: 玩家，Value: 同盟序号）
+// 同一同盟内的玩家互为友军，未加入同盟的玩家各自为战，中立势力与所有玩家都不敌对
+// 注：nil 表示没有任何同盟，即所有非中立玩家两两敌对
+type Alliances map[Player]int
+
+// NewAlliances 根据同盟分组创建同盟关系
+func NewAlliances(groups [][]Player) (Alliances, error) {
+	alliances := Alliances{}
+	for idx, group := range groups {
+		for _, player := range group {
+			if !player.IsValid() {
+				return nil, errors.Errorf("unknown player %q in alliance %d", player, idx)
+			}
+			if player == Neutral {
+				return nil, errors.Errorf("neutral player can not join alliance %d", idx)
+			}
+			if other, ok := alliances[player]; ok {
+				return nil, errors.Errorf("player %s already in alliance %d", player, other)
+			}
+			alliances[player] = idx
+		}
+	}
+	return alliances, nil
+}
+
+// IsAlly 两个玩家是否为友军（包括同一玩家）
+func (a Alliances) IsAlly(p, q Player) bool {
+	if p == q {
+		return true
+	}
+	pIdx, pOk := a[p]
+	qIdx, qOk := a[q]
+	return pOk && qOk && pIdx == qIdx
+}
+
+// IsEnemy 两个玩家是否敌对（中立势力不与任何玩家敌对）
+func (a Alliances) IsEnemy(p, q Player) bool {
+	return p != Neutral && q != Neutral && !a.IsAlly(p, q)
+}
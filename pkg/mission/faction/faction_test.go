@@ -0,0 +1,38 @@
+package faction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlliances(t *testing.T) {
+	alliances, err := NewAlliances([][]Player{{HumanAlpha, ComputerBeta}, {ComputerAlpha}})
+	require.NoError(t, err)
+
+	require.True(t, alliances.IsAlly(HumanAlpha, ComputerBeta))
+	require.False(t, alliances.IsEnemy(ComputerBeta, HumanAlpha))
+	require.True(t, alliances.IsEnemy(HumanAlpha, ComputerAlpha))
+	// 未加入同盟的玩家各自为战
+	require.True(t, alliances.IsEnemy(ComputerGamma, ComputerAlpha))
+	require.True(t, alliances.IsAlly(ComputerGamma, ComputerGamma))
+	// 中立势力不是友军，也不是敌人
+	require.False(t, alliances.IsAlly(HumanAlpha, Neutral))
+	require.False(t, alliances.IsEnemy(Neutral, ComputerAlpha))
+}
+
+func TestNilAlliancesAreAllHostile(t *testing.T) {
+	var alliances Alliances
+	require.True(t, alliances.IsEnemy(HumanAlpha, ComputerAlpha))
+	require.True(t, alliances.IsEnemy(HumanAlpha, HumanBeta))
+	require.False(t, alliances.IsEnemy(HumanAlpha, HumanAlpha))
+}
+
+func TestNewAlliancesRejectsInvalidGroups(t *testing.T) {
+	_, err := NewAlliances([][]Player{{HumanAlpha, "XX"}})
+	require.Error(t, err)
+	_, err = NewAlliances([][]Player{{HumanAlpha, Neutral}})
+	require.Error(t, err)
+	_, err = NewAlliances([][]Player{{HumanAlpha}, {HumanAlpha, ComputerAlpha}})
+	require.Error(t, err)
+}
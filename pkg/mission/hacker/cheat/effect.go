@@ -32,7 +32,7 @@ func (c *AngelicaSinensis) Exec(misState *state.MissionState) string {
 
 var _ Cheat = (*AngelicaSinensis)(nil)
 
-// BlackSheepWall 黑羊之墙 -> 地图全开（目前没用）
+// BlackSheepWall 黑羊之墙 -> 地图全开（再次输入则恢复战争迷雾）
 type BlackSheepWall struct{}
 
 func (c *BlackSheepWall) String() string {
@@ -47,8 +47,12 @@ func (c *BlackSheepWall) Match(cmd string) bool {
 	return isCommandEqual(c.String(), cmd)
 }
 
-func (c *BlackSheepWall) Exec(_ *state.MissionState) string {
-	return "Not Implemented"
+func (c *BlackSheepWall) Exec(misState *state.MissionState) string {
+	misState.Vision.Revealed = !misState.Vision.Revealed
+	if !misState.Vision.Revealed {
+		return "The fog of war rolls back in."
+	}
+	return "The fog of war has been lifted, all enemy units are revealed!"
 }
 
 var _ Cheat = (*BlackSheepWall)(nil)
@@ -163,9 +167,17 @@ func (c *YouHaveBetrayedTheWorkingClass) Match(cmd string) bool {
 }
 
 func (c *YouHaveBetrayedTheWorkingClass) Exec(misState *state.MissionState) string {
+	// 投靠任务中第一个与当前玩家敌对的势力，没有则默认为电脑玩家一
+	enemy := faction.ComputerAlpha
+	for _, player := range misState.Core.MissionMD.Players {
+		if misState.IsEnemy(misState.Player.CurPlayer, player) {
+			enemy = player
+			break
+		}
+	}
 	for _, shipUid := range misState.Interaction.SelectedShips {
 		if ship, ok := misState.Arena.Ships[shipUid]; ok {
-			ship.BelongPlayer = faction.ComputerAlpha
+			ship.BelongPlayer = enemy
 		}
 	}
 	return "It's time to clean up the house!"
@@ -216,7 +228,7 @@ func (c *Expelliarmus) Match(cmd string) bool {
 
 func (c *Expelliarmus) Exec(misState *state.MissionState) string {
 	for _, ship := range misState.Arena.Ships {
-		if misState.View.Camera.Contains(ship.CurPos) && ship.BelongPlayer != misState.Player.CurPlayer {
+		if misState.View.Camera.Contains(ship.CurPos) && misState.IsEnemy(misState.Player.CurPlayer, ship.BelongPlayer) {
 			ship.Weapon = objUnit.ShipWeapon{}
 		}
 	}
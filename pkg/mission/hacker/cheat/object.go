@@ -2,7 +2,6 @@ package cheat
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
 
 	"github.com/narasux/jutland/pkg/mission/action"
@@ -164,8 +163,8 @@ func (c *BlackGoldRush) Exec(misState *state.MissionState) string {
 		return "Current cursor position on land, can't create oil platform"
 	}
 	// 油井范围，生成量随机
-	radius := 3 + rand.Intn(4)
-	yield := 50 + rand.Intn(100)
+	radius := 3 + misState.Rand().IntN(4)
+	yield := 50 + misState.Rand().IntN(100)
 	op := objBuilding.NewOilPlatform(*pos, radius, yield)
 	misState.Arena.OilPlatforms[op.Uid] = op
 	return fmt.Sprintf("Oil platform created at %s. Be careful, oil can breed mold!", pos.String())
@@ -0,0 +1,187 @@
+package instruction
+
+import (
+	"fmt"
+
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// ShipLaunchStrike 航母指定分组的战机空袭指定敌舰
+type ShipLaunchStrike struct {
+	shipUid   string
+	group     string
+	targetUid string
+	status    InstrStatus
+}
+
+// NewShipLaunchStrike ...
+func NewShipLaunchStrike(shipUid, group, targetUid string) *ShipLaunchStrike {
+	return &ShipLaunchStrike{shipUid: shipUid, group: group, targetUid: targetUid, status: Ready}
+}
+
+var _ Instruction = (*ShipLaunchStrike)(nil)
+
+// Exec ...
+func (i *ShipLaunchStrike) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.Aircraft.LaunchStrike(i.group, i.targetUid)
+	return nil
+}
+
+// Executed ...
+func (i *ShipLaunchStrike) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipLaunchStrike) Uid() string {
+	return GenInstrUid(NameShipLaunchStrike, i.shipUid)
+}
+
+// String ...
+func (i *ShipLaunchStrike) String() string {
+	return fmt.Sprintf("Ship %s launch %s strike on %s", i.shipUid, i.group, i.targetUid)
+}
+
+// ShipAirPatrol 航母战斗机在指定位置 / 友舰上空执行战斗空中巡逻
+type ShipAirPatrol struct {
+	shipUid  string
+	pos      objPos.MapPos
+	guardUid string
+	status   InstrStatus
+}
+
+// NewShipAirPatrol ...
+func NewShipAirPatrol(shipUid string, pos objPos.MapPos, guardUid string) *ShipAirPatrol {
+	return &ShipAirPatrol{shipUid: shipUid, pos: pos, guardUid: guardUid, status: Ready}
+}
+
+var _ Instruction = (*ShipAirPatrol)(nil)
+
+// Exec ...
+func (i *ShipAirPatrol) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.Aircraft.SetPatrol(objUnit.AirPatrol{Pos: i.pos, GuardUid: i.guardUid})
+	return nil
+}
+
+// Executed ...
+func (i *ShipAirPatrol) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipAirPatrol) Uid() string {
+	return GenInstrUid(NameShipAirPatrol, i.shipUid)
+}
+
+// String ...
+func (i *ShipAirPatrol) String() string {
+	if i.guardUid != "" {
+		return fmt.Sprintf("Ship %s air patrol over %s", i.shipUid, i.guardUid)
+	}
+	return fmt.Sprintf("Ship %s air patrol over %s", i.shipUid, i.pos.String())
+}
+
+// ShipHoldAircraft 航母舰载机留在甲板上 / 恢复自动出击
+type ShipHoldAircraft struct {
+	shipUid string
+	hold    bool
+	status  InstrStatus
+}
+
+// NewShipHoldAircraft ...
+func NewShipHoldAircraft(shipUid string, hold bool) *ShipHoldAircraft {
+	return &ShipHoldAircraft{shipUid: shipUid, hold: hold, status: Ready}
+}
+
+var _ Instruction = (*ShipHoldAircraft)(nil)
+
+// Exec ...
+func (i *ShipHoldAircraft) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.Aircraft.SetHold(i.hold)
+	return nil
+}
+
+// Executed ...
+func (i *ShipHoldAircraft) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipHoldAircraft) Uid() string {
+	return GenInstrUid(NameShipHoldAircraft, i.shipUid)
+}
+
+// String ...
+func (i *ShipHoldAircraft) String() string {
+	return fmt.Sprintf("Ship %s hold aircraft: %t", i.shipUid, i.hold)
+}
+
+// ShipRecallAircraft 召回航母的全部战机，并留在甲板上
+type ShipRecallAircraft struct {
+	shipUid string
+	status  InstrStatus
+}
+
+// NewShipRecallAircraft ...
+func NewShipRecallAircraft(shipUid string) *ShipRecallAircraft {
+	return &ShipRecallAircraft{shipUid: shipUid, status: Ready}
+}
+
+var _ Instruction = (*ShipRecallAircraft)(nil)
+
+// Exec ...
+func (i *ShipRecallAircraft) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.Aircraft.SetHold(true)
+	// 在空中的战机放弃当前任务，由任务管理器下达返航指令
+	for _, plane := range s.Arena.Planes {
+		if plane.BelongShip == ship.Uid {
+			plane.Recalled = true
+		}
+	}
+	return nil
+}
+
+// Executed ...
+func (i *ShipRecallAircraft) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipRecallAircraft) Uid() string {
+	return GenInstrUid(NameShipRecallAircraft, i.shipUid)
+}
+
+// String ...
+func (i *ShipRecallAircraft) String() string {
+	return fmt.Sprintf("Ship %s recall aircraft", i.shipUid)
+}
@@ -2,14 +2,14 @@ package instruction
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/pkg/errors"
 
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
 	"github.com/narasux/jutland/pkg/mission/state"
 )
 
-// ShipSummon 召唤增援（仅 AI 使用）
+// ShipSummon 召唤增援
 type ShipSummon struct {
 	reinforcePointUid string
 	shipName          string
@@ -34,7 +34,7 @@ func (i *ShipSummon) Exec(s *state.MissionState) error {
 	}
 	// 如果没指定，就随机来一个
 	if i.shipName == "" {
-		idx := rand.Intn(len(rp.ProvidedShipNames))
+		idx := s.Rand().IntN(len(rp.ProvidedShipNames))
 		i.shipName = rp.ProvidedShipNames[idx]
 	}
 	rp.Summon(i.shipName)
@@ -56,3 +56,82 @@ func (i *ShipSummon) Uid() string {
 func (i *ShipSummon) String() string {
 	return fmt.Sprintf("summon %s from reinforce point %s", i.shipName, i.reinforcePointUid)
 }
+
+// CancelSummon 取消最后一艘待增援的战舰
+type CancelSummon struct {
+	reinforcePointUid string
+	status            InstrStatus
+}
+
+// NewCancelSummon ...
+func NewCancelSummon(reinforcePointUid string) *CancelSummon {
+	return &CancelSummon{reinforcePointUid: reinforcePointUid, status: Ready}
+}
+
+var _ Instruction = (*CancelSummon)(nil)
+
+// Exec 执行取消增援指令
+func (i *CancelSummon) Exec(s *state.MissionState) error {
+	i.status = Executed
+	rp, ok := s.Arena.ReinforcePoints[i.reinforcePointUid]
+	if !ok {
+		return errors.Errorf("reinforce point %s not found", i.reinforcePointUid)
+	}
+	rp.CancelLastSummon()
+	return nil
+}
+
+// Executed 判断指令是否执行完成
+func (i *CancelSummon) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid 指令 uid
+func (i *CancelSummon) Uid() string {
+	return GenInstrUid(NameCancelSummon, i.reinforcePointUid)
+}
+
+// String 返回指令字符串表示
+func (i *CancelSummon) String() string {
+	return fmt.Sprintf("cancel last summon from reinforce point %s", i.reinforcePointUid)
+}
+
+// SetRallyPos 设置增援点集结点
+type SetRallyPos struct {
+	reinforcePointUid string
+	pos               objPos.MapPos
+	status            InstrStatus
+}
+
+// NewSetRallyPos ...
+func NewSetRallyPos(reinforcePointUid string, pos objPos.MapPos) *SetRallyPos {
+	return &SetRallyPos{reinforcePointUid: reinforcePointUid, pos: pos, status: Ready}
+}
+
+var _ Instruction = (*SetRallyPos)(nil)
+
+// Exec 执行设置集结点指令
+func (i *SetRallyPos) Exec(s *state.MissionState) error {
+	i.status = Executed
+	rp, ok := s.Arena.ReinforcePoints[i.reinforcePointUid]
+	if !ok {
+		return errors.Errorf("reinforce point %s not found", i.reinforcePointUid)
+	}
+	rp.SetRallyPos(i.pos)
+	return nil
+}
+
+// Executed 判断指令是否执行完成
+func (i *SetRallyPos) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid 指令 uid
+func (i *SetRallyPos) Uid() string {
+	return GenInstrUid(NameSetRallyPos, i.reinforcePointUid)
+}
+
+// String 返回指令字符串表示
+func (i *SetRallyPos) String() string {
+	return fmt.Sprintf("set reinforce point %s rally pos to %s", i.reinforcePointUid, i.pos.String())
+}
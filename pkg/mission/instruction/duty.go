@@ -0,0 +1,141 @@
+package instruction
+
+import (
+	"fmt"
+
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// ShipPatrol 在多个航点之间循环巡逻
+type ShipPatrol struct {
+	shipUid string
+	points  []objPos.MapPos
+	status  InstrStatus
+}
+
+// NewShipPatrol ...
+func NewShipPatrol(shipUid string, points []objPos.MapPos) *ShipPatrol {
+	return &ShipPatrol{shipUid: shipUid, points: points, status: Ready}
+}
+
+var _ Instruction = (*ShipPatrol)(nil)
+
+// Exec ...
+func (i *ShipPatrol) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok || len(i.points) < 2 {
+		return nil
+	}
+
+	ship.Patrol(i.points)
+	return nil
+}
+
+// Executed ...
+func (i *ShipPatrol) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipPatrol) Uid() string {
+	return GenInstrUid(NameShipPatrol, i.shipUid)
+}
+
+// String ...
+func (i *ShipPatrol) String() string {
+	return fmt.Sprintf("Ship %s patrol between %v", i.shipUid, i.points)
+}
+
+// ShipEscort 为友舰护航
+type ShipEscort struct {
+	shipUid   string
+	targetUid string
+	status    InstrStatus
+}
+
+// NewShipEscort ...
+func NewShipEscort(shipUid, targetUid string) *ShipEscort {
+	return &ShipEscort{shipUid: shipUid, targetUid: targetUid, status: Ready}
+}
+
+var _ Instruction = (*ShipEscort)(nil)
+
+// Exec ...
+func (i *ShipEscort) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过；不能为自己护航
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok || i.targetUid == i.shipUid {
+		return nil
+	}
+	// 只能为己方 / 友军战舰护航
+	target, ok := s.Arena.Ships[i.targetUid]
+	if !ok || !s.IsAlly(ship.BelongPlayer, target.BelongPlayer) {
+		return nil
+	}
+
+	ship.AssignDuty(objUnit.ShipDuty{Type: objUnit.DutyEscort, TargetUid: i.targetUid})
+	return nil
+}
+
+// Executed ...
+func (i *ShipEscort) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipEscort) Uid() string {
+	return GenInstrUid(NameShipEscort, i.shipUid)
+}
+
+// String ...
+func (i *ShipEscort) String() string {
+	return fmt.Sprintf("Ship %s escort %s", i.shipUid, i.targetUid)
+}
+
+// ShipGuard 警戒指定区域
+type ShipGuard struct {
+	shipUid string
+	anchor  objPos.MapPos
+	radius  float64
+	status  InstrStatus
+}
+
+// NewShipGuard ...
+func NewShipGuard(shipUid string, anchor objPos.MapPos, radius float64) *ShipGuard {
+	return &ShipGuard{shipUid: shipUid, anchor: anchor, radius: radius, status: Ready}
+}
+
+var _ Instruction = (*ShipGuard)(nil)
+
+// Exec ...
+func (i *ShipGuard) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok || i.radius <= 0 {
+		return nil
+	}
+
+	ship.AssignDuty(objUnit.ShipDuty{Type: objUnit.DutyGuard, Anchor: i.anchor, Radius: i.radius})
+	return nil
+}
+
+// Executed ...
+func (i *ShipGuard) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipGuard) Uid() string {
+	return GenInstrUid(NameShipGuard, i.shipUid)
+}
+
+// String ...
+func (i *ShipGuard) String() string {
+	return fmt.Sprintf("Ship %s guard %s (radius %.1f)", i.shipUid, i.anchor.String(), i.radius)
+}
@@ -0,0 +1,137 @@
+package instruction
+
+import (
+	"fmt"
+
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// ShipFireStance 设置交战规则
+type ShipFireStance struct {
+	shipUid string
+	stance  objUnit.FireStance
+	status  InstrStatus
+}
+
+// NewShipFireStance ...
+func NewShipFireStance(shipUid string, stance objUnit.FireStance) *ShipFireStance {
+	return &ShipFireStance{shipUid: shipUid, stance: stance, status: Ready}
+}
+
+var _ Instruction = (*ShipFireStance)(nil)
+
+// Exec ...
+func (i *ShipFireStance) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.SetFireStance(i.stance)
+	return nil
+}
+
+// Executed ...
+func (i *ShipFireStance) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipFireStance) Uid() string {
+	return GenInstrUid(NameShipFireStance, i.shipUid)
+}
+
+// String ...
+func (i *ShipFireStance) String() string {
+	return fmt.Sprintf("Ship %s fire stance %q", i.shipUid, string(i.stance))
+}
+
+// ShipTargetPolicy 设置武器目标策略
+type ShipTargetPolicy struct {
+	shipUid    string
+	weaponType objUnit.WeaponType
+	policy     objUnit.TargetPolicy
+	status     InstrStatus
+}
+
+// NewShipTargetPolicy ...
+func NewShipTargetPolicy(
+	shipUid string, weaponType objUnit.WeaponType, policy objUnit.TargetPolicy,
+) *ShipTargetPolicy {
+	return &ShipTargetPolicy{shipUid: shipUid, weaponType: weaponType, policy: policy, status: Ready}
+}
+
+var _ Instruction = (*ShipTargetPolicy)(nil)
+
+// Exec ...
+func (i *ShipTargetPolicy) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.SetTargetPolicy(i.weaponType, i.policy)
+	return nil
+}
+
+// Executed ...
+func (i *ShipTargetPolicy) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipTargetPolicy) Uid() string {
+	return GenInstrUid(NameShipTargetPolicy, i.shipUid)
+}
+
+// String ...
+func (i *ShipTargetPolicy) String() string {
+	return fmt.Sprintf("Ship %s weapon %s target policy %q", i.shipUid, string(i.weaponType), string(i.policy))
+}
+
+// ShipStandOff 设置与攻击目标保持的距离
+type ShipStandOff struct {
+	shipUid string
+	rate    float64
+	status  InstrStatus
+}
+
+// NewShipStandOff ...
+func NewShipStandOff(shipUid string, rate float64) *ShipStandOff {
+	return &ShipStandOff{shipUid: shipUid, rate: rate, status: Ready}
+}
+
+var _ Instruction = (*ShipStandOff)(nil)
+
+// Exec ...
+func (i *ShipStandOff) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.SetStandOffRate(i.rate)
+	return nil
+}
+
+// Executed ...
+func (i *ShipStandOff) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipStandOff) Uid() string {
+	return GenInstrUid(NameShipStandOff, i.shipUid)
+}
+
+// String ...
+func (i *ShipStandOff) String() string {
+	return fmt.Sprintf("Ship %s stand off at %.2f of range", i.shipUid, i.rate)
+}
@@ -0,0 +1,60 @@
+package instruction
+
+import (
+	"fmt"
+
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// ShipFormation 战舰加入 / 脱离编队（阵型为空表示脱离编队）
+type ShipFormation struct {
+	shipUid   string
+	formation objUnit.ShipFormation
+	// 编队航速（仅向导舰使用）
+	speedLimit float64
+	status     InstrStatus
+}
+
+// NewShipFormation ...
+func NewShipFormation(shipUid string, formation objUnit.ShipFormation, speedLimit float64) *ShipFormation {
+	return &ShipFormation{shipUid: shipUid, formation: formation, speedLimit: speedLimit, status: Ready}
+}
+
+// NewShipLeaveFormation ...
+func NewShipLeaveFormation(shipUid string) *ShipFormation {
+	return NewShipFormation(shipUid, objUnit.ShipFormation{}, 0)
+}
+
+var _ Instruction = (*ShipFormation)(nil)
+
+// Exec ...
+func (i *ShipFormation) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.JoinFormation(i.formation, i.speedLimit)
+	return nil
+}
+
+// Executed ...
+func (i *ShipFormation) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipFormation) Uid() string {
+	return GenInstrUid(NameShipFormation, i.shipUid)
+}
+
+// String ...
+func (i *ShipFormation) String() string {
+	if i.formation.Type == objUnit.FormationNone {
+		return fmt.Sprintf("Ship %s leave formation", i.shipUid)
+	}
+	return fmt.Sprintf("Ship %s join %s formation guided by %s", i.shipUid, i.formation.Type, i.formation.GuideUid)
+}
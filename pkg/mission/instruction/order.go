@@ -0,0 +1,182 @@
+package instruction
+
+import (
+	"fmt"
+
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// ShipEnqueueOrder 追加战舰排队命令（航点 / 攻击 / 巡逻）
+type ShipEnqueueOrder struct {
+	shipUid   string
+	orderType objUnit.OrderType
+	pos       objPos.MapPos
+	targetUid string
+	status    InstrStatus
+}
+
+// NewShipEnqueueOrder ...
+func NewShipEnqueueOrder(
+	shipUid string, orderType objUnit.OrderType, pos objPos.MapPos, targetUid string,
+) *ShipEnqueueOrder {
+	return &ShipEnqueueOrder{shipUid: shipUid, orderType: orderType, pos: pos, targetUid: targetUid, status: Ready}
+}
+
+var _ Instruction = (*ShipEnqueueOrder)(nil)
+
+// Exec ...
+func (i *ShipEnqueueOrder) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	// 排队命令取代值守任务
+	ship.ClearDuty()
+	ship.EnqueueOrder(objUnit.ShipOrder{Type: i.orderType, Pos: i.pos, TargetUid: i.targetUid})
+	return nil
+}
+
+// Executed ...
+func (i *ShipEnqueueOrder) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipEnqueueOrder) Uid() string {
+	return GenInstrUid(NameShipEnqueueOrder, i.shipUid)
+}
+
+// String ...
+func (i *ShipEnqueueOrder) String() string {
+	return fmt.Sprintf("Ship %s enqueue %s order to %s", i.shipUid, i.orderType, i.pos.String())
+}
+
+// ShipCancelOrder 撤销战舰最后一条排队命令
+type ShipCancelOrder struct {
+	shipUid string
+	status  InstrStatus
+}
+
+// NewShipCancelOrder ...
+func NewShipCancelOrder(shipUid string) *ShipCancelOrder {
+	return &ShipCancelOrder{shipUid: shipUid, status: Ready}
+}
+
+var _ Instruction = (*ShipCancelOrder)(nil)
+
+// Exec ...
+func (i *ShipCancelOrder) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.CancelLastOrder()
+	return nil
+}
+
+// Executed ...
+func (i *ShipCancelOrder) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipCancelOrder) Uid() string {
+	return GenInstrUid(NameShipCancelOrder, i.shipUid)
+}
+
+// String ...
+func (i *ShipCancelOrder) String() string {
+	return fmt.Sprintf("Ship %s cancel last order", i.shipUid)
+}
+
+// ShipClearOrders 清空战舰排队命令（含巡逻航点）& 值守任务
+type ShipClearOrders struct {
+	shipUid string
+	status  InstrStatus
+}
+
+// NewShipClearOrders ...
+func NewShipClearOrders(shipUid string) *ShipClearOrders {
+	return &ShipClearOrders{shipUid: shipUid, status: Ready}
+}
+
+var _ Instruction = (*ShipClearOrders)(nil)
+
+// Exec ...
+func (i *ShipClearOrders) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.ClearOrders()
+	ship.ClearDuty()
+	return nil
+}
+
+// Executed ...
+func (i *ShipClearOrders) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipClearOrders) Uid() string {
+	return GenInstrUid(NameShipClearOrders, i.shipUid)
+}
+
+// String ...
+func (i *ShipClearOrders) String() string {
+	return fmt.Sprintf("Ship %s clear orders", i.shipUid)
+}
+
+// ShipAttackMove 攻击移动前往指定位置（取代现有的排队命令）
+type ShipAttackMove struct {
+	shipUid string
+	pos     objPos.MapPos
+	status  InstrStatus
+}
+
+// NewShipAttackMove ...
+func NewShipAttackMove(shipUid string, pos objPos.MapPos) *ShipAttackMove {
+	return &ShipAttackMove{shipUid: shipUid, pos: pos, status: Ready}
+}
+
+var _ Instruction = (*ShipAttackMove)(nil)
+
+// Exec ...
+func (i *ShipAttackMove) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.AttackMove(i.pos)
+	return nil
+}
+
+// Executed ...
+func (i *ShipAttackMove) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipAttackMove) Uid() string {
+	return GenInstrUid(NameShipAttackMove, i.shipUid)
+}
+
+// String ...
+func (i *ShipAttackMove) String() string {
+	return fmt.Sprintf("Ship %s attack move to %s", i.shipUid, i.pos.String())
+}
@@ -245,3 +245,65 @@ func (i *PlaneReturn) Uid() string {
 func (i *PlaneReturn) String() string {
 	return fmt.Sprintf("Plane %s return", i.planeUid)
 }
+
+// PlanePatrol 战斗机在母舰指定的巡逻位置上空盘旋
+type PlanePatrol struct {
+	planeUid string
+	status   InstrStatus
+}
+
+// NewPlanePatrol ...
+func NewPlanePatrol(planeUid string) *PlanePatrol {
+	return &PlanePatrol{planeUid: planeUid, status: Ready}
+}
+
+var _ Instruction = (*PlanePatrol)(nil)
+
+// Exec 执行指令
+func (i *PlanePatrol) Exec(missionState *state.MissionState) error {
+	// 获取飞机
+	plane, ok := missionState.Arena.Planes[i.planeUid]
+	// 飞机已经不存在，判定已经完成
+	if !ok {
+		i.status = Executed
+		return nil
+	}
+	mapCfg := missionState.Core.MissionMD.MapCfg
+	if plane.FlightPhase == objUnit.PlaneFlightPhaseTakingOff {
+		plane.UpdateTakeoff(mapCfg)
+		return nil
+	}
+	// 不在巡航阶段或者必须返航，判定已经完成
+	if !plane.IsCruising() || plane.MustReturn() {
+		i.status = Executed
+		return nil
+	}
+	// 母舰不存在或者已经取消巡逻，判定已经完成
+	ship, ok := missionState.Arena.Ships[plane.BelongShip]
+	if !ok || ship.Aircraft.Patrol == nil {
+		i.status = Executed
+		return nil
+	}
+	center, ok := ship.Aircraft.Patrol.Center(missionState.Arena.Ships)
+	if !ok {
+		i.status = Executed
+		return nil
+	}
+	plane.Cruise(mapCfg, objUnit.AirPatrolOrbitPos(center, plane.CurPos))
+	return nil
+}
+
+// Executed 返回指令是否已经执行
+func (i *PlanePatrol) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid 返回指令唯一ID
+func (i *PlanePatrol) Uid() string {
+	return GenInstrUid(NamePlanePatrol, i.planeUid)
+}
+
+// String 返回指令的描述
+func (i *PlanePatrol) String() string {
+	return fmt.Sprintf("Plane %s patrol", i.planeUid)
+}
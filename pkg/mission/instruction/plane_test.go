@@ -58,6 +58,7 @@ func newLandingTestState(
 	for idx := range planeCount {
 		plane := objUnit.NewPlane(
 			planeName,
+			objUnit.PlaneUid(ship.Uid, idx+1),
 			objPos.NewR(50, 53+float64(idx)*0.2),
 			0,
 			ship.Uid,
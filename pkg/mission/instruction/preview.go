@@ -0,0 +1,101 @@
+package instruction
+
+import (
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+// OrderPreviewer 可以预览的战舰命令（战术暂停时绘制尚未生效的命令）
+type OrderPreviewer interface {
+	// PreviewOrder 命令所属战舰 & 命令内容，queued 表示追加到现有排队命令之后（否则取代现有命令）
+	PreviewOrder() (shipUid string, order objUnit.ShipOrder, queued bool)
+}
+
+var (
+	_ OrderPreviewer = (*ShipMove)(nil)
+	_ OrderPreviewer = (*ShipMovePath)(nil)
+	_ OrderPreviewer = (*ShipAttack)(nil)
+	_ OrderPreviewer = (*ShipEnqueueOrder)(nil)
+	_ OrderPreviewer = (*ShipAttackMove)(nil)
+)
+
+// SettingPreviewer 可以预览的战舰设置（战术暂停时叠加到战舰设置上，用于计算下一次切换 & 绘制预览）
+type SettingPreviewer interface {
+	// PreviewSetting 设置所属战舰 & 将设置应用到战舰设置上的方法
+	PreviewSetting() (shipUid string, apply func(*objUnit.ShipSettings))
+}
+
+var (
+	_ SettingPreviewer = (*EnableWeapon)(nil)
+	_ SettingPreviewer = (*DisableWeapon)(nil)
+	_ SettingPreviewer = (*ShipEngineOrder)(nil)
+	_ SettingPreviewer = (*ShipFireStance)(nil)
+	_ SettingPreviewer = (*ShipTargetPolicy)(nil)
+	_ SettingPreviewer = (*ShipStandOff)(nil)
+	_ SettingPreviewer = (*ShipHoldAircraft)(nil)
+	_ SettingPreviewer = (*ShipRecallAircraft)(nil)
+)
+
+// PreviewOrder ...
+func (i *ShipMove) PreviewOrder() (string, objUnit.ShipOrder, bool) {
+	return i.shipUid, objUnit.ShipOrder{Type: objUnit.OrderTypeMove, Pos: i.targetPos}, false
+}
+
+// PreviewOrder ...
+func (i *ShipMovePath) PreviewOrder() (string, objUnit.ShipOrder, bool) {
+	return i.shipUid, objUnit.ShipOrder{Type: objUnit.OrderTypeMove, Pos: i.targetPos}, false
+}
+
+// PreviewOrder 攻击目标的位置在绘制时按目标当前位置计算
+func (i *ShipAttack) PreviewOrder() (string, objUnit.ShipOrder, bool) {
+	return i.shipUid, objUnit.ShipOrder{Type: objUnit.OrderTypeAttack, TargetUid: i.targetUid}, false
+}
+
+// PreviewOrder ...
+func (i *ShipEnqueueOrder) PreviewOrder() (string, objUnit.ShipOrder, bool) {
+	return i.shipUid, objUnit.ShipOrder{Type: i.orderType, Pos: i.pos, TargetUid: i.targetUid}, true
+}
+
+// PreviewOrder ...
+func (i *ShipAttackMove) PreviewOrder() (string, objUnit.ShipOrder, bool) {
+	return i.shipUid, objUnit.ShipOrder{Type: objUnit.OrderTypeAttackMove, Pos: i.pos}, false
+}
+
+// PreviewSetting ...
+func (i *EnableWeapon) PreviewSetting() (string, func(*objUnit.ShipSettings)) {
+	return i.shipUid, func(ss *objUnit.ShipSettings) { ss.SetWeaponDisabled(i.weaponType, false) }
+}
+
+// PreviewSetting ...
+func (i *DisableWeapon) PreviewSetting() (string, func(*objUnit.ShipSettings)) {
+	return i.shipUid, func(ss *objUnit.ShipSettings) { ss.SetWeaponDisabled(i.weaponType, true) }
+}
+
+// PreviewSetting ...
+func (i *ShipEngineOrder) PreviewSetting() (string, func(*objUnit.ShipSettings)) {
+	return i.shipUid, func(ss *objUnit.ShipSettings) { ss.EngineOrder = i.order }
+}
+
+// PreviewSetting ...
+func (i *ShipFireStance) PreviewSetting() (string, func(*objUnit.ShipSettings)) {
+	return i.shipUid, func(ss *objUnit.ShipSettings) { ss.FireStance = i.stance }
+}
+
+// PreviewSetting ...
+func (i *ShipTargetPolicy) PreviewSetting() (string, func(*objUnit.ShipSettings)) {
+	return i.shipUid, func(ss *objUnit.ShipSettings) { ss.SetTargetPolicy(i.weaponType, i.policy) }
+}
+
+// PreviewSetting ...
+func (i *ShipStandOff) PreviewSetting() (string, func(*objUnit.ShipSettings)) {
+	return i.shipUid, func(ss *objUnit.ShipSettings) { ss.StandOffRate = i.rate }
+}
+
+// PreviewSetting ...
+func (i *ShipHoldAircraft) PreviewSetting() (string, func(*objUnit.ShipSettings)) {
+	return i.shipUid, func(ss *objUnit.ShipSettings) { ss.HoldOnDeck = i.hold }
+}
+
+// PreviewSetting 召回战机后留在甲板上
+func (i *ShipRecallAircraft) PreviewSetting() (string, func(*objUnit.ShipSettings)) {
+	return i.shipUid, func(ss *objUnit.ShipSettings) { ss.HoldOnDeck = true }
+}
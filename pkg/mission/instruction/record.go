@@ -0,0 +1,272 @@
+package instruction
+
+import (
+	"log"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/narasux/jutland/pkg/mission/object"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+// Record 指令记录，可序列化，用于录像等需要重建指令的场景
+// 注：只记录创建指令的参数，不记录执行进度（如寻路结果），重建后的指令从头开始执行
+type Record struct {
+	Name string `json:"n"`
+	// 指令作用对象（战舰 / 战机 / 增援点）
+	ObjUid string `json:"o"`
+	// 目标对象
+	TargetUid     string      `json:"t,omitempty"`
+	TargetObjType object.Type `json:"tt,omitempty"`
+	// 增援战舰名称
+	ShipName string `json:"s,omitempty"`
+	// 武器类型
+	WeaponType objUnit.WeaponType `json:"w,omitempty"`
+	// 起点 / 目标位置
+	CurPos    *objPos.MapPos `json:"c,omitempty"`
+	TargetPos *objPos.MapPos `json:"p,omitempty"`
+	// 创建指令时战舰的速度
+	Speed float64 `json:"v,omitempty"`
+	// 车钟
+	EngineOrder objUnit.EngineOrder `json:"eo,omitempty"`
+	// 排队命令类型
+	OrderType objUnit.OrderType `json:"ot,omitempty"`
+	// 编队阵型 & 编队位置
+	FormationType objUnit.FormationType  `json:"ft,omitempty"`
+	FormationSlot *objUnit.FormationSlot `json:"fs,omitempty"`
+	// 巡逻航点
+	Points []objPos.MapPos `json:"ps,omitempty"`
+	// 警戒半径
+	Radius float64 `json:"r,omitempty"`
+	// 交战规则 & 目标策略
+	FireStance   objUnit.FireStance   `json:"fst,omitempty"`
+	TargetPolicy objUnit.TargetPolicy `json:"tp,omitempty"`
+	// 保持距离比例
+	StandOffRate float64 `json:"so,omitempty"`
+	// 空袭出击的战机分组
+	PlaneGroup string `json:"pg,omitempty"`
+	// 舰载机是否留在甲板上
+	Hold bool `json:"h,omitempty"`
+}
+
+// Encode 将指令转换成记录
+func Encode(i Instruction) (Record, error) {
+	switch i := i.(type) {
+	case *EnableWeapon:
+		return Record{Name: NameEnableWeapon, ObjUid: i.shipUid, WeaponType: i.weaponType}, nil
+	case *DisableWeapon:
+		return Record{Name: NameDisableWeapon, ObjUid: i.shipUid, WeaponType: i.weaponType}, nil
+	case *ShipMove:
+		return Record{Name: NameShipMove, ObjUid: i.shipUid, TargetPos: &i.targetPos}, nil
+	case *ShipMovePath:
+		return Record{
+			Name:      NameShipMovePath,
+			ObjUid:    i.shipUid,
+			CurPos:    &i.curPos,
+			TargetPos: &i.targetPos,
+			Speed:     i.initSpeed,
+		}, nil
+	case *ShipEngineOrder:
+		return Record{Name: NameShipEngineOrder, ObjUid: i.shipUid, EngineOrder: i.order}, nil
+	case *ShipDamageControl:
+		return Record{Name: NameShipDamageControl, ObjUid: i.shipUid}, nil
+	case *ShipAttack:
+		return Record{Name: NameShipAttack, ObjUid: i.shipUid, TargetUid: i.targetUid}, nil
+	case *ShipEnqueueOrder:
+		return Record{
+			Name:      NameShipEnqueueOrder,
+			ObjUid:    i.shipUid,
+			OrderType: i.orderType,
+			TargetPos: &i.pos,
+			TargetUid: i.targetUid,
+		}, nil
+	case *ShipCancelOrder:
+		return Record{Name: NameShipCancelOrder, ObjUid: i.shipUid}, nil
+	case *ShipClearOrders:
+		return Record{Name: NameShipClearOrders, ObjUid: i.shipUid}, nil
+	case *ShipAttackMove:
+		return Record{Name: NameShipAttackMove, ObjUid: i.shipUid, TargetPos: &i.pos}, nil
+	case *ShipFormation:
+		return Record{
+			Name:          NameShipFormation,
+			ObjUid:        i.shipUid,
+			TargetUid:     i.formation.GuideUid,
+			FormationType: i.formation.Type,
+			FormationSlot: &i.formation.Slot,
+			Speed:         i.speedLimit,
+		}, nil
+	case *ShipPatrol:
+		return Record{Name: NameShipPatrol, ObjUid: i.shipUid, Points: i.points}, nil
+	case *ShipEscort:
+		return Record{Name: NameShipEscort, ObjUid: i.shipUid, TargetUid: i.targetUid}, nil
+	case *ShipGuard:
+		return Record{Name: NameShipGuard, ObjUid: i.shipUid, TargetPos: &i.anchor, Radius: i.radius}, nil
+	case *ShipFireStance:
+		return Record{Name: NameShipFireStance, ObjUid: i.shipUid, FireStance: i.stance}, nil
+	case *ShipTargetPolicy:
+		return Record{
+			Name:         NameShipTargetPolicy,
+			ObjUid:       i.shipUid,
+			WeaponType:   i.weaponType,
+			TargetPolicy: i.policy,
+		}, nil
+	case *ShipStandOff:
+		return Record{Name: NameShipStandOff, ObjUid: i.shipUid, StandOffRate: i.rate}, nil
+	case *ShipLaunchStrike:
+		return Record{
+			Name:       NameShipLaunchStrike,
+			ObjUid:     i.shipUid,
+			PlaneGroup: i.group,
+			TargetUid:  i.targetUid,
+		}, nil
+	case *ShipAirPatrol:
+		return Record{Name: NameShipAirPatrol, ObjUid: i.shipUid, TargetPos: &i.pos, TargetUid: i.guardUid}, nil
+	case *ShipHoldAircraft:
+		return Record{Name: NameShipHoldAircraft, ObjUid: i.shipUid, Hold: i.hold}, nil
+	case *ShipRecallAircraft:
+		return Record{Name: NameShipRecallAircraft, ObjUid: i.shipUid}, nil
+	case *ShipSummon:
+		return Record{Name: NameShipSummon, ObjUid: i.reinforcePointUid, ShipName: i.shipName}, nil
+	case *CancelSummon:
+		return Record{Name: NameCancelSummon, ObjUid: i.reinforcePointUid}, nil
+	case *SetRallyPos:
+		return Record{Name: NameSetRallyPos, ObjUid: i.reinforcePointUid, TargetPos: &i.pos}, nil
+	case *PlaneAttack:
+		return Record{
+			Name:          NamePlaneAttack,
+			ObjUid:        i.planeUid,
+			TargetUid:     i.targetUid,
+			TargetObjType: i.targetObjType,
+		}, nil
+	case *PlaneReturn:
+		return Record{Name: NamePlaneReturn, ObjUid: i.planeUid}, nil
+	case *PlanePatrol:
+		return Record{Name: NamePlanePatrol, ObjUid: i.planeUid}, nil
+	default:
+		return Record{}, errors.Errorf("unsupported instruction: %s", i.String())
+	}
+}
+
+// EncodeAll 按 Uid 顺序批量将指令转换成记录（不支持的指令会被跳过），保证序列化结果稳定
+func EncodeAll(instructions map[string]Instruction) []Record {
+	uids := lo.Keys(instructions)
+	slices.Sort(uids)
+
+	records := make([]Record, 0, len(uids))
+	for _, uid := range uids {
+		record, err := Encode(instructions[uid])
+		if err != nil {
+			log.Printf("[WARN] skip encoding instruction %s: %s", uid, err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Decode 根据记录重建指令
+func Decode(r Record) (Instruction, error) {
+	if r.ObjUid == "" {
+		return nil, errors.Errorf("instruction %s missing obj uid", r.Name)
+	}
+	switch r.Name {
+	case NameEnableWeapon:
+		return NewEnableWeapon(r.ObjUid, r.WeaponType), nil
+	case NameDisableWeapon:
+		return NewDisableWeapon(r.ObjUid, r.WeaponType), nil
+	case NameShipMove:
+		if r.TargetPos == nil {
+			return nil, errors.Errorf("instruction %s missing target pos", r.Name)
+		}
+		return NewShipMove(r.ObjUid, *r.TargetPos), nil
+	case NameShipMovePath:
+		if r.CurPos == nil || r.TargetPos == nil {
+			return nil, errors.Errorf("instruction %s missing cur / target pos", r.Name)
+		}
+		return NewShipMovePath(r.ObjUid, *r.CurPos, *r.TargetPos, r.Speed), nil
+	case NameShipEngineOrder:
+		return NewShipEngineOrder(r.ObjUid, r.EngineOrder), nil
+	case NameShipDamageControl:
+		return NewShipDamageControl(r.ObjUid), nil
+	case NameShipAttack:
+		return NewShipAttack(r.ObjUid, r.TargetUid), nil
+	case NameShipEnqueueOrder:
+		if r.TargetPos == nil {
+			return nil, errors.Errorf("instruction %s missing target pos", r.Name)
+		}
+		return NewShipEnqueueOrder(r.ObjUid, r.OrderType, *r.TargetPos, r.TargetUid), nil
+	case NameShipCancelOrder:
+		return NewShipCancelOrder(r.ObjUid), nil
+	case NameShipClearOrders:
+		return NewShipClearOrders(r.ObjUid), nil
+	case NameShipAttackMove:
+		if r.TargetPos == nil {
+			return nil, errors.Errorf("instruction %s missing target pos", r.Name)
+		}
+		return NewShipAttackMove(r.ObjUid, *r.TargetPos), nil
+	case NameShipFormation:
+		formation := objUnit.ShipFormation{Type: r.FormationType, GuideUid: r.TargetUid}
+		if r.FormationSlot != nil {
+			formation.Slot = *r.FormationSlot
+		}
+		return NewShipFormation(r.ObjUid, formation, r.Speed), nil
+	case NameShipPatrol:
+		if len(r.Points) < 2 {
+			return nil, errors.Errorf("instruction %s requires at least 2 points", r.Name)
+		}
+		return NewShipPatrol(r.ObjUid, r.Points), nil
+	case NameShipEscort:
+		if r.TargetUid == "" {
+			return nil, errors.Errorf("instruction %s missing target uid", r.Name)
+		}
+		return NewShipEscort(r.ObjUid, r.TargetUid), nil
+	case NameShipGuard:
+		if r.TargetPos == nil {
+			return nil, errors.Errorf("instruction %s missing target pos", r.Name)
+		}
+		return NewShipGuard(r.ObjUid, *r.TargetPos, r.Radius), nil
+	case NameShipFireStance:
+		return NewShipFireStance(r.ObjUid, r.FireStance), nil
+	case NameShipTargetPolicy:
+		if r.WeaponType == "" {
+			return nil, errors.Errorf("instruction %s missing weapon type", r.Name)
+		}
+		return NewShipTargetPolicy(r.ObjUid, r.WeaponType, r.TargetPolicy), nil
+	case NameShipStandOff:
+		return NewShipStandOff(r.ObjUid, r.StandOffRate), nil
+	case NameShipLaunchStrike:
+		if r.PlaneGroup == "" || r.TargetUid == "" {
+			return nil, errors.Errorf("instruction %s missing plane group / target uid", r.Name)
+		}
+		return NewShipLaunchStrike(r.ObjUid, r.PlaneGroup, r.TargetUid), nil
+	case NameShipAirPatrol:
+		if r.TargetPos == nil {
+			return nil, errors.Errorf("instruction %s missing target pos", r.Name)
+		}
+		return NewShipAirPatrol(r.ObjUid, *r.TargetPos, r.TargetUid), nil
+	case NameShipHoldAircraft:
+		return NewShipHoldAircraft(r.ObjUid, r.Hold), nil
+	case NameShipRecallAircraft:
+		return NewShipRecallAircraft(r.ObjUid), nil
+	case NameShipSummon:
+		return NewShipSummon(r.ObjUid, r.ShipName), nil
+	case NameCancelSummon:
+		return NewCancelSummon(r.ObjUid), nil
+	case NameSetRallyPos:
+		if r.TargetPos == nil {
+			return nil, errors.Errorf("instruction %s missing target pos", r.Name)
+		}
+		return NewSetRallyPos(r.ObjUid, *r.TargetPos), nil
+	case NamePlaneAttack:
+		return NewPlaneAttack(r.ObjUid, r.TargetObjType, r.TargetUid), nil
+	case NamePlaneReturn:
+		return NewPlaneReturn(r.ObjUid), nil
+	case NamePlanePatrol:
+		return NewPlanePatrol(r.ObjUid), nil
+	default:
+		return nil, errors.Errorf("unknown instruction: %s", r.Name)
+	}
+}
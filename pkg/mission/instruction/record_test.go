@@ -0,0 +1,105 @@
+package instruction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/object"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	instructions := []Instruction{
+		NewEnableWeapon("ship-1", objUnit.WeaponTypeMainGun),
+		NewDisableWeapon("ship-1", objUnit.WeaponTypeTorpedo),
+		NewShipMove("ship-1", objPos.NewR(10.5, 20.25)),
+		NewShipMovePath("ship-2", objPos.New(1, 2), objPos.NewR(30.75, 40.5), 0.3),
+		NewShipAttack("ship-2", "ship-9"),
+		NewShipEngineOrder("ship-1", objUnit.EngineOrderAstern),
+		NewShipEngineOrder("ship-2", objUnit.EngineOrderFlank),
+		NewShipDamageControl("ship-1"),
+		NewShipEnqueueOrder("ship-1", objUnit.OrderTypeMove, objPos.NewR(12.5, 8), ""),
+		NewShipEnqueueOrder("ship-2", objUnit.OrderTypeAttack, objPos.New(3, 4), "ship-9"),
+		NewShipCancelOrder("ship-1"),
+		NewShipClearOrders("ship-2"),
+		NewShipAttackMove("ship-1", objPos.NewR(40.5, 12)),
+		NewShipEnqueueOrder("ship-2", objUnit.OrderTypeAttackMove, objPos.New(6, 7), ""),
+		NewShipFormation("ship-1", objUnit.ShipFormation{Type: objUnit.FormationLineAhead, GuideUid: "ship-1"}, 0.3),
+		NewShipFormation(
+			"ship-2",
+			objUnit.ShipFormation{
+				Type:     objUnit.FormationWedge,
+				GuideUid: "ship-1",
+				Slot:     objUnit.FormationSlot{Forward: -2.5, Lateral: 2.5},
+			},
+			0,
+		),
+		NewShipLeaveFormation("ship-2"),
+		NewShipPatrol("ship-1", []objPos.MapPos{objPos.New(5, 5), objPos.NewR(20.5, 5), objPos.New(20, 20)}),
+		NewShipEscort("ship-2", "ship-3"),
+		NewShipGuard("ship-1", objPos.NewR(15.5, 16), 12),
+		NewShipFireStance("ship-1", objUnit.FireStanceHoldFire),
+		NewShipFireStance("ship-2", objUnit.FireStanceFireAtWill),
+		NewShipTargetPolicy("ship-1", objUnit.WeaponTypeMainGun, objUnit.TargetPolicyWeakest),
+		NewShipTargetPolicy("ship-2", objUnit.WeaponTypeAll, objUnit.TargetPolicyDefault),
+		NewShipStandOff("ship-1", 0.7),
+		NewShipStandOff("ship-2", 0),
+		NewShipLaunchStrike("ship-1", "SBD-3", "ship-9"),
+		NewShipAirPatrol("ship-1", objPos.NewR(12.5, 30), ""),
+		NewShipAirPatrol("ship-2", objPos.New(3, 4), "ship-3"),
+		NewShipHoldAircraft("ship-1", true),
+		NewShipHoldAircraft("ship-2", false),
+		NewShipRecallAircraft("ship-1"),
+		NewShipSummon("HumanAlpha/rp-0", "Yamato"),
+		NewShipSummon("HumanAlpha/rp-0", ""),
+		NewCancelSummon("HumanAlpha/rp-1"),
+		NewSetRallyPos("HumanAlpha/rp-1", objPos.New(7, 8)),
+		NewPlaneAttack("ship-2/plane-1", object.TypeShip, "ship-9"),
+		NewPlaneReturn("ship-2/plane-1"),
+		NewPlanePatrol("ship-2/plane-2"),
+	}
+
+	for _, i := range instructions {
+		record, err := Encode(i)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(record)
+		require.NoError(t, err)
+		var decodedRecord Record
+		require.NoError(t, json.Unmarshal(data, &decodedRecord))
+
+		decoded, err := Decode(decodedRecord)
+		require.NoError(t, err)
+		require.Equal(t, i, decoded)
+		require.Equal(t, i.Uid(), decoded.Uid())
+	}
+}
+
+func TestDecodeRejectsInvalidRecord(t *testing.T) {
+	_, err := Decode(Record{Name: "Unknown", ObjUid: "ship-1"})
+	require.Error(t, err)
+
+	_, err = Decode(Record{Name: NameShipMove})
+	require.Error(t, err)
+
+	_, err = Decode(Record{Name: NameShipMove, ObjUid: "ship-1"})
+	require.Error(t, err)
+
+	_, err = Decode(Record{Name: NameShipPatrol, ObjUid: "ship-1", Points: []objPos.MapPos{objPos.New(1, 1)}})
+	require.Error(t, err)
+
+	_, err = Decode(Record{Name: NameShipAttackMove, ObjUid: "ship-1"})
+	require.Error(t, err)
+
+	_, err = Decode(Record{Name: NameShipTargetPolicy, ObjUid: "ship-1", TargetPolicy: objUnit.TargetPolicyNearest})
+	require.Error(t, err)
+
+	_, err = Decode(Record{Name: NameShipLaunchStrike, ObjUid: "ship-1", TargetUid: "ship-9"})
+	require.Error(t, err)
+
+	_, err = Decode(Record{Name: NameShipAirPatrol, ObjUid: "ship-1", TargetUid: "ship-3"})
+	require.Error(t, err)
+}
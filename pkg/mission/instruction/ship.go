@@ -137,6 +137,48 @@ func (i *ShipMove) String() string {
 	return fmt.Sprintf("Ship %s move to %s", i.shipUid, i.targetPos.String())
 }
 
+// ShipAttack 指定攻击目标
+type ShipAttack struct {
+	shipUid   string
+	targetUid string
+	status    InstrStatus
+}
+
+// NewShipAttack ...
+func NewShipAttack(shipUid, targetUid string) *ShipAttack {
+	return &ShipAttack{shipUid: shipUid, targetUid: targetUid, status: Ready}
+}
+
+var _ Instruction = (*ShipAttack)(nil)
+
+// Exec ...
+func (i *ShipAttack) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.Attack(i.targetUid)
+	return nil
+}
+
+// Executed ...
+func (i *ShipAttack) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipAttack) Uid() string {
+	return GenInstrUid(NameShipAttack, i.shipUid)
+}
+
+// String ...
+func (i *ShipAttack) String() string {
+	return fmt.Sprintf("Ship %s attack %s", i.shipUid, i.targetUid)
+}
+
 // ShipMovePath 按照指定路径移动
 type ShipMovePath struct {
 	shipUid   string
@@ -147,8 +189,19 @@ type ShipMovePath struct {
 	status    InstrStatus
 	// 创建指令时战舰的当前速度，用于路径就绪后恢复速度
 	initSpeed float64
+	// 异步寻路完成信号 & 已等待的帧数
+	pathDone       chan struct{}
+	pathFound      bool
+	preparingTicks int
 }
 
+// 异步寻路结果在固定帧数后才被采纳（未完成则阻塞等待），
+// 使战舰开始沿路径航行的时机与寻路耗时无关，保证同一种子 / 录像回放可复现
+const pathReadyTicks = 10
+
+// 路径就绪后的首帧处理完成后 initSpeed 置为该值（战舰速度远小于 1，倒车速度也不会与之混淆）
+const initSpeedHandled = -1
+
 // NewShipMovePath ...
 func NewShipMovePath(shipUid string, curPos, targetPos objPos.MapPos, curSpeed float64) *ShipMovePath {
 	return &ShipMovePath{shipUid: shipUid, curPos: curPos, targetPos: targetPos, status: Pending, initSpeed: curSpeed}
@@ -158,28 +211,37 @@ var _ Instruction = (*ShipMovePath)(nil)
 
 // Exec ...
 func (i *ShipMovePath) Exec(s *state.MissionState) error {
-	// 寻路失败（genPath 异步标记为 Executing），主线程中重置速度后标记完成
-	if i.status == Executing {
-		if ship, ok := s.Arena.Ships[i.shipUid]; ok {
-			ship.CurSpeed = 0
-		}
-		i.status = Executed
-		return nil
-	}
 	if i.status == Executed {
 		return nil
 	}
 
-	if i.status != Ready {
-		if i.status != Preparing {
-			i.status = Preparing
-			go i.genPath(s)
+	if i.status == Pending {
+		i.status = Preparing
+		i.pathDone = make(chan struct{})
+		go func() {
+			defer close(i.pathDone)
+			i.pathFound = i.genPath(s)
+		}()
+	}
+	if i.status == Preparing {
+		i.preparingTicks++
+		// Preparing 状态下，让战舰继续朝目标方向直线移动（或倒车）作为过渡
+		if i.preparingTicks < pathReadyTicks {
+			if ship, ok := s.Arena.Ships[i.shipUid]; ok && ship.CurSpeed != 0 {
+				ship.MoveTo(s.Core.MissionMD.MapCfg, i.targetPos, false)
+			}
+			return nil
 		}
-		// Preparing 状态下，让战舰继续朝目标方向直线移动作为过渡
-		if ship, ok := s.Arena.Ships[i.shipUid]; ok && ship.CurSpeed > 0 {
-			ship.MoveTo(s.Core.MissionMD.MapCfg, i.targetPos, false)
+		<-i.pathDone
+		// 寻路失败，重置速度后标记完成
+		if !i.pathFound {
+			if ship, ok := s.Arena.Ships[i.shipUid]; ok {
+				ship.CurSpeed = 0
+			}
+			i.status = Executed
+			return nil
 		}
-		return nil
+		i.status = Ready
 	}
 
 	if i.curIdx >= len(i.path) {
@@ -197,14 +259,14 @@ func (i *ShipMovePath) Exec(s *state.MissionState) error {
 		return nil
 	}
 
-	// 路径就绪后的首帧处理（initSpeed >= 0 表示尚未处理过）
-	if i.initSpeed >= 0 {
+	// 路径就绪后的首帧处理（initSpeed 不为 initSpeedHandled 表示尚未处理过）
+	if i.initSpeed != initSpeedHandled {
 		// 恢复创建指令时的速度，确保不因路径切换而归零
 		if i.initSpeed > 0 {
 			ship.CurSpeed = min(i.initSpeed, ship.MaxSpeed)
 		}
 		// 标记首帧处理已完成，避免后续帧重复执行
-		i.initSpeed = -1
+		i.initSpeed = initSpeedHandled
 		// 找到路径中离战舰当前位置最近的有效路径点，跳过已经过的点
 		// 避免战舰在过渡移动后"回退"到已经过的路径点
 		minDist := ship.CurPos.Distance(i.path[0])
@@ -232,17 +294,15 @@ func (i *ShipMovePath) Exec(s *state.MissionState) error {
 	return nil
 }
 
-// genPath 生成战舰移动的路径
-func (i *ShipMovePath) genPath(misState *state.MissionState) {
+// genPath 生成战舰移动的路径，返回是否寻路成功
+// 注：在独立协程中执行，只能写入路径，指令状态由 Exec 在主线程中更新
+func (i *ShipMovePath) genPath(misState *state.MissionState) bool {
 	points := misState.Core.MissionMD.MapCfg.GenPath(
 		grid.Point{i.curPos.MX, i.curPos.MY},
 		grid.Point{i.targetPos.MX, i.targetPos.MY},
 	)
-	// 寻路失败，标记为 Executing 让主线程的 Exec 处理速度重置
-	// 不能直接标记 Executed，否则会被 RemoveExecuted 在 Exec 之前清除，导致速度无法重置
 	if len(points) < 2 {
-		i.status = Executing
-		return
+		return false
 	}
 	// 寻路期间战舰会继续向新目标转向、移动；命令下达时的位置已经在身后，
 	// 不能再作为航点，否则大地图寻路较慢时战舰会折返并在近距离原地掉头。
@@ -251,8 +311,7 @@ func (i *ShipMovePath) genPath(misState *state.MissionState) {
 		i.path = append(i.path, objPos.New(p.X, p.Y))
 	}
 	i.path = append(i.path, i.targetPos)
-
-	i.status = Ready
+	return true
 }
 
 // Executed ...
@@ -269,3 +328,86 @@ func (i *ShipMovePath) Uid() string {
 func (i *ShipMovePath) String() string {
 	return fmt.Sprintf("Ship %s move with path %v", i.shipUid, i.path)
 }
+
+// ShipEngineOrder 设置车钟（航速命令）
+type ShipEngineOrder struct {
+	shipUid string
+	order   objWeapon.EngineOrder
+	status  InstrStatus
+}
+
+// NewShipEngineOrder ...
+func NewShipEngineOrder(shipUid string, order objWeapon.EngineOrder) *ShipEngineOrder {
+	return &ShipEngineOrder{shipUid: shipUid, order: order, status: Ready}
+}
+
+var _ Instruction = (*ShipEngineOrder)(nil)
+
+// Exec ...
+func (i *ShipEngineOrder) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.SetEngineOrder(i.order)
+	return nil
+}
+
+// Executed ...
+func (i *ShipEngineOrder) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipEngineOrder) Uid() string {
+	return GenInstrUid(NameShipEngineOrder, i.shipUid)
+}
+
+// String ...
+func (i *ShipEngineOrder) String() string {
+	return fmt.Sprintf("Ship %s engine order %q", i.shipUid, string(i.order))
+}
+
+// ShipDamageControl 损管：扑灭战舰全部火灾 & 堵住全部进水（冷却中则无效）
+type ShipDamageControl struct {
+	shipUid string
+	status  InstrStatus
+}
+
+// NewShipDamageControl ...
+func NewShipDamageControl(shipUid string) *ShipDamageControl {
+	return &ShipDamageControl{shipUid: shipUid, status: Ready}
+}
+
+var _ Instruction = (*ShipDamageControl)(nil)
+
+// Exec ...
+func (i *ShipDamageControl) Exec(s *state.MissionState) error {
+	i.status = Executed
+	// 战舰如果不存在（被摧毁），直跳过
+	ship, ok := s.Arena.Ships[i.shipUid]
+	if !ok {
+		return nil
+	}
+
+	ship.DamageControl(s.Core.Clock.Now())
+	return nil
+}
+
+// Executed ...
+func (i *ShipDamageControl) Executed() bool {
+	return i.status == Executed
+}
+
+// Uid ...
+func (i *ShipDamageControl) Uid() string {
+	return GenInstrUid(NameShipDamageControl, i.shipUid)
+}
+
+// String ...
+func (i *ShipDamageControl) String() string {
+	return fmt.Sprintf("Ship %s damage control", i.shipUid)
+}
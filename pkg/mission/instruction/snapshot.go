@@ -0,0 +1,179 @@
+package instruction
+
+import (
+	"github.com/pkg/errors"
+
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+// Snapshot 指令快照：创建参数 + 执行进度，用于任务存档
+// 注：与 Record 不同，快照恢复后的指令从存档时的进度继续执行（如已经算好的寻路路径）
+type Snapshot struct {
+	Record
+	Status InstrStatus
+	// ShipMovePath 执行进度
+	Path           []objPos.MapPos
+	CurIdx         int
+	PathFound      bool
+	PreparingTicks int
+	// PlaneAttack 释放器快照
+	ReleaserSnapshot []bool
+	SnapshotTaken    bool
+}
+
+// TakeSnapshot 获取指令快照
+// 注：异步寻路中的 ShipMovePath 会等待寻路完成，需要在主线程（帧间）调用
+func TakeSnapshot(i Instruction) (Snapshot, error) {
+	record, err := Encode(i)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snap := Snapshot{Record: record}
+
+	switch i := i.(type) {
+	case *EnableWeapon:
+		snap.Status = i.status
+	case *DisableWeapon:
+		snap.Status = i.status
+	case *ShipMove:
+		snap.Status = i.status
+	case *ShipMovePath:
+		if i.status == Preparing {
+			<-i.pathDone
+		}
+		snap.Status = i.status
+		snap.Path = i.path
+		snap.CurIdx = i.curIdx
+		snap.PathFound = i.pathFound
+		snap.PreparingTicks = i.preparingTicks
+	case *ShipEngineOrder:
+		snap.Status = i.status
+	case *ShipAttack:
+		snap.Status = i.status
+	case *ShipEnqueueOrder:
+		snap.Status = i.status
+	case *ShipCancelOrder:
+		snap.Status = i.status
+	case *ShipClearOrders:
+		snap.Status = i.status
+	case *ShipFormation:
+		snap.Status = i.status
+	case *ShipPatrol:
+		snap.Status = i.status
+	case *ShipEscort:
+		snap.Status = i.status
+	case *ShipGuard:
+		snap.Status = i.status
+	case *ShipFireStance:
+		snap.Status = i.status
+	case *ShipTargetPolicy:
+		snap.Status = i.status
+	case *ShipStandOff:
+		snap.Status = i.status
+	case *ShipAttackMove:
+		snap.Status = i.status
+	case *ShipLaunchStrike:
+		snap.Status = i.status
+	case *ShipAirPatrol:
+		snap.Status = i.status
+	case *ShipHoldAircraft:
+		snap.Status = i.status
+	case *ShipRecallAircraft:
+		snap.Status = i.status
+	case *ShipSummon:
+		snap.Status = i.status
+	case *CancelSummon:
+		snap.Status = i.status
+	case *SetRallyPos:
+		snap.Status = i.status
+	case *PlaneAttack:
+		snap.Status = i.status
+		snap.ReleaserSnapshot = i.releaserSnapshot
+		snap.SnapshotTaken = i.snapshotTaken
+	case *PlaneReturn:
+		snap.Status = i.status
+	case *PlanePatrol:
+		snap.Status = i.status
+	}
+	return snap, nil
+}
+
+// RestoreSnapshot 根据快照重建指令（包含执行进度）
+func RestoreSnapshot(snap Snapshot) (Instruction, error) {
+	i, err := Decode(snap.Record)
+	if err != nil {
+		return nil, err
+	}
+
+	switch i := i.(type) {
+	case *EnableWeapon:
+		i.status = snap.Status
+	case *DisableWeapon:
+		i.status = snap.Status
+	case *ShipMove:
+		i.status = snap.Status
+	case *ShipMovePath:
+		i.status = snap.Status
+		i.path = snap.Path
+		i.curIdx = snap.CurIdx
+		i.pathFound = snap.PathFound
+		i.preparingTicks = snap.PreparingTicks
+		if i.status == Preparing {
+			// 存档时寻路已经完成，恢复为已完成的信号，到达采纳帧后直接使用路径
+			i.pathDone = make(chan struct{})
+			close(i.pathDone)
+		}
+		if i.status == Ready && len(i.path) == 0 {
+			return nil, errors.Errorf("instruction %s missing path", i.Uid())
+		}
+	case *ShipEngineOrder:
+		i.status = snap.Status
+	case *ShipAttack:
+		i.status = snap.Status
+	case *ShipEnqueueOrder:
+		i.status = snap.Status
+	case *ShipCancelOrder:
+		i.status = snap.Status
+	case *ShipClearOrders:
+		i.status = snap.Status
+	case *ShipFormation:
+		i.status = snap.Status
+	case *ShipPatrol:
+		i.status = snap.Status
+	case *ShipEscort:
+		i.status = snap.Status
+	case *ShipGuard:
+		i.status = snap.Status
+	case *ShipFireStance:
+		i.status = snap.Status
+	case *ShipTargetPolicy:
+		i.status = snap.Status
+	case *ShipStandOff:
+		i.status = snap.Status
+	case *ShipAttackMove:
+		i.status = snap.Status
+	case *ShipLaunchStrike:
+		i.status = snap.Status
+	case *ShipAirPatrol:
+		i.status = snap.Status
+	case *ShipHoldAircraft:
+		i.status = snap.Status
+	case *ShipRecallAircraft:
+		i.status = snap.Status
+	case *ShipSummon:
+		i.status = snap.Status
+	case *CancelSummon:
+		i.status = snap.Status
+	case *SetRallyPos:
+		i.status = snap.Status
+	case *PlaneAttack:
+		i.status = snap.Status
+		i.releaserSnapshot = snap.ReleaserSnapshot
+		i.snapshotTaken = snap.SnapshotTaken
+	case *PlaneReturn:
+		i.status = snap.Status
+	case *PlanePatrol:
+		i.status = snap.Status
+	}
+	return i, nil
+}
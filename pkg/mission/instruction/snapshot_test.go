@@ -0,0 +1,84 @@
+package instruction
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	"github.com/narasux/jutland/pkg/mission/object"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/resources/mapcfg"
+	"github.com/narasux/jutland/pkg/utils/grid"
+)
+
+func gobRoundTrip(t *testing.T, snap Snapshot) Snapshot {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(snap))
+	var decoded Snapshot
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	return decoded
+}
+
+func TestSnapshotKeepsShipMovePathProgress(t *testing.T) {
+	cells := make(grid.Cells, 20)
+	for y := range cells {
+		cells[y] = make([]int, 40)
+	}
+	ship := &objUnit.BattleShip{Uid: "ship", CurPos: objPos.NewR(10, 10), MaxSpeed: 0.1}
+	misState := &state.MissionState{
+		Core: state.MissionCoreState{
+			MissionMD: metadata.MissionMetadata{MapCfg: &mapcfg.MapCfg{Cells: cells, Width: 40, Height: 20}},
+		},
+		Arena: state.MissionArenaState{
+			Ships: map[string]*objUnit.BattleShip{ship.Uid: ship},
+		},
+	}
+
+	move := NewShipMovePath(ship.Uid, objPos.New(10, 10), objPos.New(30, 10), 0.1)
+	// 存档发生在异步寻路期间
+	require.NoError(t, move.Exec(misState))
+	snap, err := TakeSnapshot(move)
+	require.NoError(t, err)
+	require.Equal(t, Preparing, snap.Status)
+	require.True(t, snap.PathFound)
+	require.Equal(t, 1, snap.PreparingTicks)
+
+	restored, err := RestoreSnapshot(gobRoundTrip(t, snap))
+	require.NoError(t, err)
+	restoredMove := restored.(*ShipMovePath)
+	require.Equal(t, move.path, restoredMove.path)
+
+	// 原指令与恢复的指令在各自的战舰副本上执行，结果一致
+	restoredShip := *ship
+	restoredState := *misState
+	restoredState.Arena.Ships = map[string]*objUnit.BattleShip{ship.Uid: &restoredShip}
+	for range pathReadyTicks + 5 {
+		require.NoError(t, move.Exec(misState))
+		require.NoError(t, restoredMove.Exec(&restoredState))
+	}
+	require.Equal(t, move.status, restoredMove.status)
+	require.Equal(t, move.curIdx, restoredMove.curIdx)
+	require.Equal(t, ship.CurPos, restoredShip.CurPos)
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	attack := NewPlaneAttack("ship-2/plane-1", object.TypeShip, "ship-9")
+	attack.snapshotTaken = true
+	attack.releaserSnapshot = []bool{true, false}
+	weapon := NewEnableWeapon("ship-1", objUnit.WeaponTypeMainGun)
+	weapon.status = Executed
+
+	for _, i := range []Instruction{attack, weapon, NewPlaneReturn("ship-2/plane-1")} {
+		snap, err := TakeSnapshot(i)
+		require.NoError(t, err)
+		restored, err := RestoreSnapshot(gobRoundTrip(t, snap))
+		require.NoError(t, err)
+		require.Equal(t, i, restored)
+	}
+}
@@ -6,13 +6,34 @@ package instruction
 import "github.com/narasux/jutland/pkg/mission/state"
 
 const (
-	NameEnableWeapon  = "EnableWeapon"
-	NameDisableWeapon = "DisableWeapon"
-	NameShipMove      = "ShipMove"
-	NameShipMovePath  = "ShipMovePath"
-	NameShipSummon    = "ShipSummon"
-	NamePlaneAttack   = "PlaneAttack"
-	NamePlaneReturn   = "PlaneReturn"
+	NameEnableWeapon       = "EnableWeapon"
+	NameDisableWeapon      = "DisableWeapon"
+	NameShipMove           = "ShipMove"
+	NameShipMovePath       = "ShipMovePath"
+	NameShipEngineOrder    = "ShipEngineOrder"
+	NameShipDamageControl  = "ShipDamageControl"
+	NameShipAttack         = "ShipAttack"
+	NameShipEnqueueOrder   = "ShipEnqueueOrder"
+	NameShipCancelOrder    = "ShipCancelOrder"
+	NameShipClearOrders    = "ShipClearOrders"
+	NameShipAttackMove     = "ShipAttackMove"
+	NameShipFormation      = "ShipFormation"
+	NameShipPatrol         = "ShipPatrol"
+	NameShipEscort         = "ShipEscort"
+	NameShipGuard          = "ShipGuard"
+	NameShipFireStance     = "ShipFireStance"
+	NameShipTargetPolicy   = "ShipTargetPolicy"
+	NameShipStandOff       = "ShipStandOff"
+	NameShipLaunchStrike   = "ShipLaunchStrike"
+	NameShipAirPatrol      = "ShipAirPatrol"
+	NameShipHoldAircraft   = "ShipHoldAircraft"
+	NameShipRecallAircraft = "ShipRecallAircraft"
+	NameShipSummon         = "ShipSummon"
+	NameCancelSummon       = "CancelSummon"
+	NameSetRallyPos        = "SetRallyPos"
+	NamePlaneAttack        = "PlaneAttack"
+	NamePlaneReturn        = "PlaneReturn"
+	NamePlanePatrol        = "PlanePatrol"
 )
 
 // InstrStatus 指令状态
@@ -3,69 +3,74 @@ package manager
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"strconv"
 
 	"github.com/samber/lo"
 
 	"github.com/narasux/jutland/pkg/common/constants"
+	"github.com/narasux/jutland/pkg/i18n"
 	instr "github.com/narasux/jutland/pkg/mission/instruction"
 	"github.com/narasux/jutland/pkg/mission/object"
 	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
 	objExplosion "github.com/narasux/jutland/pkg/mission/object/explosion"
 	objMark "github.com/narasux/jutland/pkg/mission/object/mark"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
 	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 	"github.com/narasux/jutland/pkg/utils/colorx"
 	"github.com/narasux/jutland/pkg/utils/geometry"
 )
 
-// 更新战舰武器开火相关状态
-// TODO 开火逻辑优化：主炮/鱼雷向射程内最大的，生命值比例最少目标开火，副炮向最近的目标开火
+// 更新战舰武器开火相关状态：按交战规则筛选射程内的敌人，各类武器再按目标策略选择目标
 func (m *MissionManager) updateShipWeaponFire() {
 	maxBulletDiameter := 0
 	isTorpedoLaunched := false
 	isRocketLaunched := false
+	now, rng := m.state.Core.Clock.Now(), m.state.Rand()
+	// 按固定顺序遍历，保证同一种子下选择的目标可复现
+	ships, planes := m.state.SortedShips(), m.state.SortedPlanes()
 
-	for _, ship := range m.state.Arena.Ships {
+	for _, ship := range ships {
 		inRangeEnemies := []objUnit.Hurtable{}
 
-		target := m.state.Arena.Ships[ship.AttackTarget]
-		// 若有指定攻击目标且在射程内，则优先攻击该目标；否则扫描沿途其他敌人
-		if target != nil && ship.CurPos.Distance(target.CurPos) < ship.Weapon.MaxToShipRange {
+		// 若有指定攻击目标（非友军，可以是中立势力）且在视野 & 射程内，则优先攻击该目标；交战规则不限制指定目标
+		var priority objUnit.Hurtable
+		if target := m.state.Arena.Ships[ship.AttackTarget]; target != nil &&
+			!m.state.IsAlly(ship.BelongPlayer, target.BelongPlayer) &&
+			m.state.CanSee(ship.BelongPlayer, target) &&
+			ship.CurPos.Distance(target.CurPos) < ship.Weapon.MaxToShipRange {
+			priority = target
 			inRangeEnemies = append(inRangeEnemies, target)
-		} else {
-			// 敌机
-			for _, enemy := range m.state.Arena.Planes {
-				// 不能攻击己方的战机
-				if ship.BelongPlayer == enemy.BelongPlayer {
-					continue
-				}
-				// 如果不在 对空 最大射程内，跳过
-				if ship.CurPos.Distance(enemy.CurPos) > ship.Weapon.MaxToPlaneRange {
-					continue
-				}
-				inRangeEnemies = append(inRangeEnemies, enemy)
+		}
+		// 敌机
+		for _, enemy := range planes {
+			// 只会主动攻击交战规则允许的敌对势力战机
+			if !m.state.IsEnemy(ship.BelongPlayer, enemy.BelongPlayer) || !m.mayEngage(ship, enemy.Uid, now) {
+				continue
 			}
-
-			// 敌舰
-			for enemyUid, enemy := range m.state.Arena.Ships {
-				// 不能主动炮击己方的战舰（包括自己），目标敌人的也可以跳过（前面已处理）
-				if ship.BelongPlayer == enemy.BelongPlayer ||
-					enemyUid == ship.AttackTarget {
-					continue
-				}
-				// 如果不在 对舰 最大射程内，跳过
-				if ship.CurPos.Distance(enemy.CurPos) > ship.Weapon.MaxToShipRange {
-					continue
-				}
-				inRangeEnemies = append(inRangeEnemies, enemy)
+			// 如果不在 对空 最大射程内，或不在视野内，跳过
+			if ship.CurPos.Distance(enemy.CurPos) > ship.Weapon.MaxToPlaneRange ||
+				!m.state.CanSee(ship.BelongPlayer, enemy) {
+				continue
 			}
+			inRangeEnemies = append(inRangeEnemies, enemy)
+		}
+		// 敌舰
+		for _, enemy := range ships {
+			// 不能主动炮击己方 / 友军 / 中立的战舰（包括自己），目标敌人的也可以跳过（前面已处理）
+			if !m.state.IsEnemy(ship.BelongPlayer, enemy.BelongPlayer) ||
+				enemy.Uid == ship.AttackTarget || !m.mayEngage(ship, enemy.Uid, now) {
+				continue
+			}
+			// 如果不在 对舰 最大射程内，或不在视野内，跳过
+			if ship.CurPos.Distance(enemy.CurPos) > ship.Weapon.MaxToShipRange ||
+				!m.state.CanSee(ship.BelongPlayer, enemy) {
+				continue
+			}
+			inRangeEnemies = append(inRangeEnemies, enemy)
 		}
 
-		if total := len(inRangeEnemies); total != 0 {
-			// 射程内的敌人都会被攻击
-			enemy := inRangeEnemies[rand.Intn(total)]
-			bullets := ship.Fire(enemy)
+		if len(inRangeEnemies) != 0 {
+			bullets := ship.FireByPolicy(priority, inRangeEnemies, now, rng)
 			if len(bullets) == 0 {
 				continue
 			}
@@ -89,32 +94,56 @@ func (m *MissionManager) updateShipWeaponFire() {
 	m.weaponFirePlayer.PlayShipFire(maxBulletDiameter, isTorpedoLaunched, isRocketLaunched)
 }
 
+// mayEngage 交战规则是否允许战舰主动攻击指定敌人（指定攻击目标不受限制）
+func (m *MissionManager) mayEngage(ship *objUnit.BattleShip, enemyUid string, now int64) bool {
+	switch ship.FireStance {
+	case objUnit.FireStanceHoldFire:
+		return false
+	case objUnit.FireStanceReturnFire:
+		return ship.RecentlyAttackedBy(enemyUid, now)
+	}
+	return true
+}
+
 // 飞机出动 & 攻击
 func (m *MissionManager) updatePlaneAttackOrReturn() {
-	for _, ship := range m.state.Arena.Ships {
+	now, rng := m.state.Core.Clock.Now(), m.state.Rand()
+	ships, planes := m.state.SortedShips(), m.state.SortedPlanes()
+	for _, ship := range ships {
 		// 战舰上没有飞机的，跳过
 		if !ship.Aircraft.HasPlane {
 			continue
 		}
+		// 指定空袭 & 战斗空中巡逻优先于自动出击
+		if m.launchAirStrike(ship, now) || m.launchAirPatrol(ship, now) {
+			continue
+		}
+		// 留在甲板上的舰载机不自动出击
+		if ship.Aircraft.HoldOnDeck {
+			continue
+		}
 
 		inRangeEnemies := []objUnit.Hurtable{}
-		// TODO 目前飞机目标都没考虑是否在攻击范围内，未来还是需要考虑的
-		if target := m.state.Arena.Ships[ship.AttackTarget]; target != nil {
+		// TODO 目前飞机目标只考虑是否在视野内，没考虑是否在攻击范围内，未来还是需要考虑的
+		if target := m.state.Arena.Ships[ship.AttackTarget]; target != nil &&
+			!m.state.IsAlly(ship.BelongPlayer, target.BelongPlayer) && m.state.CanSee(ship.BelongPlayer, target) {
 			// 如果有目标敌人，则直接选中即可
 			inRangeEnemies = append(inRangeEnemies, target)
 		} else {
 			// 敌机
-			for _, enemy := range m.state.Arena.Planes {
-				// 不能攻击己方的战机
-				if ship.BelongPlayer == enemy.BelongPlayer {
+			for _, enemy := range planes {
+				// 只攻击交战规则允许的敌对势力战机，也不能攻击视野外的战机
+				if !m.state.IsEnemy(ship.BelongPlayer, enemy.BelongPlayer) || !m.state.CanSee(ship.BelongPlayer, enemy) ||
+					!m.mayEngage(ship, enemy.Uid, now) {
 					continue
 				}
 				inRangeEnemies = append(inRangeEnemies, enemy)
 			}
 			// 敌舰
-			for _, enemy := range m.state.Arena.Ships {
-				// 不能主动炮击己方的战舰（包括自己），目标敌人的也可以跳过（前面已处理）
-				if ship.BelongPlayer == enemy.BelongPlayer {
+			for _, enemy := range ships {
+				// 只主动攻击交战规则允许的敌对势力战舰，也不能攻击视野外的战舰
+				if !m.state.IsEnemy(ship.BelongPlayer, enemy.BelongPlayer) || !m.state.CanSee(ship.BelongPlayer, enemy) ||
+					!m.mayEngage(ship, enemy.Uid, now) {
 					continue
 				}
 				inRangeEnemies = append(inRangeEnemies, enemy)
@@ -123,8 +152,8 @@ func (m *MissionManager) updatePlaneAttackOrReturn() {
 
 		if total := len(inRangeEnemies); total != 0 {
 			// 射程内的敌人都会被攻击
-			enemy := inRangeEnemies[rand.Intn(total)]
-			plane := ship.Aircraft.TakeOff(ship, enemy.ObjType())
+			enemy := inRangeEnemies[rng.IntN(total)]
+			plane := ship.Aircraft.TakeOff(ship, enemy.ObjType(), now)
 			// 没有合适的飞机，那就跳过
 			if plane == nil {
 				continue
@@ -136,7 +165,9 @@ func (m *MissionManager) updatePlaneAttackOrReturn() {
 		}
 	}
 
-	for _, plane := range m.state.Arena.Planes {
+	// 新起飞的战机也需要参与
+	planes = m.state.SortedPlanes()
+	for _, plane := range planes {
 		if !plane.IsCruising() {
 			continue
 		}
@@ -151,24 +182,34 @@ func (m *MissionManager) updatePlaneAttackOrReturn() {
 		if m.instructionSet.Exists(instrUid) {
 			continue
 		}
+		// 执行指定空袭的战机，一击脱离后继续攻击空袭目标
+		if target, ok := m.state.Arena.Ships[plane.StrikeTarget]; ok {
+			m.instructionSet.Add(instr.NewPlaneAttack(plane.Uid, target.ObjType(), target.Uid))
+			continue
+		}
+		// 执行战斗空中巡逻的战斗机，只拦截巡逻范围内的敌机，没有敌机时继续盘旋
+		if center, ok := m.airPatrolCenter(plane); ok {
+			m.updatePlaneAirPatrol(plane, planes, center)
+			continue
+		}
 
 		// 有剩余燃料 & 没有攻击目标，按攻击类型选一个新的
 		inRangeEnemies := []objUnit.Hurtable{}
 
 		if plane.AttackObjType() == object.TypePlane {
 			// 敌机
-			for _, enemy := range m.state.Arena.Planes {
-				// 不能攻击己方的战机
-				if plane.BelongPlayer == enemy.BelongPlayer {
+			for _, enemy := range planes {
+				// 只攻击敌对势力的战机，也不能攻击视野外的战机
+				if !m.state.IsEnemy(plane.BelongPlayer, enemy.BelongPlayer) || !m.state.CanSee(plane.BelongPlayer, enemy) {
 					continue
 				}
 				inRangeEnemies = append(inRangeEnemies, enemy)
 			}
 		} else if plane.AttackObjType() == object.TypeShip {
 			// 敌舰
-			for _, enemy := range m.state.Arena.Ships {
-				// 不能主动炮击己方的战舰（包括自己），目标敌人的也可以跳过（前面已处理）
-				if plane.BelongPlayer == enemy.BelongPlayer {
+			for _, enemy := range ships {
+				// 只主动攻击敌对势力的战舰，也不能攻击视野外的战舰
+				if !m.state.IsEnemy(plane.BelongPlayer, enemy.BelongPlayer) || !m.state.CanSee(plane.BelongPlayer, enemy) {
 					continue
 				}
 				inRangeEnemies = append(inRangeEnemies, enemy)
@@ -176,7 +217,7 @@ func (m *MissionManager) updatePlaneAttackOrReturn() {
 		}
 		if total := len(inRangeEnemies); total != 0 {
 			// 射程内的敌人都会被攻击
-			enemy := inRangeEnemies[rand.Intn(total)]
+			enemy := inRangeEnemies[rng.IntN(total)]
 			// 给飞机下达攻击指令
 			m.instructionSet.Add(instr.NewPlaneAttack(plane.Uid, enemy.ObjType(), enemy.ID()))
 		} else {
@@ -186,20 +227,95 @@ func (m *MissionManager) updatePlaneAttackOrReturn() {
 	}
 }
 
+// launchAirStrike 执行指定空袭，空袭进行中返回 true（不再自动出击）
+func (m *MissionManager) launchAirStrike(ship *objUnit.BattleShip, now int64) bool {
+	strike := ship.Aircraft.Strike
+	if strike == nil {
+		return false
+	}
+	target, ok := m.state.Arena.Ships[strike.TargetUid]
+	// 目标已经被击沉 / 不再敌对，或者分组战机已经全部出击，空袭结束
+	if !ok || m.state.IsAlly(ship.BelongPlayer, target.BelongPlayer) ||
+		ship.Aircraft.GroupCount(strike.Group) <= 0 {
+		ship.Aircraft.Strike = nil
+		return false
+	}
+	if plane := ship.Aircraft.TakeOffGroup(ship, strike.Group, now); plane != nil {
+		plane.StrikeTarget = target.Uid
+		m.state.Arena.Planes[plane.Uid] = plane
+		m.instructionSet.Add(instr.NewPlaneAttack(plane.Uid, target.ObjType(), target.Uid))
+	}
+	return true
+}
+
+// launchAirPatrol 战斗空中巡逻：起飞舰上的战斗机前往巡逻位置，有战斗机起飞时返回 true
+func (m *MissionManager) launchAirPatrol(ship *objUnit.BattleShip, now int64) bool {
+	patrol := ship.Aircraft.Patrol
+	if patrol == nil {
+		return false
+	}
+	// 掩护的友舰已经沉没，巡逻结束
+	if _, ok := patrol.Center(m.state.Arena.Ships); !ok {
+		ship.Aircraft.Patrol = nil
+		return false
+	}
+	plane := ship.Aircraft.TakeOff(ship, object.TypePlane, now)
+	if plane == nil {
+		return false
+	}
+	m.state.Arena.Planes[plane.Uid] = plane
+	m.instructionSet.Add(instr.NewPlanePatrol(plane.Uid))
+	return true
+}
+
+// airPatrolCenter 战斗机所属母舰正在执行战斗空中巡逻时，返回巡逻中心
+func (m *MissionManager) airPatrolCenter(plane *objUnit.Plane) (objPos.MapPos, bool) {
+	if plane.AttackObjType() != object.TypePlane {
+		return objPos.MapPos{}, false
+	}
+	ship, ok := m.state.Arena.Ships[plane.BelongShip]
+	if !ok || ship.Aircraft.Patrol == nil {
+		return objPos.MapPos{}, false
+	}
+	return ship.Aircraft.Patrol.Center(m.state.Arena.Ships)
+}
+
+// updatePlaneAirPatrol 巡逻的战斗机拦截巡逻范围内的敌机，没有敌机时在巡逻中心上空盘旋
+func (m *MissionManager) updatePlaneAirPatrol(plane *objUnit.Plane, planes []*objUnit.Plane, center objPos.MapPos) {
+	inRangeEnemies := []objUnit.Hurtable{}
+	for _, enemy := range planes {
+		if !m.state.IsEnemy(plane.BelongPlayer, enemy.BelongPlayer) || !m.state.CanSee(plane.BelongPlayer, enemy) ||
+			!enemy.CurPos.Near(center, objUnit.AirPatrolRadius) {
+			continue
+		}
+		inRangeEnemies = append(inRangeEnemies, enemy)
+	}
+	patrolInstrUid := instr.GenInstrUid(instr.NamePlanePatrol, plane.Uid)
+	if total := len(inRangeEnemies); total != 0 {
+		enemy := inRangeEnemies[m.state.Rand().IntN(total)]
+		m.instructionSet.Remove(patrolInstrUid)
+		m.instructionSet.Add(instr.NewPlaneAttack(plane.Uid, enemy.ObjType(), enemy.ID()))
+	} else if !m.instructionSet.Exists(patrolInstrUid) {
+		m.instructionSet.Add(instr.NewPlanePatrol(plane.Uid))
+	}
+}
+
 // 更新战机武器开火相关状态
 func (m *MissionManager) updatePlaneWeaponFire() {
 	bombReleased, rocketLaunched, torpedoLaunched := false, false, false
+	now, rng := m.state.Core.Clock.Now(), m.state.Rand()
+	ships, planes := m.state.SortedShips(), m.state.SortedPlanes()
 
-	for _, plane := range m.state.Arena.Planes {
+	for _, plane := range planes {
 		if !plane.IsCruising() {
 			continue
 		}
 		inRangeEnemies := []objUnit.Hurtable{}
 		if plane.AttackObjType() == object.TypePlane {
 			// 敌机
-			for _, enemy := range m.state.Arena.Planes {
-				// 不能攻击己方的战机（包括自己）
-				if plane.BelongPlayer == enemy.BelongPlayer {
+			for _, enemy := range planes {
+				// 只攻击敌对势力的战机（不包括自己）
+				if !m.state.IsEnemy(plane.BelongPlayer, enemy.BelongPlayer) {
 					continue
 				}
 				// 如果不在 对空 最大射程内，跳过
@@ -210,9 +326,10 @@ func (m *MissionManager) updatePlaneWeaponFire() {
 			}
 		} else if plane.AttackObjType() == object.TypeShip {
 			// 敌舰
-			for _, enemy := range m.state.Arena.Ships {
-				// 不能攻击自己，也不能攻击己方的战舰
-				if plane.BelongPlayer == enemy.BelongPlayer {
+			for _, enemy := range ships {
+				// 不能攻击己方 / 友军的战舰，中立船只只有被指定为攻击目标时才会攻击
+				if m.state.IsAlly(plane.BelongPlayer, enemy.BelongPlayer) ||
+					(!m.state.IsEnemy(plane.BelongPlayer, enemy.BelongPlayer) && enemy.Uid != plane.CurAttackTarget) {
 					continue
 				}
 				// 如果不在 对舰 最大射程内，跳过
@@ -224,7 +341,7 @@ func (m *MissionManager) updatePlaneWeaponFire() {
 		}
 		if total := len(inRangeEnemies); total != 0 {
 			// 射程内的敌人都会被攻击
-			enemy := inRangeEnemies[rand.Intn(total)]
+			enemy := inRangeEnemies[rng.IntN(total)]
 			// 投放前检查飞机到预计命中点的航迹，只有陆地真正挡在
 			// 鱼雷与目标之间时才放弃本次投放。
 			if plane.Type == objUnit.PlaneTypeTorpedoBomber &&
@@ -232,7 +349,7 @@ func (m *MissionManager) updatePlaneWeaponFire() {
 				m.retargetTorpedoBomber(plane, enemy.ID())
 				continue
 			}
-			bullets := plane.Fire(enemy)
+			bullets := plane.Fire(enemy, now, rng)
 			if len(bullets) == 0 {
 				continue
 			}
@@ -259,12 +376,13 @@ func (m *MissionManager) updatePlaneWeaponFire() {
 	m.weaponFirePlayer.PlayPlaneFire(bombReleased, rocketLaunched, torpedoLaunched)
 }
 
-// retargetTorpedoBomber 让鱼雷机放弃当前不安全的投放对象，改为追踪其他敌舰。
+// retargetTorpedoBomber 让鱼雷机放弃当前不安全的投放对象，改为追踪视野内的其他敌舰。
 // 若没有其他敌舰，则保留原指令，等飞离陆地后再尝试投放。
 func (m *MissionManager) retargetTorpedoBomber(plane *objUnit.Plane, skippedTargetUid string) {
 	targets := []objUnit.Hurtable{}
-	for _, enemy := range m.state.Arena.Ships {
-		if plane.BelongPlayer == enemy.BelongPlayer || enemy.Uid == skippedTargetUid {
+	for _, enemy := range m.state.SortedShips() {
+		if !m.state.IsEnemy(plane.BelongPlayer, enemy.BelongPlayer) || enemy.Uid == skippedTargetUid ||
+			!m.state.CanSee(plane.BelongPlayer, enemy) {
 			continue
 		}
 		targets = append(targets, enemy)
@@ -273,7 +391,7 @@ func (m *MissionManager) retargetTorpedoBomber(plane *objUnit.Plane, skippedTarg
 		return
 	}
 
-	enemy := targets[rand.Intn(len(targets))]
+	enemy := targets[m.state.Rand().IntN(len(targets))]
 	plane.CurAttackTarget = enemy.ID()
 	m.instructionSet.Add(instr.NewPlaneAttack(plane.Uid, enemy.ObjType(), enemy.ID()))
 }
@@ -284,6 +402,18 @@ func (m *MissionManager) updateShotBullets() {
 		m.state.Arena.ForwardingBullets[i].Forward()
 	}
 
+	now, rng := m.state.Core.Clock.Now(), m.state.Rand()
+	// 按固定顺序遍历，保证同一种子下命中 / 暴击判定可复现
+	ships, planes := m.state.SortedShips(), m.state.SortedPlanes()
+
+	// 战舰中弹，记录敌方射手以便还击
+	hurtShip := func(ship *objUnit.BattleShip, bt *objBullet.Bullet) {
+		ship.HurtBy(bt, now, rng)
+		if m.state.IsEnemy(bt.BelongPlayer, ship.BelongPlayer) {
+			ship.RecordAttacker(bt.Shooter, now)
+		}
+	}
+
 	// 结算伤害
 	resolveDamage := func(bt *objBullet.Bullet) bool {
 		prevPos := bt.CurPos.Copy()
@@ -292,13 +422,13 @@ func (m *MissionManager) updateShotBullets() {
 
 		switch bt.TargetObjType {
 		case object.TypeShip:
-			for _, ship := range m.state.Arena.Ships {
+			for _, ship := range ships {
 				// 总不能不小心打死自己吧，真是不应该 :D
 				if bt.Shooter == ship.Uid {
 					continue
 				}
-				// 如果友军伤害没启用，则不对己方战舰造成伤害
-				if !m.state.UI.GameOpts.FriendlyFire && bt.BelongPlayer == ship.BelongPlayer {
+				// 如果友军伤害没启用，则不对己方 / 友军战舰造成伤害
+				if !m.state.UI.GameOpts.FriendlyFire && m.state.IsAlly(bt.BelongPlayer, ship.BelongPlayer) {
 					continue
 				}
 
@@ -313,7 +443,7 @@ func (m *MissionManager) updateShotBullets() {
 						ship.Width/constants.MapBlockSize,
 						ship.CurRotation,
 					) {
-						ship.HurtBy(bt)
+						hurtShip(ship, bt)
 						bt.HitObjType = object.TypeShip
 						break
 					}
@@ -327,32 +457,32 @@ func (m *MissionManager) updateShotBullets() {
 						ship.Width/constants.MapBlockSize,
 						ship.CurRotation,
 					) {
-						ship.HurtBy(bt)
+						hurtShip(ship, bt)
 						bt.HitObjType = object.TypeShip
 						break
 					}
 				}
 			}
 		case object.TypePlane:
-			for _, plane := range m.state.Arena.Planes {
+			for _, plane := range planes {
 				// 总不能不小心打死自己吧，真是不应该 :D
 				if bt.Shooter == plane.Uid {
 					continue
 				}
-				// 如果友军伤害没启用，则不对己方战舰造成伤害
-				if !m.state.UI.GameOpts.FriendlyFire && bt.BelongPlayer == plane.BelongPlayer {
+				// 如果友军伤害没启用，则不对己方 / 友军战机造成伤害
+				if !m.state.UI.GameOpts.FriendlyFire && m.state.IsAlly(bt.BelongPlayer, plane.BelongPlayer) {
 					continue
 				}
 				// 如果是舰对空，需要设置 “擦肩而过” 率，现在命中率太高（昭和防空，十防九空）
 				if bt.ShooterObjType == object.TypeShip {
 					if plane.Type == objUnit.PlaneTypeDiveBomber {
 						// 俯冲轰炸机要飞得很近，插肩而过率得高一些
-						if rand.Intn(24) != 0 {
+						if rng.IntN(24) != 0 {
 							continue
 						}
 					} else {
 						// 鱼雷机/战斗机按 1/8 的概率被击中
-						if rand.Intn(8) != 0 {
+						if rng.IntN(8) != 0 {
 							continue
 						}
 					}
@@ -368,7 +498,7 @@ func (m *MissionManager) updateShotBullets() {
 					plane.Width/constants.MapBlockSize,
 					plane.CurRotation,
 				) {
-					plane.HurtBy(bt)
+					plane.HurtBy(bt, now, rng)
 					bt.HitObjType = object.TypePlane
 					break
 				}
@@ -383,11 +513,11 @@ func (m *MissionManager) updateShotBullets() {
 		if bt.Life <= 0 || bt.CurPos.Near(bt.TargetPos, bt.ProximityRadius) {
 			return true
 		}
-		for _, plane := range m.state.Arena.Planes {
+		for _, plane := range planes {
 			if bt.Shooter == plane.Uid {
 				continue
 			}
-			if !m.state.UI.GameOpts.FriendlyFire && bt.BelongPlayer == plane.BelongPlayer {
+			if !m.state.UI.GameOpts.FriendlyFire && m.state.IsAlly(bt.BelongPlayer, plane.BelongPlayer) {
 				continue
 			}
 			if bt.CurPos.Distance(plane.CurPos) <= bt.ProximityRadius {
@@ -399,17 +529,17 @@ func (m *MissionManager) updateShotBullets() {
 
 	// resolveRocketDamage 处理火箭近炸破片范围伤害，并创建局部爆炸效果。
 	resolveRocketDamage := func(bt *objBullet.Bullet) {
-		for _, plane := range m.state.Arena.Planes {
+		for _, plane := range planes {
 			if bt.Shooter == plane.Uid {
 				continue
 			}
-			if !m.state.UI.GameOpts.FriendlyFire && bt.BelongPlayer == plane.BelongPlayer {
+			if !m.state.UI.GameOpts.FriendlyFire && m.state.IsAlly(bt.BelongPlayer, plane.BelongPlayer) {
 				continue
 			}
 			if bt.CurPos.Distance(plane.CurPos) > bt.BlastRadius {
 				continue
 			}
-			plane.HurtBy(bt)
+			plane.HurtBy(bt, now, rng)
 			bt.HitObjType = object.TypePlane
 		}
 		if bt.HitObjType == object.TypeNone {
@@ -476,7 +606,17 @@ func (m *MissionManager) updateShotBullets() {
 			continue
 		}
 
-		if m.state.UI.GameOpts.DisplayDamageNumber {
+		// 鱼雷命中战舰必定进水，与炮弹命中区分开（迷雾中的命中不展示，避免暴露目标位置）
+		if bt.Type == objBullet.TypeTorpedo && bt.HitObjType == object.TypeShip &&
+			m.state.View.Camera.Contains(bt.CurPos) && m.state.PosInVision(m.state.Player.CurPlayer, bt.CurPos) {
+			pos := bt.CurPos.Copy()
+			pos.SubRy(0.5)
+			mark := objMark.NewText(pos, i18n.Text(i18n.MsgShipTorpedoFlooding), 18, colorx.SkyBlue, 50)
+			m.state.UI.GameMarks[mark.ID] = mark
+		}
+
+		// 伤害数值会暴露目标位置，迷雾中的命中不展示
+		if m.state.UI.GameOpts.DisplayDamageNumber && m.state.PosInVision(m.state.Player.CurPlayer, bt.CurPos) {
 			fontSize, clr := 0.0, colorx.White
 			switch bt.CriticalType {
 			case objBullet.CriticalTypeNone:
@@ -488,7 +628,7 @@ func (m *MissionManager) updateShotBullets() {
 			}
 			// DEBUG: 调试用逻辑，区分敌我伤害
 			if m.state.UI.DebugFlags.DamageColorByTeam {
-				if bt.BelongPlayer == m.state.Player.CurPlayer {
+				if m.state.IsAlly(bt.BelongPlayer, m.state.Player.CurPlayer) {
 					clr = colorx.Cyan
 				} else {
 					clr = colorx.DarkRed
@@ -500,6 +640,15 @@ func (m *MissionManager) updateShotBullets() {
 				strconv.Itoa(int(bt.RealDamage)),
 				fmt.Sprintf("%.2f", bt.RealDamage),
 			)
+			// 跳弹 / 未击穿 / 过度击穿在伤害数值后标注
+			switch bt.ArmorResult {
+			case objBullet.ArmorResultRicochet:
+				flagText += " " + i18n.Text(i18n.MsgArmorRicochet)
+			case objBullet.ArmorResultNonPenetration:
+				flagText += " " + i18n.Text(i18n.MsgArmorNonPenetration)
+			case objBullet.ArmorResultOverPenetration:
+				flagText += " " + i18n.Text(i18n.MsgArmorOverPenetration)
+			}
 			mark := objMark.NewText(bt.CurPos, flagText, fontSize, clr, 20)
 			m.state.UI.GameMarks[mark.ID] = mark
 		}
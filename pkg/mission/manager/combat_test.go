@@ -4,11 +4,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	audioPlayer "github.com/narasux/jutland/pkg/audio/player"
 	"github.com/narasux/jutland/pkg/config"
 	"github.com/narasux/jutland/pkg/mission/faction"
 	instr "github.com/narasux/jutland/pkg/mission/instruction"
 	"github.com/narasux/jutland/pkg/mission/metadata"
+	"github.com/narasux/jutland/pkg/mission/object"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
 	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 	"github.com/narasux/jutland/pkg/mission/state"
@@ -115,3 +118,69 @@ func TestTorpedoBomberSkipsReleaseWhenPathCrossesLand(t *testing.T) {
 		t.Fatalf("attack instruction = %v, want target %q", attackInstruction, alternateTarget.Uid)
 	}
 }
+
+func TestFireStanceLimitsEngagement(t *testing.T) {
+	ship := newDutyTestShip("ship", 0, 0, faction.HumanAlpha)
+	m := newOrderTestManager(ship)
+	now := int64(5000)
+
+	require.True(t, m.mayEngage(ship, "enemy", now))
+
+	// 还击：只攻击近期攻击过自己的敌人
+	ship.SetFireStance(objUnit.FireStanceReturnFire)
+	require.False(t, m.mayEngage(ship, "enemy", now))
+	ship.RecordAttacker("enemy", now)
+	require.True(t, m.mayEngage(ship, "enemy", now))
+	require.False(t, m.mayEngage(ship, "other", now))
+
+	// 停火：不主动攻击任何敌人
+	ship.SetFireStance(objUnit.FireStanceHoldFire)
+	require.False(t, m.mayEngage(ship, "enemy", now))
+}
+
+func TestCarrierAirOpsLaunches(t *testing.T) {
+	oldSettings := config.G
+	config.G = config.NewDefaultGameSettings()
+	t.Cleanup(func() { config.G = oldSettings })
+	for _, name := range []string{"test-air-ops-fighter", "test-air-ops-bomber"} {
+		objUnit.PlaneMap[name] = &objUnit.Plane{Name: name, MaxSpeed: 0.1, Range: 100}
+	}
+	t.Cleanup(func() {
+		delete(objUnit.PlaneMap, "test-air-ops-fighter")
+		delete(objUnit.PlaneMap, "test-air-ops-bomber")
+	})
+
+	carrier := newDutyTestShip("carrier", 10, 10, faction.HumanAlpha)
+	carrier.Aircraft = objUnit.ShipAircraft{
+		TakeOffTime: 3,
+		HasPlane:    true,
+		Groups: []objUnit.PlaneGroup{
+			{Name: "test-air-ops-fighter", MaxCount: 2, TargetType: object.TypePlane, CurCount: 2},
+			{Name: "test-air-ops-bomber", MaxCount: 2, TargetType: object.TypeShip, CurCount: 2},
+		},
+	}
+	enemy := newDutyTestShip("enemy", 20, 10, faction.ComputerAlpha)
+	m := newOrderTestManager(carrier, enemy)
+	m.state.Arena.Planes = map[string]*objUnit.Plane{}
+
+	// 留在甲板上时不自动出击
+	carrier.Aircraft.SetHold(true)
+	m.updatePlaneAttackOrReturn()
+	require.Empty(t, m.state.Arena.Planes)
+
+	// 指定空袭：起飞指定分组的战机攻击目标
+	require.True(t, carrier.Aircraft.LaunchStrike("test-air-ops-bomber", enemy.Uid))
+	m.updatePlaneAttackOrReturn()
+	plane := m.state.Arena.Planes["carrier/plane-1"]
+	require.NotNil(t, plane)
+	require.Equal(t, "test-air-ops-bomber", plane.Name)
+	require.Equal(t, enemy.Uid, plane.StrikeTarget)
+	require.True(t, m.instructionSet.Exists(instr.GenInstrUid(instr.NamePlaneAttack, plane.Uid)))
+
+	// 召回后战机必须返航
+	recall := instr.NewShipRecallAircraft(carrier.Uid)
+	require.NoError(t, recall.Exec(m.state))
+	require.True(t, plane.MustReturn())
+	require.True(t, carrier.Aircraft.HoldOnDeck)
+	require.Nil(t, carrier.Aircraft.Strike)
+}
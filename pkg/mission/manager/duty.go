@@ -0,0 +1,113 @@
+package manager
+
+import (
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+const (
+	// 护航：敌舰进入护航舰射程外该距离内（相对护航对象）即迎击
+	escortAlertDistance = 4
+	// 警戒：离警戒点超过该距离且没有敌情时返回警戒点
+	guardReturnDistance = 2
+)
+
+// updateShipDuties 推进战舰值守任务（护航 / 警戒）
+// 注：与排队命令、编队一样只依赖任务状态，不需要录制
+func (m *MissionManager) updateShipDuties() {
+	ships := m.state.SortedShips()
+	// 同一护航对象的护航舰按 Uid 顺序占据阵位
+	escorts := map[string][]*objUnit.BattleShip{}
+	for _, ship := range ships {
+		if ship.Duty.Type == objUnit.DutyEscort {
+			escorts[ship.Duty.TargetUid] = append(escorts[ship.Duty.TargetUid], ship)
+		}
+	}
+	for _, ship := range ships {
+		switch ship.Duty.Type {
+		case objUnit.DutyEscort:
+			m.advanceEscort(ships, ship, escorts[ship.Duty.TargetUid])
+		case objUnit.DutyGuard:
+			m.advanceGuard(ships, ship)
+		}
+	}
+}
+
+// advanceEscort 护航：在护航对象周围的环形阵位上保持位置，迎击逼近护航对象的敌舰；护航对象沉没后任务结束
+func (m *MissionManager) advanceEscort(ships []*objUnit.BattleShip, ship *objUnit.BattleShip, group []*objUnit.BattleShip) {
+	target, ok := m.state.Arena.Ships[ship.Duty.TargetUid]
+	if !ok {
+		ship.ClearDuty()
+		return
+	}
+
+	if enemy := m.nearestVisibleEnemy(
+		ships, ship, target.CurPos, ship.Weapon.MaxToShipRange+escortAlertDistance,
+	); enemy != nil && ship.AttackTarget != enemy.Uid {
+		m.instructionSet.Add(instr.NewShipAttack(ship.Uid, enemy.Uid))
+	}
+
+	idx := 0
+	for i, escort := range group {
+		if escort.Uid == ship.Uid {
+			idx = i
+		}
+	}
+	spacing := objUnit.FormationSpacing(append([]*objUnit.BattleShip{target}, group...))
+	slot := objUnit.FormationSlots(objUnit.FormationScreen, len(group), spacing)[idx]
+	slotPos := slot.Pos(target.CurPos, target.CurRotation)
+
+	moveUid := instr.GenInstrUid(instr.NameShipMove, ship.Uid)
+	// 离阵位太远时寻路驶向护航对象，靠近后再保持阵位
+	if ship.CurPos.Distance(slotPos) > formationRejoinDistance {
+		if !m.instructionSet.Exists(moveUid) {
+			m.instructionSet.Add(newShipMoveInstr(ship, target.CurPos))
+		}
+		return
+	}
+	m.instructionSet.Remove(moveUid)
+	ship.FollowFormation(m.state.Core.MissionMD.MapCfg, slotPos, target.CurRotation, target.CurSpeed)
+}
+
+// advanceGuard 警戒：追击进入警戒区域的敌舰（离警戒点最近的一艘），敌舰被击沉 / 离开区域后返回警戒点
+func (m *MissionManager) advanceGuard(ships []*objUnit.BattleShip, ship *objUnit.BattleShip) {
+	duty := &ship.Duty
+	moving := m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipMove, ship.Uid))
+
+	enemy := m.nearestVisibleEnemy(ships, ship, duty.Anchor, duty.Radius)
+	if enemy == nil {
+		if duty.ChaseUid != "" {
+			// 追击结束，立即返回警戒点（取代追击的移动指令）
+			duty.ChaseUid = ""
+			m.instructionSet.Add(newShipMoveInstr(ship, duty.Anchor))
+		} else if !moving && ship.CurPos.Distance(duty.Anchor) > guardReturnDistance {
+			m.instructionSet.Add(newShipMoveInstr(ship, duty.Anchor))
+		}
+		return
+	}
+
+	if ship.AttackTarget != enemy.Uid {
+		m.instructionSet.Add(instr.NewShipAttack(ship.Uid, enemy.Uid))
+	}
+	retarget := duty.ChaseUid != enemy.Uid
+	duty.ChaseUid = enemy.Uid
+	m.pursueTarget(ships, ship, enemy, retarget)
+}
+
+// nearestVisibleEnemy 距离指定位置 radius 范围内、战舰所属阵营可见的最近敌舰
+func (m *MissionManager) nearestVisibleEnemy(
+	ships []*objUnit.BattleShip, ship *objUnit.BattleShip, pos objPos.MapPos, radius float64,
+) *objUnit.BattleShip {
+	var nearest *objUnit.BattleShip
+	for _, enemy := range ships {
+		if !m.state.IsEnemy(ship.BelongPlayer, enemy.BelongPlayer) || !m.state.CanSee(ship.BelongPlayer, enemy) {
+			continue
+		}
+		dist := pos.Distance(enemy.CurPos)
+		if dist <= radius && (nearest == nil || dist < pos.Distance(nearest.CurPos)) {
+			nearest = enemy
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/resources/mapcfg"
+)
+
+func newDutyTestShip(uid string, x, y float64, player faction.Player) *objUnit.BattleShip {
+	return &objUnit.BattleShip{
+		Uid: uid, CurHP: 100, MaxSpeed: 1, Acceleration: 0.1, RotateSpeed: 5,
+		CurPos: objPos.NewR(x, y), BelongPlayer: player, Weapon: objUnit.ShipWeapon{MaxToShipRange: 5},
+	}
+}
+
+func TestEscortKeepsStationAndEngages(t *testing.T) {
+	oldSettings := config.G
+	config.G = config.NewDefaultGameSettings()
+	t.Cleanup(func() { config.G = oldSettings })
+
+	cargo := newDutyTestShip("cargo", 20, 20, faction.HumanAlpha)
+	near := newDutyTestShip("near", 21, 22, faction.HumanAlpha)
+	far := newDutyTestShip("far", 40, 40, faction.HumanAlpha)
+	near.AssignDuty(objUnit.ShipDuty{Type: objUnit.DutyEscort, TargetUid: cargo.Uid})
+	far.AssignDuty(objUnit.ShipDuty{Type: objUnit.DutyEscort, TargetUid: cargo.Uid})
+	enemy := newDutyTestShip("enemy", 20, 12, faction.ComputerAlpha)
+	m := newOrderTestManager(cargo, near, far, enemy)
+	m.state.Core.MissionMD = metadata.MissionMetadata{MapCfg: &mapcfg.MapCfg{Width: 60, Height: 60}}
+
+	m.updateShipDuties()
+	// 迎击逼近护航对象的敌舰
+	require.True(t, m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipAttack, near.Uid)))
+	// 阵位附近的护航舰直接驶向阵位，离得太远的寻路驶向护航对象
+	require.NotEqual(t, objPos.NewR(21, 22), near.CurPos)
+	_, ok := shipMoveTarget(t, m, near.Uid)
+	require.False(t, ok)
+	target, ok := shipMoveTarget(t, m, far.Uid)
+	require.True(t, ok)
+	require.Equal(t, cargo.CurPos, target)
+
+	// 护航对象沉没后任务结束
+	delete(m.state.Arena.Ships, cargo.Uid)
+	m.updateShipDuties()
+	require.False(t, near.HasDuty())
+	require.False(t, far.HasDuty())
+}
+
+func TestGuardChasesIntruderAndReturns(t *testing.T) {
+	anchor := objPos.New(20, 20)
+	ship := newDutyTestShip("ship", 20, 20, faction.HumanAlpha)
+	ship.AssignDuty(objUnit.ShipDuty{Type: objUnit.DutyGuard, Anchor: anchor, Radius: 10})
+	enemy := newDutyTestShip("enemy", 20, 40, faction.ComputerAlpha)
+	m := newOrderTestManager(ship, enemy)
+
+	// 区域外的敌舰不追击
+	m.updateShipDuties()
+	_, ok := shipMoveTarget(t, m, ship.Uid)
+	require.False(t, ok)
+
+	// 敌舰进入警戒区域后追击
+	enemy.CurPos = objPos.New(20, 29)
+	m.updateShipDuties()
+	require.True(t, m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipAttack, ship.Uid)))
+	require.Equal(t, enemy.Uid, ship.Duty.ChaseUid)
+	target, ok := shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.Equal(t, enemy.CurPos, target)
+
+	// 敌舰被击沉后返回警戒点
+	ship.CurPos = objPos.New(20, 26)
+	delete(m.state.Arena.Ships, enemy.Uid)
+	m.updateShipDuties()
+	require.Empty(t, ship.Duty.ChaseUid)
+	target, ok = shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.Equal(t, anchor, target)
+}
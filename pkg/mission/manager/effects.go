@@ -2,7 +2,6 @@ package manager
 
 import (
 	"math"
-	"math/rand"
 
 	"github.com/narasux/jutland/pkg/audio"
 	"github.com/narasux/jutland/pkg/common/constants"
@@ -96,7 +95,8 @@ func (m *MissionManager) updateMissionShips() {
 			// 这里做了取巧，复用 CurHP 用于后续渲染爆炸效果
 			ship.CurHP = textureImg.MaxShipExplodeState
 
-			if audioPlayQuota > 0 && m.state.View.Camera.Contains(ship.CurPos) {
+			m.recordShipLost(ship.BelongPlayer)
+			if !m.headless && audioPlayQuota > 0 && m.state.View.Camera.Contains(ship.CurPos) {
 				audio.PlayAudioToEnd(audioRes.NewShipExplode())
 				audioPlayQuota--
 			}
@@ -126,20 +126,21 @@ func (m *MissionManager) updateMissionShips() {
 // 更新局内战机
 func (m *MissionManager) updateMissionPlanes() {
 	// 如果战机 HP 为 0，则需要走消亡流程
-	for uid, plane := range m.state.Arena.Planes {
+	for _, plane := range m.state.SortedPlanes() {
 		if plane.CurHP <= 0 {
+			m.recordPlaneLost(plane.BelongPlayer)
 			if ship := m.state.Arena.Ships[plane.BelongShip]; ship != nil {
-				ship.Aircraft.CancelLanding(uid)
+				ship.Aircraft.CancelLanding(plane.Uid)
 			}
 			// 这里做了取巧，复用 CurHP 用于后续渲染爆炸效果
 			plane.CurHP = textureImg.MaxPlaneExplodeState
 
 			// 随机决定坠落偏转方向和幅度，存储在 RemainRange 中（借用该字段）
 			// 范围 [-0.5, 0.5]，正值右偏，负值左偏
-			plane.RemainRange = rand.Float64() - 0.5
+			plane.RemainRange = m.state.Rand().Float64() - 0.5
 
 			m.state.Arena.DestroyedPlanes = append(m.state.Arena.DestroyedPlanes, plane)
-			delete(m.state.Arena.Planes, uid)
+			delete(m.state.Arena.Planes, plane.Uid)
 		}
 	}
 
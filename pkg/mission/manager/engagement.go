@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"math"
+
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+const (
+	// 保持距离：与目标的距离偏离保持距离超过该值（地图格）时才调整位置，避免来回折腾
+	standOffTolerance = 1.5
+	// 攻击移动：敌舰进入射程外该距离内即迎击
+	attackMoveAlertDistance = 2
+)
+
+// updateStandOff 直接指定攻击目标（没有排队命令 & 值守任务）的保持距离战舰，保持与攻击目标的距离，敌舰过近时后撤
+// 注：排队命令 / 值守任务的追击由各自的推进逻辑调用 pursueTarget，这里不重复处理
+func (m *MissionManager) updateStandOff() {
+	ships := m.state.SortedShips()
+	for _, ship := range ships {
+		if ship.StandOffRate <= 0 || ship.AttackTarget == "" || len(ship.Orders) != 0 || ship.HasDuty() ||
+			(ship.InFormation() && !ship.IsFormationGuide()) {
+			continue
+		}
+		target, ok := m.state.Arena.Ships[ship.AttackTarget]
+		if !ok || !m.state.CanSee(ship.BelongPlayer, target) {
+			continue
+		}
+		m.pursueTarget(ships, ship, target, false)
+	}
+}
+
+// pursueTarget 追击攻击目标：保持距离的战舰驶向目标方向上的保持距离处（敌舰过近时后撤），其余战舰逼近到射程内
+// force 为 true 时取代正在执行的移动指令（如更换追击目标），否则等待移动完成后再调整
+func (m *MissionManager) pursueTarget(
+	ships []*objUnit.BattleShip, ship, target *objUnit.BattleShip, force bool,
+) {
+	// 航母攻击的话，不要移动过去突脸
+	if ship.Type == objUnit.ShipTypeAircraftCarrier {
+		return
+	}
+	if !force && m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipMove, ship.Uid)) {
+		return
+	}
+	if ship.StandOffRate <= 0 {
+		if ship.CurPos.Distance(target.CurPos) > ship.Weapon.MaxToShipRange*orderAttackRangeRate {
+			m.instructionSet.Add(newShipMoveInstr(ship, target.CurPos))
+		}
+		return
+	}
+	if pos, ok := m.standOffPos(ships, ship, target); ok {
+		m.instructionSet.Add(newShipMoveInstr(ship, pos))
+	}
+}
+
+// standOffPos 保持距离的战舰需要前往的位置：有敌舰过近时沿远离该敌舰的方向后撤，
+// 离攻击目标太远时驶向目标方向上的保持距离处；距离合适（或目标位置在陆地上）时不需要移动
+func (m *MissionManager) standOffPos(
+	ships []*objUnit.BattleShip, ship, target *objUnit.BattleShip,
+) (objPos.MapPos, bool) {
+	keep := ship.StandOffDistance()
+	from := target.CurPos
+	if threat := m.nearestVisibleEnemy(ships, ship, ship.CurPos, keep-standOffTolerance); threat != nil {
+		from = threat.CurPos
+	} else if ship.CurPos.Distance(target.CurPos) <= min(keep+standOffTolerance, ship.Weapon.MaxToShipRange) {
+		return objPos.MapPos{}, false
+	}
+
+	// 从敌舰指向战舰的方向（重合时沿当前航向）
+	dx, dy := ship.CurPos.RX-from.RX, ship.CurPos.RY-from.RY
+	if dist := math.Hypot(dx, dy); dist > 0 {
+		dx, dy = dx/dist, dy/dist
+	} else {
+		dx, dy = math.Sin(ship.CurRotation*math.Pi/180), -math.Cos(ship.CurRotation*math.Pi/180)
+	}
+	pos := objPos.NewR(from.RX+dx*keep, from.RY+dy*keep)
+	mapCfg := m.state.Core.MissionMD.MapCfg
+	pos.EnsureBorder(float64(mapCfg.Width-2), float64(mapCfg.Height-2))
+	if mapCfg.Map.IsLand(pos.MX, pos.MY) && !ship.CanOnLand() {
+		return objPos.MapPos{}, false
+	}
+	return pos, true
+}
@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	"github.com/narasux/jutland/pkg/resources/mapcfg"
+)
+
+func TestStandOffKeepsRangeAndKites(t *testing.T) {
+	ship := newDutyTestShip("ship", 20, 40, faction.HumanAlpha)
+	ship.Weapon.MaxToShipRange = 10
+	ship.SetStandOffRate(0.5)
+	enemy := newDutyTestShip("enemy", 20, 10, faction.ComputerAlpha)
+	ship.Attack(enemy.Uid)
+	m := newOrderTestManager(ship, enemy)
+	m.state.Core.MissionMD = metadata.MissionMetadata{MapCfg: &mapcfg.MapCfg{Width: 60, Height: 60}}
+
+	// 离目标太远时，驶向目标方向上的保持距离处，而不是直接突脸
+	m.updateStandOff()
+	target, ok := shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.InDelta(t, 20, target.RX, 1e-9)
+	require.InDelta(t, 15, target.RY, 1e-9)
+
+	// 距离合适时原地交战
+	m.instructionSet.Remove(instr.GenInstrUid(instr.NameShipMove, ship.Uid))
+	ship.CurPos = objPos.NewR(20, 15.5)
+	m.updateStandOff()
+	_, ok = shipMoveTarget(t, m, ship.Uid)
+	require.False(t, ok)
+
+	// 敌舰逼近时后撤
+	enemy.CurPos = objPos.NewR(20, 13)
+	m.updateStandOff()
+	target, ok = shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.InDelta(t, 18, target.RY, 1e-9)
+}
+
+func TestAttackMoveEngagesAndResumes(t *testing.T) {
+	waypoint := objPos.New(20, 50)
+	ship := newDutyTestShip("ship", 20, 10, faction.HumanAlpha)
+	ship.AttackMove(waypoint)
+	enemy := newDutyTestShip("enemy", 40, 40, faction.ComputerAlpha)
+	m := newOrderTestManager(ship, enemy)
+
+	// 没有敌情时前往航点
+	m.updateShipOrders()
+	target, ok := shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.Equal(t, waypoint, target)
+
+	// 途中遭遇敌舰，停下迎击
+	enemy.CurPos = objPos.NewR(22, 12)
+	m.updateShipOrders()
+	require.True(t, m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipAttack, ship.Uid)))
+	require.Equal(t, enemy.Uid, ship.Orders[0].TargetUid)
+	_, ok = shipMoveTarget(t, m, ship.Uid)
+	require.False(t, ok)
+
+	// 敌舰被击沉后继续前往航点
+	delete(m.state.Arena.Ships, enemy.Uid)
+	m.updateShipOrders()
+	require.Empty(t, ship.Orders[0].TargetUid)
+	target, ok = shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.Equal(t, waypoint, target)
+
+	// 到达航点后命令完成
+	m.instructionSet.Remove(instr.GenInstrUid(instr.NameShipMove, ship.Uid))
+	ship.CurPos = waypoint
+	m.updateShipOrders()
+	require.Empty(t, ship.Orders)
+}
@@ -0,0 +1,45 @@
+package manager
+
+import (
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+)
+
+// 跟随舰离编队位置超过该距离时改为寻路追赶
+const formationRejoinDistance = 8
+
+// updateFormations 编队跟随：跟随舰驶向相对向导舰航向的编队位置（向导舰转向后自动重新列队）
+// 向导舰被击沉 / 解散编队时跟随舰脱离编队，没有跟随舰的向导舰也脱离编队（解除航速限制）
+// 注：与排队命令一样只依赖任务状态，不需要录制
+func (m *MissionManager) updateFormations() {
+	ships := m.state.SortedShips()
+	followers := map[string]int{}
+	for _, ship := range ships {
+		if !ship.InFormation() || ship.IsFormationGuide() {
+			continue
+		}
+		guide, ok := m.state.Arena.Ships[ship.Formation.GuideUid]
+		if !ok || !guide.IsFormationGuide() || guide.Formation.Type != ship.Formation.Type {
+			ship.LeaveFormation()
+			continue
+		}
+		followers[guide.Uid]++
+
+		slotPos := ship.Formation.Slot.Pos(guide.CurPos, guide.CurRotation)
+		moveUid := instr.GenInstrUid(instr.NameShipMove, ship.Uid)
+		// 离编队位置太远（刚加入编队，或被岛屿隔开）时寻路驶向向导舰，靠近后再列队
+		if ship.CurPos.Distance(slotPos) > formationRejoinDistance {
+			if !m.instructionSet.Exists(moveUid) {
+				m.instructionSet.Add(newShipMoveInstr(ship, guide.CurPos))
+			}
+			continue
+		}
+		// 编队接管跟随舰的移动，残留的移动指令不再执行
+		m.instructionSet.Remove(moveUid)
+		ship.FollowFormation(m.state.Core.MissionMD.MapCfg, slotPos, guide.CurRotation, guide.CurSpeed)
+	}
+	for _, ship := range ships {
+		if ship.IsFormationGuide() && followers[ship.Uid] == 0 {
+			ship.LeaveFormation()
+		}
+	}
+}
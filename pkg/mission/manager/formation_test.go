@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/resources/mapcfg"
+)
+
+func newFormationTestShip(uid string, x, y float64, formation objUnit.ShipFormation) *objUnit.BattleShip {
+	return &objUnit.BattleShip{
+		Uid: uid, CurHP: 100, MaxSpeed: 1, Acceleration: 0.1, RotateSpeed: 5,
+		CurPos: objPos.NewR(x, y), BelongPlayer: faction.HumanAlpha, Formation: formation,
+	}
+}
+
+func TestFormationFollowersTakeStation(t *testing.T) {
+	oldSettings := config.G
+	config.G = config.NewDefaultGameSettings()
+	t.Cleanup(func() { config.G = oldSettings })
+
+	formation := objUnit.ShipFormation{Type: objUnit.FormationLineAhead, GuideUid: "guide"}
+	guide := newFormationTestShip("guide", 20, 20, formation)
+	guide.SpeedLimit = 0.5
+	formation.Slot = objUnit.FormationSlot{Forward: -3}
+	near := newFormationTestShip("near", 20, 25, formation)
+	far := newFormationTestShip("far", 20, 40, formation)
+	m := newOrderTestManager(guide, near, far)
+	m.state.Core.MissionMD = metadata.MissionMetadata{MapCfg: &mapcfg.MapCfg{Width: 60, Height: 60}}
+
+	// 跟随舰残留的移动指令由编队接管
+	m.instructionSet.Add(instr.NewShipMove(near.Uid, objPos.New(40, 40)))
+	m.updateFormations()
+	require.False(t, m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipMove, near.Uid)))
+	// 驶向向导舰身后的编队位置
+	require.Less(t, near.CurPos.RY, 25.0)
+	// 离编队位置太远的跟随舰寻路追赶向导舰
+	target, ok := shipMoveTarget(t, m, far.Uid)
+	require.True(t, ok)
+	require.Equal(t, guide.CurPos, target)
+
+	// 向导舰被击沉后，跟随舰脱离编队
+	delete(m.state.Arena.Ships, guide.Uid)
+	m.updateFormations()
+	require.False(t, near.InFormation())
+	require.False(t, far.InFormation())
+}
+
+func TestFormationGuideWithoutFollowersLeaves(t *testing.T) {
+	guide := newFormationTestShip("guide", 20, 20, objUnit.ShipFormation{Type: objUnit.FormationWedge, GuideUid: "guide"})
+	guide.SpeedLimit = 0.5
+	m := newOrderTestManager(guide)
+
+	m.updateFormations()
+	require.False(t, guide.InFormation())
+	require.Zero(t, guide.SpeedLimit)
+}
@@ -0,0 +1,151 @@
+package manager
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/narasux/jutland/pkg/mission/controller/computer"
+	"github.com/narasux/jutland/pkg/mission/faction"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// HeadlessSummary 无界面模拟结束后的战况汇总
+type HeadlessSummary struct {
+	Mission string
+	Seed    int64
+	// 电脑 AI 难度
+	Difficulty state.Difficulty
+	Status     state.MissionStatus
+	Ticks      int
+	Players    []HeadlessPlayerSummary
+}
+
+// HeadlessPlayerSummary 单个玩家的战况汇总
+type HeadlessPlayerSummary struct {
+	Player      faction.Player
+	IsCurPlayer bool
+	// 存活战舰数量 & 总吨位
+	Ships   int
+	Tonnage float64
+	// 存活战舰剩余生命值比例
+	RemainHPRate float64
+	LostShips    int
+	LostPlanes   int
+	// 资金
+	Funds int64
+}
+
+// NewHeadless 创建无界面的任务管理器（不创建绘制器，侧边栏，终端，音效）
+// 各方均由电脑控制，用于命令行批量模拟任务 / 测试 AI
+func NewHeadless(mission string, seed int64) *MissionManager {
+	misState := state.NewMissionState(mission, seed)
+	return &MissionManager{
+		state:          misState,
+		instructionSet: NewInstructionSet(),
+		playerHandlers: newPlayerHandlers(misState, computer.NewHandler(misState.Player.CurPlayer)),
+		headless:       true,
+		recorder:       replay.NewRecorder(mission, seed),
+	}
+}
+
+// Step 推进一帧模拟，不读取任何键鼠输入，返回推进后的任务状态
+// 注：联机对战时，尚未收到对方下一帧的指令则不推进
+func (m *MissionManager) Step() state.MissionStatus {
+	status := m.state.Core.MissionStatus
+	if !missionStatusRunsSimulation(status) || !m.netplayReady() {
+		return status
+	}
+	m.state.Core.Clock.Advance()
+	m.updateCommandPhase()
+	m.updateSupportPhase()
+	m.updateCombatPhase()
+
+	m.state.Core.MissionStatus = m.calcNextStatusByShips(status)
+	return m.state.Core.MissionStatus
+}
+
+// RunHeadless 持续推进模拟直到任务结束或达到最大帧数（maxTicks <= 0 表示不限制）
+func (m *MissionManager) RunHeadless(maxTicks int) HeadlessSummary {
+	ticks := 0
+	for maxTicks <= 0 || ticks < maxTicks {
+		ticks++
+		if status := m.Step(); status == state.MissionSuccess || status == state.MissionFailed {
+			break
+		}
+	}
+	return m.Summary(ticks)
+}
+
+// Summary 汇总当前战况
+func (m *MissionManager) Summary(ticks int) HeadlessSummary {
+	players := map[faction.Player]*HeadlessPlayerSummary{}
+	getOrInit := func(player faction.Player) *HeadlessPlayerSummary {
+		if s, ok := players[player]; ok {
+			return s
+		}
+		players[player] = &HeadlessPlayerSummary{
+			Player:      player,
+			IsCurPlayer: player == m.state.Player.CurPlayer,
+		}
+		return players[player]
+	}
+	for _, player := range faction.AllPlayers {
+		if funds, ok := m.state.FundsOf(player); ok {
+			getOrInit(player).Funds = funds
+		}
+	}
+
+	curHP, totalHP := map[faction.Player]float64{}, map[faction.Player]float64{}
+	for _, ship := range m.state.Arena.Ships {
+		s := getOrInit(ship.BelongPlayer)
+		s.Ships++
+		s.Tonnage += ship.Tonnage
+		curHP[ship.BelongPlayer] += ship.CurHP
+		totalHP[ship.BelongPlayer] += ship.TotalHP
+	}
+	for player, cnt := range m.lostShips {
+		getOrInit(player).LostShips = cnt
+	}
+	for player, cnt := range m.lostPlanes {
+		getOrInit(player).LostPlanes = cnt
+	}
+	for player, s := range players {
+		if totalHP[player] > 0 {
+			s.RemainHPRate = curHP[player] / totalHP[player]
+		}
+	}
+
+	summaries := lo.MapToSlice(players, func(_ faction.Player, s *HeadlessPlayerSummary) HeadlessPlayerSummary {
+		return *s
+	})
+	slices.SortFunc(summaries, func(a, b HeadlessPlayerSummary) int {
+		return strings.Compare(string(a.Player), string(b.Player))
+	})
+	return HeadlessSummary{
+		Mission:    m.state.Core.Mission,
+		Seed:       m.state.Core.Seed,
+		Difficulty: m.state.Core.Difficulty,
+		Status:     m.state.Core.MissionStatus,
+		Ticks:      ticks,
+		Players:    summaries,
+	}
+}
+
+// recordShipLost 记录战舰损失
+func (m *MissionManager) recordShipLost(player faction.Player) {
+	if m.lostShips == nil {
+		m.lostShips = map[faction.Player]int{}
+	}
+	m.lostShips[player]++
+}
+
+// recordPlaneLost 记录战机损失
+func (m *MissionManager) recordPlaneLost(player faction.Player) {
+	if m.lostPlanes == nil {
+		m.lostPlanes = map[faction.Player]int{}
+	}
+	m.lostPlanes[player]++
+}
@@ -0,0 +1,45 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	_ "github.com/narasux/jutland/pkg/mission/object/initialize"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+func TestRunHeadlessStopsAtMaxTicks(t *testing.T) {
+	m := NewHeadless("Midway1942", 42)
+	require.Nil(t, m.drawer)
+	require.Nil(t, m.sidebar)
+	require.Nil(t, m.weaponFirePlayer)
+
+	summary := m.RunHeadless(120)
+	require.Equal(t, "Midway1942", summary.Mission)
+	require.Equal(t, state.MissionRunning, summary.Status)
+	require.Equal(t, 120, summary.Ticks)
+	require.Len(t, summary.Players, 2)
+	require.Equal(t, faction.ComputerAlpha, summary.Players[0].Player)
+	require.Equal(t, faction.HumanAlpha, summary.Players[1].Player)
+	require.True(t, summary.Players[1].IsCurPlayer)
+}
+
+func TestRunHeadlessEndsWhenEnemyEliminated(t *testing.T) {
+	m := NewHeadless("Midway1942", 42)
+	for uid, ship := range m.state.Arena.Ships {
+		if ship.BelongPlayer != m.state.Player.CurPlayer {
+			delete(m.state.Arena.Ships, uid)
+		}
+	}
+	for uid, rp := range m.state.Arena.ReinforcePoints {
+		if rp.BelongPlayer != m.state.Player.CurPlayer {
+			delete(m.state.Arena.ReinforcePoints, uid)
+		}
+	}
+
+	summary := m.RunHeadless(0)
+	require.Equal(t, state.MissionSuccess, summary.Status)
+	require.Equal(t, 1, summary.Ticks)
+}
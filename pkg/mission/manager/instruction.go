@@ -2,6 +2,7 @@ package manager
 
 import (
 	"log"
+	"slices"
 	"sync"
 
 	"github.com/samber/lo"
@@ -71,10 +72,14 @@ func (s *InstructionSet) Exists(uid string) bool {
 }
 
 // ExecAll 执行所有指令
+// 注：按指令 Uid 顺序执行，保证同一种子下指令消耗随机数的顺序可复现
 func (s *InstructionSet) ExecAll(state *state.MissionState) {
 	s.RLock()
 	defer s.RUnlock()
-	for _, i := range s.instructions {
+	uids := lo.Keys(s.instructions)
+	slices.Sort(uids)
+	for _, uid := range uids {
+		i := s.instructions[uid]
 		if err := i.Exec(state); err != nil {
 			log.Printf("Instruction %s exec error: %s\n", i.String(), err)
 		}
@@ -7,14 +7,24 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/samber/lo"
 
+	"github.com/narasux/jutland/pkg/config"
 	"github.com/narasux/jutland/pkg/mission/action"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
 	"github.com/narasux/jutland/pkg/mission/object"
 	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 	"github.com/narasux/jutland/pkg/mission/state"
 	"github.com/narasux/jutland/pkg/utils/magnify"
 )
 
+const (
+	// 双击的最大间隔帧数
+	doubleClickFrames = 18
+	// 单击时鼠标的最大偏移（像素），超过则视为框选
+	clickMaxOffset = 4
+)
+
 // 更新游戏选项
 func (m *MissionManager) updateGameOptions(skipCursorInput bool) {
 	_, wheelY := ebiten.Wheel()
@@ -78,9 +88,46 @@ func (m *MissionManager) consumeZoomInput(wheelY, magnification float64) int {
 func (m *MissionManager) updateInstructions() {
 	// 已经执行完的指令，就不再需要
 	m.instructionSet.RemoveExecuted()
+	// 录像回放：不再读取输入，按帧重新下发录制的指令
+	if m.replayer != nil {
+		for _, i := range m.replayer.Next(m.state.Core.Clock.Ticks) {
+			m.instructionSet.Add(i)
+		}
+		return
+	}
+	// 联机对战：本地指令延迟下发，与对方的指令同步
+	if m.session != nil {
+		m.updateNetplayInstructions()
+		return
+	}
+	// 上一帧界面操作（如增援，集结点）产生的指令
+	m.submitInstructions(m.pendingInstructions)
+	m.pendingInstructions = nil
+	m.submitPlannedInstructions()
 	// 逐个读取各个用户的输入，更新指令
-	m.instructionSet.Assign(m.playerAlphaHandler.Handle(m.instructionSet.Items(), m.state))
-	m.instructionSet.Assign(m.playerBetaHandler.Handle(m.instructionSet.Items(), m.state))
+	for _, handler := range m.playerHandlers {
+		instructions := handler.Handle(m.instructionSet.Items(), m.state)
+		m.dropPlannedInstructions(instructions)
+		m.submitInstructions(instructions)
+	}
+}
+
+// submitInstructions 下发玩家 / 电脑指令，同时写入录像
+func (m *MissionManager) submitInstructions(instructions map[string]instr.Instruction) {
+	if len(instructions) == 0 {
+		return
+	}
+	m.recorder.Record(m.state.Core.Clock.Ticks, instructions)
+	m.instructionSet.Assign(instructions)
+}
+
+// queueInstruction 暂存界面操作产生的指令，在下一帧的指令阶段统一下发
+// 注：界面操作在指令执行之后处理，直接加入指令集会让录像无法还原其生效的帧
+func (m *MissionManager) queueInstruction(i instr.Instruction) {
+	if m.pendingInstructions == nil {
+		m.pendingInstructions = map[string]instr.Instruction{}
+	}
+	m.pendingInstructions[i.Uid()] = i
 }
 
 // 计算下一帧相机位置
@@ -177,42 +224,25 @@ func (m *MissionManager) updateSelectedShips() {
 	if m.state.Core.MissionStatus != state.MissionRunning {
 		return
 	}
-	// 选择一个区域中的所有战舰
-	if area := action.DetectCursorSelectArea(m.state); area != nil {
-		m.state.Interaction.SelectedShips = []string{}
-		for _, ship := range m.state.Arena.Ships {
-			// 被鼠标划区区域选中的我方战舰
-			if ship.BelongPlayer == m.state.Player.CurPlayer && area.Contain(ship.CurPos) {
-				m.state.Interaction.SelectedShips = append(m.state.Interaction.SelectedShips, ship.Uid)
-			}
-		}
-	}
+	m.updateAreaSelection()
+	m.updateSelectionHotkeys()
 	// 正在分组中，不可用
 	if !m.state.Interaction.IsGrouping {
-		// 通过分组选中战舰
+		// 通过分组选中战舰（按住 Shift 时追加到当前选中）
 		groupID := action.GetGroupIDByPressedKey()
 		if groupID != object.GroupIDNone {
-			m.state.Interaction.SelectedShips = m.state.Interaction.SelectedShips[:0]
-			for _, ship := range m.state.Arena.Ships {
-				if ship.BelongPlayer == m.state.Player.CurPlayer && ship.GroupID == groupID {
-					m.state.Interaction.SelectedShips = append(m.state.Interaction.SelectedShips, ship.Uid)
-				}
-			}
-
-			// 如果当前选中的分组不是当前按键的分组，则更新记录
-			if m.state.Interaction.SelectedGroupID != groupID {
+			groupShips := m.state.OwnShipUids(func(ship *objUnit.BattleShip) bool { return ship.GroupID == groupID })
+			if ebiten.IsKeyPressed(ebiten.KeyShift) {
+				m.setSelectedShips(state.ApplySelection(m.state.Interaction.SelectedShips, groupShips, state.SelectAdd))
+			} else if m.state.Interaction.SelectedGroupID != groupID {
+				// 如果当前选中的分组不是当前按键的分组，则更新记录
+				m.state.Interaction.SelectedShips = groupShips
 				m.state.Interaction.SelectedGroupID = groupID
 			} else {
+				m.state.Interaction.SelectedShips = groupShips
 				// 如果当前选中的分组再次被选中，移动相机中心位置到当前分组的第一艘战舰处
-				if len(m.state.Interaction.SelectedShips) > 0 {
-					nextPos := m.state.Arena.Ships[m.state.Interaction.SelectedShips[0]].CurPos.Copy()
-					nextPos.SubMx(m.state.View.Camera.Width / 2)
-					nextPos.SubMy(m.state.View.Camera.Height / 2)
-
-					moveSpeed := m.state.View.Camera.BaseMoveSpeed
-					rx := float64(int(nextPos.RX/moveSpeed)) * moveSpeed
-					ry := float64(int(nextPos.RY/moveSpeed)) * moveSpeed
-					m.state.View.Camera.Pos.AssignRxy(rx, ry)
+				if len(groupShips) > 0 {
+					m.centerCameraOn(m.state.Arena.Ships[groupShips[0]].CurPos)
 				}
 			}
 		}
@@ -233,17 +263,114 @@ func (m *MissionManager) updateSelectedShips() {
 	}
 }
 
-// 更新舰队编组状态（左 Ctrl + 0-9 编组）
+// updateAreaSelection 框选 / 单击选中战舰：按住 Shift 追加，按住 Ctrl 移除，双击己方战舰选中视野内所有同型战舰
+func (m *MissionManager) updateAreaSelection() {
+	wasSelecting := m.state.Interaction.IsAreaSelecting
+	area := action.DetectCursorSelectArea(m.state)
+	if area == nil {
+		return
+	}
+	// 按下鼠标时确定选择方式，拖动过程中按框选开始时的选中战舰合并
+	if !wasSelecting {
+		m.startAreaSelection(area)
+	}
+	var picked []string
+	if m.areaSelectClass != "" {
+		picked = m.state.OwnShipUids(func(ship *objUnit.BattleShip) bool {
+			return ship.Name == m.areaSelectClass && m.state.View.Camera.Contains(ship.CurPos)
+		})
+	} else if max(area.CurX-area.StartX, area.StartX-area.CurX) <= clickMaxOffset &&
+		max(area.CurY-area.StartY, area.StartY-area.CurY) <= clickMaxOffset {
+		// 单击：选中鼠标所在的己方战舰
+		if ship := m.state.ShipAtPos(area.CurAt, m.isOwnShip); ship != nil {
+			picked = []string{ship.Uid}
+		}
+	} else {
+		picked = m.state.OwnShipUids(func(ship *objUnit.BattleShip) bool { return area.Contain(ship.CurPos) })
+	}
+	m.setSelectedShips(state.ApplySelection(m.areaSelectBase, picked, m.areaSelectMode))
+}
+
+// startAreaSelection 开始框选，记录选择方式 & 原有选中的战舰，识别双击
+func (m *MissionManager) startAreaSelection(area *action.SelectedArea) {
+	m.areaSelectMode = state.SelectReplace
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		m.areaSelectMode = state.SelectAdd
+	} else if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		m.areaSelectMode = state.SelectRemove
+	}
+	m.areaSelectBase = slices.Clone(m.state.Interaction.SelectedShips)
+	m.areaSelectClass = ""
+
+	ship := m.state.ShipAtPos(area.StartAt, m.isOwnShip)
+	if ship == nil {
+		m.lastClickShipUid = ""
+		return
+	}
+	if ship.Uid == m.lastClickShipUid && m.frames-m.lastClickFrame <= doubleClickFrames {
+		m.areaSelectClass = ship.Name
+		m.lastClickShipUid = ""
+		return
+	}
+	m.lastClickShipUid, m.lastClickFrame = ship.Uid, m.frames
+}
+
+// updateSelectionHotkeys 选中全部己方战舰，循环选中空闲 / 受损战舰（相机移动到该战舰处）
+func (m *MissionManager) updateSelectionHotkeys() {
+	var candidates []string
+	switch {
+	case action.IsActionJustPressed(config.KeyActionSelectAll):
+		m.setSelectedShips(m.state.OwnShipUids(func(*objUnit.BattleShip) bool { return true }))
+		return
+	case action.IsActionJustPressed(config.KeyActionCycleIdleShips):
+		candidates = m.state.IdleShipUids()
+	case action.IsActionJustPressed(config.KeyActionCycleDamagedShips):
+		candidates = m.state.DamagedShipUids()
+	default:
+		return
+	}
+	uid := state.NextCycledShip(candidates, m.state.Interaction.SelectedShips)
+	if uid == "" {
+		return
+	}
+	m.setSelectedShips([]string{uid})
+	m.centerCameraOn(m.state.Arena.Ships[uid].CurPos)
+}
+
+// setSelectedShips 更新选中的战舰（不再对应某个编组）
+func (m *MissionManager) setSelectedShips(uids []string) {
+	m.state.Interaction.SelectedShips = uids
+	m.state.Interaction.SelectedGroupID = object.GroupIDNone
+}
+
+// isOwnShip 是否为当前玩家的战舰
+func (m *MissionManager) isOwnShip(ship *objUnit.BattleShip) bool {
+	return ship.BelongPlayer == m.state.Player.CurPlayer
+}
+
+// centerCameraOn 移动相机，使指定位置位于视野中心
+func (m *MissionManager) centerCameraOn(pos objPos.MapPos) {
+	nextPos := pos.Copy()
+	nextPos.SubMx(m.state.View.Camera.Width / 2)
+	nextPos.SubMy(m.state.View.Camera.Height / 2)
+
+	moveSpeed := m.state.View.Camera.BaseMoveSpeed
+	rx := float64(int(nextPos.RX/moveSpeed)) * moveSpeed
+	ry := float64(int(nextPos.RY/moveSpeed)) * moveSpeed
+	m.state.View.Camera.Pos.AssignRxy(rx, ry)
+}
+
+// 更新舰队编组状态（编组键（默认左 Ctrl）+ 0-9 编组）
 func (m *MissionManager) updateShipGroups() {
 	if m.state.Core.MissionStatus != state.MissionRunning {
 		return
 	}
-	// 按下左边的 ctrl 键：进入 / 退出编组模式
-	if inpututil.IsKeyJustPressed(ebiten.KeyControlLeft) {
+	// 按下编组键：进入 / 退出编组模式
+	if action.IsActionJustPressed(config.KeyActionGroupModifier) {
 		m.state.Interaction.IsGrouping = !m.state.Interaction.IsGrouping
 	}
-	// 设置编组后，如果松开 ctrl，则退出编组模式
-	if inpututil.IsKeyJustReleased(ebiten.KeyControlLeft) {
+	// 设置编组后，如果松开编组键，则退出编组模式
+	if action.IsActionJustReleased(config.KeyActionGroupModifier) {
 		m.state.Interaction.IsGrouping = false
 	}
 	// 没有在编组模式，直接返回
@@ -268,7 +395,12 @@ func (m *MissionManager) updateShipGroups() {
 
 // 更新终端
 func (m *MissionManager) updateTerminal() {
+	executed := len(m.terminal.History)
 	m.terminal.Update(m.state)
+	// 作弊指令直接修改任务状态，无法录制，只能标记录像
+	if len(m.terminal.History) > executed {
+		m.recorder.MarkCheated()
+	}
 }
 
 // 更新被选中的待增援战舰
@@ -290,10 +422,10 @@ func (m *MissionManager) updateReinforcePoints() {
 	slices.Sort(reinforcePointUIDs)
 	rpIndex := lo.IndexOf(reinforcePointUIDs, m.state.Interaction.SelectedReinforcePointUid)
 
-	// 上下方向键选择增援点
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+	// 上下方向键（可重新绑定）选择增援点
+	if action.IsActionJustPressed(config.KeyActionReinforceNextPoint) {
 		rpIndex++
-	} else if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+	} else if action.IsActionJustPressed(config.KeyActionReinforcePrevPoint) {
 		rpIndex--
 	}
 	uidCount := len(reinforcePointUIDs)
@@ -304,11 +436,11 @@ func (m *MissionManager) updateReinforcePoints() {
 
 	rp := m.state.Arena.ReinforcePoints[rpUID]
 
-	// 左右方向键选择战舰
+	// 左右方向键（可重新绑定）选择战舰
 	shipIndex := rp.CurSelectedShipIndex
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+	if action.IsActionJustPressed(config.KeyActionReinforcePrevShip) {
 		shipIndex--
-	} else if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+	} else if action.IsActionJustPressed(config.KeyActionReinforceNextShip) {
 		shipIndex++
 	}
 	shipCount := len(rp.ProvidedShipNames)
@@ -316,16 +448,14 @@ func (m *MissionManager) updateReinforcePoints() {
 
 	rp.CurSelectedShipIndex = shipIndex
 
-	// 确定增援的战舰
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		rp.Summon(rp.ProvidedShipNames[shipIndex])
+	// 确定增援的战舰（默认 Enter）
+	if action.IsActionJustPressed(config.KeyActionReinforceConfirm) {
+		m.queueInstruction(instr.NewShipSummon(rpUID, rp.ProvidedShipNames[shipIndex]))
 	}
 
-	// 退格键取消最后增援的战舰
-	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
-		if len(rp.OncomingShips) > 0 {
-			rp.OncomingShips = rp.OncomingShips[:len(rp.OncomingShips)-1]
-		}
+	// 取消增援键（默认退格）取消最后增援的战舰
+	if action.IsActionJustPressed(config.KeyActionReinforceCancel) && len(rp.OncomingShips) > 0 {
+		m.queueInstruction(instr.NewCancelSummon(rpUID))
 	}
 
 	// 缩略地图点击设集结点
@@ -364,7 +494,7 @@ func (m *MissionManager) setReinforcePointRallyPos(rp *objBuilding.ReinforcePoin
 		max(min(pos.RX, float64(m.state.Core.MissionMD.MapCfg.Width-1)), 0),
 		max(min(pos.RY, float64(m.state.Core.MissionMD.MapCfg.Height-1)), 0),
 	)
-	rp.SetRallyPos(pos)
+	m.queueInstruction(instr.NewSetRallyPos(rp.Uid, pos))
 }
 
 // 更新集结线显示（游戏模式下点击己方增援点）
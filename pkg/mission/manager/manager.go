@@ -11,6 +11,9 @@ import (
 	"github.com/narasux/jutland/pkg/mission/drawer"
 	"github.com/narasux/jutland/pkg/mission/faction"
 	"github.com/narasux/jutland/pkg/mission/hacker"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/netplay"
+	"github.com/narasux/jutland/pkg/mission/replay"
 	"github.com/narasux/jutland/pkg/mission/sidebar"
 	"github.com/narasux/jutland/pkg/mission/state"
 	mapBlockImg "github.com/narasux/jutland/pkg/resources/images/mapblock"
@@ -27,13 +30,13 @@ const (
 
 // MissionManager 任务管理器
 type MissionManager struct {
-	state                     *state.MissionState
-	drawer                    *drawer.Drawer
-	sidebar                   *sidebar.Panel
-	terminal                  *hacker.Terminal
-	instructionSet            *InstructionSet
-	playerAlphaHandler        controller.InputHandler
-	playerBetaHandler         controller.InputHandler
+	state          *state.MissionState
+	drawer         *drawer.Drawer
+	sidebar        *sidebar.Panel
+	terminal       *hacker.Terminal
+	instructionSet *InstructionSet
+	// 各玩家的输入处理器（当前玩家在前，其余为电脑玩家）
+	playerHandlers            []controller.InputHandler
 	weaponFirePlayer          *audioPlayer.WeaponFire
 	pinchWheelAccum           float64
 	wheelZoomCooldown         int
@@ -43,22 +46,66 @@ type MissionManager struct {
 	mapBlockPrewarmFocusY     int
 	mapBlockPrewarmFocusW     int
 	mapBlockPrewarmFocusH     int
+	// 无界面模式（命令行模拟），不绘制，不播放音效，不读取键鼠输入
+	headless bool
+	// 各玩家损失的战舰 / 战机数量
+	lostShips  map[faction.Player]int
+	lostPlanes map[faction.Player]int
+	// 界面操作产生，待下一帧下发的指令
+	pendingInstructions map[string]instr.Instruction
+	// 战术暂停时下达的指令（按下达顺序分批，恢复后每帧下发一批）
+	plannedInstructions []map[string]instr.Instruction
+	// 框选开始时的选中战舰 & 选择方式，双击己方战舰时为同型战舰名称
+	areaSelectBase  []string
+	areaSelectMode  state.SelectMode
+	areaSelectClass string
+	// 上一次单击的己方战舰 & 界面帧数，用于识别双击
+	lastClickShipUid string
+	lastClickFrame   int64
+	// 界面帧数（战术暂停时任务时钟冻结，界面交互按此计时）
+	frames int64
+	// 录像录制器（回放时为 nil）
+	recorder *replay.Recorder
+	// 录像播放器（非回放时为 nil）
+	replayer     *replay.Player
+	replayPaused bool
+	replaySpeed  int
+	// 联机会话（单机时为 nil）
+	session *netplay.Session
 }
 
-// New 创建任务管理器
-func New(mission string, ui *ebitenui.UI) *MissionManager {
+// New 创建任务管理器，seed 为任务随机种子（相同种子下战斗可复现），difficulty 为电脑 AI 难度
+func New(mission string, ui *ebitenui.UI, seed int64, difficulty state.Difficulty) *MissionManager {
 	magnify.Init()
-	return &MissionManager{
-		state:          state.NewMissionState(mission),
+	misState := state.NewMissionState(mission, seed)
+	m := &MissionManager{
+		state:          misState,
 		drawer:         drawer.NewDrawer(mission),
 		sidebar:        sidebar.New(mission, ui),
 		terminal:       hacker.NewTerminal(),
 		instructionSet: NewInstructionSet(),
-		// 目前用户一只能是人类，用户二是电脑 TODO 支持多人远程联机
-		playerAlphaHandler: human.NewHandler(faction.HumanAlpha),
-		playerBetaHandler:  computer.NewHandler(faction.ComputerAlpha),
-		weaponFirePlayer:   audioPlayer.NewWeaponFire(),
+		// 单机时当前玩家是人类，其余玩家都是电脑（联机对战见 NewNetplay）
+		playerHandlers:   newPlayerHandlers(misState, human.NewHandler(misState.Player.CurPlayer)),
+		weaponFirePlayer: audioPlayer.NewWeaponFire(),
+		recorder:         replay.NewRecorder(mission, seed),
 	}
+	m.UseDifficulty(difficulty)
+	return m
+}
+
+// UseDifficulty 设置电脑 AI 难度（需要在任务开始前设置，录像中同样记录）
+func (m *MissionManager) UseDifficulty(difficulty state.Difficulty) {
+	m.state.Core.Difficulty = difficulty.OrDefault()
+	m.recorder.UseDifficulty(m.state.Core.Difficulty)
+}
+
+// newPlayerHandlers 创建各玩家的输入处理器，当前玩家使用 curHandler，其余参战玩家（中立势力除外）由电脑控制
+func newPlayerHandlers(misState *state.MissionState, curHandler controller.InputHandler) []controller.InputHandler {
+	handlers := []controller.InputHandler{curHandler}
+	for _, player := range misState.ComputerPlayers() {
+		handlers = append(handlers, computer.NewHandler(player))
+	}
+	return handlers
 }
 
 // Draw 绘制任务图像
@@ -79,6 +126,7 @@ func (m *MissionManager) WarmupMapBlocks() bool {
 
 // Update 更新一帧任务状态
 func (m *MissionManager) Update() (state.MissionStatus, error) {
+	m.frames++
 	status := m.state.Core.MissionStatus
 	if status == state.MissionRunning {
 		m.sidebar.Update(m.state)
@@ -101,7 +149,14 @@ func (m *MissionManager) Update() (state.MissionStatus, error) {
 		m.updateCameraPosition()
 	}
 
-	if missionStatusRunsSimulation(status) {
+	// 战术暂停时不推进模拟，仍可选择战舰 & 下达命令；
+	// 联机对战时，尚未收到对方的指令则等待（只允许移动相机）
+	if missionStatusRunsSimulation(status) && m.state.UI.TacticalPaused {
+		m.updateTacticalPause(status)
+	} else if missionStatusRunsSimulation(status) && !m.netplayReady() {
+		m.updateCameraPosition()
+	} else if missionStatusRunsSimulation(status) {
+		m.state.Core.Clock.Advance()
 		m.updateCommandPhase()
 		switch status {
 		case state.MissionRunning, state.MissionInMap:
@@ -124,7 +179,7 @@ func (m *MissionManager) Update() (state.MissionStatus, error) {
 
 	m.updateMissionStatus()
 
-	return m.state.Core.MissionStatus, nil
+	return m.state.Core.MissionStatus, m.netplayErr()
 }
 
 // missionStatusRunsSimulation 判断当前任务状态是否需要继续推进战斗模拟
@@ -137,17 +192,25 @@ func missionStatusRunsSimulation(status state.MissionStatus) bool {
 	}
 }
 
-// updateCommandPhase 更新玩家和电脑指令并执行已就绪指令
+// updateCommandPhase 更新玩家和电脑指令，推进排队命令并执行已就绪指令，最后编队跟随向导舰 & 推进值守任务 & 保持距离
 func (m *MissionManager) updateCommandPhase() {
 	m.updateInstructions()
+	m.updateShipOrders()
 	m.executeInstructions()
+	m.updateFormations()
+	m.updateShipDuties()
+	m.updateStandOff()
 }
 
-// updateSupportPhase 更新标识、建筑和辅助单位效果
+// updateSupportPhase 更新标识、建筑和辅助单位效果，结算燃油，补给弹药 & 燃油，修复战损部件，结算火灾 / 进水
 func (m *MissionManager) updateSupportPhase() {
 	m.updateGameMarks()
 	m.updateBuildings()
 	m.updateHospitalShipHealing()
+	m.updateShipFuel()
+	m.updateShipResupply()
+	m.updateShipRepairs()
+	m.updateShipHazards()
 }
 
 // updateMapBlockPrewarm 分帧预热相机附近场景地图块的缩放缓存，返回当前缩放是否就绪。
@@ -228,9 +291,11 @@ func getAdjacentZooms(zoom int) []int {
 	return nil
 }
 
-// updateCombatPhase 更新武器开火、弹药、尾流和单位消亡状态
+// updateCombatPhase 更新视野、武器开火、弹药、尾流和单位消亡状态
 func (m *MissionManager) updateCombatPhase() {
 	m.weaponFirePlayer.Update()
+	// 只能向视野内的敌人开火 / 出击，需要先更新视野
+	m.state.UpdateVision()
 	m.updateShipWeaponFire()
 	m.updatePlaneAttackOrReturn()
 	m.updatePlaneWeaponFire()
@@ -240,4 +305,5 @@ func (m *MissionManager) updateCombatPhase() {
 	m.updateExplosions()
 	m.updateMissionShips()
 	m.updateMissionPlanes()
+	m.recordNetplayChecksum()
 }
@@ -0,0 +1,133 @@
+package manager
+
+import (
+	"log"
+
+	"github.com/ebitenui/ebitenui"
+
+	audioPlayer "github.com/narasux/jutland/pkg/audio/player"
+	"github.com/narasux/jutland/pkg/mission/controller/computer"
+	"github.com/narasux/jutland/pkg/mission/controller/human"
+	"github.com/narasux/jutland/pkg/mission/drawer"
+	"github.com/narasux/jutland/pkg/mission/hacker"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/netplay"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/sidebar"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/utils/magnify"
+)
+
+// NewNetplay 创建联机对战的任务管理器，本地玩家由人类操控，远程玩家的指令通过联机会话同步
+// 注：调用方需要先应用 session.Hello().Settings 中的游戏设置
+func NewNetplay(session *netplay.Session, ui *ebitenui.UI) *MissionManager {
+	magnify.Init()
+	hello := session.Hello()
+	m := &MissionManager{
+		state:            newNetplayState(session),
+		drawer:           drawer.NewDrawer(hello.Mission),
+		sidebar:          sidebar.New(hello.Mission, ui),
+		terminal:         hacker.NewTerminal(),
+		instructionSet:   NewInstructionSet(),
+		weaponFirePlayer: audioPlayer.NewWeaponFire(),
+		recorder:         replay.NewRecorder(hello.Mission, hello.Seed),
+		session:          session,
+	}
+	m.playerHandlers = newPlayerHandlers(m.state, human.NewHandler(m.state.Player.CurPlayer))
+	m.recorder.UsePlayers(session.LocalPlayer(), session.RemotePlayer())
+	m.recorder.UseDifficulty(m.state.Core.Difficulty)
+	return m
+}
+
+// NewHeadlessNetplay 创建无界面的联机任务管理器，本地玩家也由电脑控制（用于测试联机同步）
+func NewHeadlessNetplay(session *netplay.Session) *MissionManager {
+	hello := session.Hello()
+	m := &MissionManager{
+		state:          newNetplayState(session),
+		instructionSet: NewInstructionSet(),
+		headless:       true,
+		recorder:       replay.NewRecorder(hello.Mission, hello.Seed),
+		session:        session,
+	}
+	m.playerHandlers = newPlayerHandlers(m.state, computer.NewHandler(m.state.Player.CurPlayer))
+	m.recorder.UsePlayers(session.LocalPlayer(), session.RemotePlayer())
+	m.recorder.UseDifficulty(m.state.Core.Difficulty)
+	return m
+}
+
+// newNetplayState 按联机对局信息初始化任务状态（双方初始状态必须完全一致）
+func newNetplayState(session *netplay.Session) *state.MissionState {
+	hello := session.Hello()
+	misState := state.NewMissionState(hello.Mission, hello.Seed)
+	misState.Core.Difficulty = hello.Difficulty.OrDefault()
+	misState.UsePlayer(session.LocalPlayer())
+	misState.Player.RemotePlayer = session.RemotePlayer()
+	return misState
+}
+
+// Netplay 是否为联机对战
+func (m *MissionManager) Netplay() bool {
+	return m.session != nil
+}
+
+// netplayReady 联机对战时，是否已经收到对方下一帧的指令（单机总是就绪）
+func (m *MissionManager) netplayReady() bool {
+	if m.session == nil {
+		return true
+	}
+	ready := m.session.Ready(m.state.Core.Clock.Ticks + 1)
+	m.state.UI.WaitingForPeer = !ready
+	return ready
+}
+
+// netplayErr 联机会话错误（连接断开，对方离开，状态不同步）
+func (m *MissionManager) netplayErr() error {
+	if m.session == nil {
+		return nil
+	}
+	return m.session.Err()
+}
+
+// updateNetplayInstructions 联机对战时更新指令集合：
+// 本地玩家的指令延迟 netplay.InputDelay 帧后与对方的指令一起下发，电脑玩家的指令在两端独立计算（结果一致）
+func (m *MissionManager) updateNetplayInstructions() {
+	tick := m.state.Core.Clock.Ticks
+
+	local := m.pendingInstructions
+	m.pendingInstructions = nil
+	if local == nil {
+		local = map[string]instr.Instruction{}
+	}
+	for uid, i := range m.playerHandlers[0].Handle(m.instructionSet.Items(), m.state) {
+		local[uid] = i
+	}
+	if err := m.session.Send(tick, instr.EncodeAll(local)); err != nil {
+		log.Printf("[ERROR] netplay send frame %d: %s", tick, err)
+	}
+
+	// 按主机，客机的顺序下发本帧双方的指令
+	instructions := map[string]instr.Instruction{}
+	for _, record := range m.session.Take(tick) {
+		i, err := instr.Decode(record)
+		if err != nil {
+			log.Printf("[WARN] netplay skip record %s at tick %d: %s", record.Name, tick, err)
+			continue
+		}
+		instructions[i.Uid()] = i
+	}
+	m.submitInstructions(instructions)
+
+	for _, handler := range m.playerHandlers[1:] {
+		m.submitInstructions(handler.Handle(m.instructionSet.Items(), m.state))
+	}
+}
+
+// recordNetplayChecksum 定期记录任务状态校验值，由联机会话与对方比对
+func (m *MissionManager) recordNetplayChecksum() {
+	if m.session == nil {
+		return
+	}
+	if tick := m.state.Core.Clock.Ticks; tick%netplay.ChecksumInterval == 0 {
+		m.session.RecordChecksum(tick, m.state.Checksum())
+	}
+}
@@ -0,0 +1,79 @@
+package manager
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/netplay"
+	_ "github.com/narasux/jutland/pkg/mission/object/initialize"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// runNetplayPeer 推进联机任务直到指定帧（或任务结束），返回最终的任务状态校验值
+func runNetplayPeer(m *MissionManager, maxTicks int64) (uint64, error) {
+	for m.state.Core.Clock.Ticks < maxTicks {
+		status := m.Step()
+		if err := m.netplayErr(); err != nil {
+			return 0, err
+		}
+		if status == state.MissionSuccess || status == state.MissionFailed {
+			break
+		}
+	}
+	return m.state.Checksum(), nil
+}
+
+// takeOffCount 统计任务中（含已被击沉的）航母累计起飞的战机数量
+func takeOffCount(m *MissionManager) int {
+	count := 0
+	for _, ship := range m.state.Arena.Ships {
+		count += ship.Aircraft.TakeOffCount
+	}
+	for _, ship := range m.state.Arena.DestroyedShips {
+		count += ship.Aircraft.TakeOffCount
+	}
+	return count
+}
+
+func TestNetplayPeersStayInSync(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	// 萨马岛开局双方舰队即在视野内，护航航母会立即放飞战机，校验值需要覆盖战机状态
+	hello, err := netplay.NewHello("Samar1944", 42, state.DifficultyNormal)
+	require.NoError(t, err)
+
+	const maxTicks = 1200
+	type result struct {
+		checksum uint64
+		err      error
+	}
+	hostCh := make(chan result, 1)
+	go func() {
+		session, err := netplay.Host(ln, hello)
+		if err != nil {
+			hostCh <- result{err: err}
+			return
+		}
+		defer session.Close()
+		checksum, err := runNetplayPeer(NewHeadlessNetplay(session), maxTicks)
+		hostCh <- result{checksum, err}
+	}()
+
+	session, err := netplay.Join(ln.Addr().String())
+	require.NoError(t, err)
+	guest := NewHeadlessNetplay(session)
+	require.Equal(t, hello.GuestPlayer, guest.state.Player.CurPlayer)
+	require.Equal(t, hello.HostPlayer, guest.state.Player.RemotePlayer)
+	guestChecksum, err := runNetplayPeer(guest, maxTicks)
+	require.NoError(t, err)
+
+	host := <-hostCh
+	require.NoError(t, host.err)
+	require.Equal(t, host.checksum, guestChecksum)
+	require.Positive(t, takeOffCount(guest))
+	session.Close()
+}
@@ -0,0 +1,120 @@
+package manager
+
+import (
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+const (
+	// 还有后续航点时，距离当前航点多远即转向下一个航点（避免每个航点都停船）
+	orderWaypointReach = 1.5
+	// 攻击命令：距离目标超过射程的该比例时继续逼近
+	orderAttackRangeRate = 0.8
+)
+
+// updateShipOrders 推进战舰排队命令：当前命令完成后，下发下一条命令对应的移动 / 攻击指令
+// 注：排队命令只通过指令修改，推进逻辑只依赖任务状态，录像回放 & 联机对战时两端结果一致，不需要录制
+func (m *MissionManager) updateShipOrders() {
+	ships := m.state.SortedShips()
+	for _, ship := range ships {
+		// 编队跟随舰由编队控制移动
+		if len(ship.Orders) == 0 || (ship.InFormation() && !ship.IsFormationGuide()) {
+			continue
+		}
+		order := &ship.Orders[0]
+		switch order.Type {
+		case objUnit.OrderTypeAttack:
+			m.advanceAttackOrder(ships, ship, order)
+		case objUnit.OrderTypeAttackMove:
+			m.advanceAttackMoveOrder(ships, ship, order)
+		default:
+			m.advanceMoveOrder(ship, order)
+		}
+	}
+}
+
+// advanceMoveOrder 推进航点 / 巡逻命令（以及没有敌情时的攻击移动命令）
+func (m *MissionManager) advanceMoveOrder(ship *objUnit.BattleShip, order *objUnit.ShipOrder) {
+	if m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipMove, ship.Uid)) {
+		// 排队命令在直接下达的移动（如方向键微调）完成后才开始；
+		// 还有后续航点时，接近当前航点即提前转向，否则等待移动指令执行完成
+		if !order.Started || len(ship.Orders) == 1 || !ship.CurPos.Near(order.Pos, orderWaypointReach) {
+			return
+		}
+	}
+
+	if order.Started || ship.CurPos.Near(order.Pos, orderWaypointReach) {
+		ship.FinishOrder()
+		if len(ship.Orders) == 0 || ship.Orders[0].Type == objUnit.OrderTypeAttack {
+			return
+		}
+		order = &ship.Orders[0]
+		// 只剩一个巡逻航点且已经到达，下一帧再处理，避免原地反复下发
+		if ship.CurPos.Near(order.Pos, orderWaypointReach) {
+			return
+		}
+	}
+	order.Started = true
+	m.instructionSet.Add(newShipMoveInstr(ship, order.Pos))
+}
+
+// advanceAttackOrder 推进攻击命令：指定攻击目标并逼近到射程内（或保持距离），目标被击沉或离开视野后命令完成
+func (m *MissionManager) advanceAttackOrder(
+	ships []*objUnit.BattleShip, ship *objUnit.BattleShip, order *objUnit.ShipOrder,
+) {
+	moving := m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipMove, ship.Uid))
+	if !order.Started && moving {
+		return
+	}
+
+	target, ok := m.state.Arena.Ships[order.TargetUid]
+	if !ok || !m.state.CanSee(ship.BelongPlayer, target) {
+		ship.FinishOrder()
+		return
+	}
+	if !order.Started {
+		order.Started = true
+		m.instructionSet.Add(instr.NewShipAttack(ship.Uid, target.Uid))
+	}
+	m.pursueTarget(ships, ship, target, false)
+}
+
+// advanceAttackMoveOrder 推进攻击移动命令：前往航点，途中迎击进入射程附近的可见敌舰（停下追击），
+// 敌舰被击沉或离开后继续前往航点，到达后命令完成
+func (m *MissionManager) advanceAttackMoveOrder(
+	ships []*objUnit.BattleShip, ship *objUnit.BattleShip, order *objUnit.ShipOrder,
+) {
+	moveUid := instr.GenInstrUid(instr.NameShipMove, ship.Uid)
+	if enemy := m.nearestVisibleEnemy(
+		ships, ship, ship.CurPos, ship.Weapon.MaxToShipRange+attackMoveAlertDistance,
+	); enemy != nil {
+		retarget := order.TargetUid != enemy.Uid
+		if retarget {
+			// 遭遇新的敌舰，停止前往航点
+			m.instructionSet.Remove(moveUid)
+		}
+		if ship.AttackTarget != enemy.Uid {
+			m.instructionSet.Add(instr.NewShipAttack(ship.Uid, enemy.Uid))
+		}
+		order.TargetUid, order.Started = enemy.Uid, true
+		m.pursueTarget(ships, ship, enemy, retarget)
+		return
+	}
+	if order.TargetUid != "" {
+		// 迎击结束，继续前往航点
+		order.TargetUid, order.Started = "", true
+		m.instructionSet.Add(newShipMoveInstr(ship, order.Pos))
+		return
+	}
+	// 没有敌情时与航点命令一致
+	m.advanceMoveOrder(ship, order)
+}
+
+// newShipMoveInstr 生成战舰移动指令（可以上岸的单位直线移动，其余寻路）
+func newShipMoveInstr(ship *objUnit.BattleShip, targetPos objPos.MapPos) instr.Instruction {
+	if ship.CanOnLand() {
+		return instr.NewShipMove(ship.Uid, targetPos)
+	}
+	return instr.NewShipMovePath(ship.Uid, ship.CurPos, targetPos, ship.CurSpeed)
+}
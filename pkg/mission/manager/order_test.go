@@ -0,0 +1,122 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+func newOrderTestManager(ships ...*objUnit.BattleShip) *MissionManager {
+	misState := &state.MissionState{
+		Arena: state.MissionArenaState{Ships: map[string]*objUnit.BattleShip{}},
+	}
+	for _, ship := range ships {
+		misState.Arena.Ships[ship.Uid] = ship
+	}
+	return &MissionManager{state: misState, instructionSet: NewInstructionSet()}
+}
+
+// shipMoveTarget 获取战舰当前移动指令的目标位置
+func shipMoveTarget(t *testing.T, m *MissionManager, shipUid string) (objPos.MapPos, bool) {
+	i, ok := m.instructionSet.Items()[instr.GenInstrUid(instr.NameShipMove, shipUid)]
+	if !ok {
+		return objPos.MapPos{}, false
+	}
+	record, err := instr.Encode(i)
+	require.NoError(t, err)
+	return *record.TargetPos, true
+}
+
+func TestShipOrdersAdvanceInQueue(t *testing.T) {
+	ship := &objUnit.BattleShip{Uid: "ship", CurHP: 100, CurPos: objPos.New(5, 5), BelongPlayer: faction.HumanAlpha}
+	ship.EnqueueOrder(objUnit.ShipOrder{Type: objUnit.OrderTypeMove, Pos: objPos.New(20, 5)})
+	ship.EnqueueOrder(objUnit.ShipOrder{Type: objUnit.OrderTypeMove, Pos: objPos.New(20, 20)})
+	m := newOrderTestManager(ship)
+
+	m.updateShipOrders()
+	target, ok := shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.Equal(t, objPos.New(20, 5), target)
+	require.True(t, ship.Orders[0].Started)
+
+	// 航段未完成时不下发新指令
+	m.updateShipOrders()
+	require.Len(t, ship.Orders, 2)
+
+	// 接近航点时提前转向下一个航点
+	ship.CurPos = objPos.New(19, 5)
+	m.updateShipOrders()
+	require.Len(t, ship.Orders, 1)
+	target, ok = shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.Equal(t, objPos.New(20, 20), target)
+
+	// 最后一个航点的移动指令完成后，命令队列清空
+	m.instructionSet.Remove(instr.GenInstrUid(instr.NameShipMove, ship.Uid))
+	m.updateShipOrders()
+	require.Empty(t, ship.Orders)
+	_, ok = shipMoveTarget(t, m, ship.Uid)
+	require.False(t, ok)
+}
+
+func TestShipOrdersWaitForDirectMove(t *testing.T) {
+	ship := &objUnit.BattleShip{Uid: "ship", CurHP: 100, CurPos: objPos.New(5, 5), BelongPlayer: faction.HumanAlpha}
+	ship.EnqueueOrder(objUnit.ShipOrder{Type: objUnit.OrderTypeMove, Pos: objPos.New(20, 5)})
+	m := newOrderTestManager(ship)
+	m.instructionSet.Add(instr.NewShipMove(ship.Uid, objPos.New(6, 5)))
+
+	m.updateShipOrders()
+	require.False(t, ship.Orders[0].Started)
+	target, _ := shipMoveTarget(t, m, ship.Uid)
+	require.Equal(t, objPos.New(6, 5), target)
+}
+
+func TestPatrolOrdersLoop(t *testing.T) {
+	ship := &objUnit.BattleShip{Uid: "ship", CurHP: 100, CurPos: objPos.New(5, 5), BelongPlayer: faction.HumanAlpha}
+	ship.EnqueueOrder(objUnit.ShipOrder{Type: objUnit.OrderTypePatrol, Pos: objPos.New(20, 5)})
+	ship.EnqueueOrder(objUnit.ShipOrder{Type: objUnit.OrderTypePatrol, Pos: objPos.New(5, 20)})
+	m := newOrderTestManager(ship)
+
+	m.updateShipOrders()
+	m.instructionSet.Remove(instr.GenInstrUid(instr.NameShipMove, ship.Uid))
+	m.updateShipOrders()
+
+	// 到达的巡逻航点重新排到队尾
+	require.Len(t, ship.Orders, 2)
+	require.Equal(t, objPos.New(5, 20), ship.Orders[0].Pos)
+	require.Equal(t, objPos.New(20, 5), ship.Orders[1].Pos)
+	require.False(t, ship.Orders[1].Started)
+	target, ok := shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.Equal(t, objPos.New(5, 20), target)
+}
+
+func TestAttackOrderChasesTargetUntilSunk(t *testing.T) {
+	ship := &objUnit.BattleShip{
+		Uid: "ship", CurHP: 100, CurPos: objPos.New(5, 5), BelongPlayer: faction.HumanAlpha,
+		Weapon: objUnit.ShipWeapon{MaxToShipRange: 5},
+	}
+	enemy := &objUnit.BattleShip{Uid: "enemy", CurHP: 100, CurPos: objPos.New(20, 5), BelongPlayer: faction.ComputerAlpha}
+	ship.EnqueueOrder(objUnit.ShipOrder{Type: objUnit.OrderTypeAttack, Pos: enemy.CurPos, TargetUid: enemy.Uid})
+	ship.EnqueueOrder(objUnit.ShipOrder{Type: objUnit.OrderTypeMove, Pos: objPos.New(5, 20)})
+	m := newOrderTestManager(ship, enemy)
+
+	m.updateShipOrders()
+	require.True(t, m.instructionSet.Exists(instr.GenInstrUid(instr.NameShipAttack, ship.Uid)))
+	target, ok := shipMoveTarget(t, m, ship.Uid)
+	require.True(t, ok)
+	require.Equal(t, enemy.CurPos, target)
+
+	// 目标被击沉后继续下一条命令
+	delete(m.state.Arena.Ships, enemy.Uid)
+	m.instructionSet.Remove(instr.GenInstrUid(instr.NameShipMove, ship.Uid))
+	m.updateShipOrders()
+	require.Len(t, ship.Orders, 1)
+	require.Equal(t, objUnit.OrderTypeMove, ship.Orders[0].Type)
+}
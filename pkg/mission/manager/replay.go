@@ -0,0 +1,106 @@
+package manager
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	audioPlayer "github.com/narasux/jutland/pkg/audio/player"
+	"github.com/narasux/jutland/pkg/mission/drawer"
+	"github.com/narasux/jutland/pkg/mission/hacker"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/utils/magnify"
+)
+
+// 回放倍速键位（数字键 1-8 对应 1x-8x）
+var replaySpeedKeys = [...]ebiten.Key{
+	ebiten.KeyDigit1, ebiten.KeyDigit2, ebiten.KeyDigit3, ebiten.KeyDigit4,
+	ebiten.KeyDigit5, ebiten.KeyDigit6, ebiten.KeyDigit7, ebiten.KeyDigit8,
+}
+
+// NewReplay 创建录像回放用的任务管理器
+// 使用录像中的任务与种子初始化全新的任务状态，按帧重新下发录制的指令；
+// 不创建侧边栏，不读取玩家指令输入，只响应回放控制与相机操作
+// 注：调用方需要先通过 Replay.ApplySettings 应用录制时的游戏设置
+func NewReplay(rp *replay.Replay) *MissionManager {
+	magnify.Init()
+	misState := state.NewMissionState(rp.Mission, rp.Seed)
+	misState.Core.Difficulty = rp.Difficulty.OrDefault()
+	// 联机对战的录像：按录制方的视角回放
+	if rp.Player != "" {
+		misState.UsePlayer(rp.Player)
+	}
+	if rp.RemotePlayer != "" {
+		misState.Player.RemotePlayer = rp.RemotePlayer
+	}
+	m := &MissionManager{
+		state:            misState,
+		drawer:           drawer.NewDrawer(rp.Mission),
+		terminal:         hacker.NewTerminal(),
+		instructionSet:   NewInstructionSet(),
+		weaponFirePlayer: audioPlayer.NewWeaponFire(),
+	}
+	m.useReplay(rp)
+	return m
+}
+
+// useReplay 切换为回放模式：不再读取玩家 / 电脑输入，也不再录制
+func (m *MissionManager) useReplay(rp *replay.Replay) {
+	m.playerHandlers = nil
+	m.recorder = nil
+	m.replayer = replay.NewPlayer(rp)
+	m.replaySpeed = 1
+}
+
+// Replay 获取截至当前帧的任务录像（回放模式下为 nil）
+func (m *MissionManager) Replay() *replay.Replay {
+	return m.recorder.Replay(m.state.Core.Clock.Ticks)
+}
+
+// UpdateReplay 更新一帧录像回放，返回录像是否已经播放完毕
+func (m *MissionManager) UpdateReplay() bool {
+	m.updateReplayControls()
+	m.updateGameOptions(false)
+	m.updateCameraPosition()
+	m.updateMapBlockPrewarm()
+
+	if !m.replayPaused {
+		for range m.replaySpeed {
+			if m.ReplayFinished() {
+				break
+			}
+			m.Step()
+		}
+	}
+	return m.ReplayFinished()
+}
+
+// ReplayFinished 录像是否已经播放完毕（到达录制结束帧或已分出胜负）
+func (m *MissionManager) ReplayFinished() bool {
+	return !missionStatusRunsSimulation(m.state.Core.MissionStatus) ||
+		m.replayer.Finished(m.state.Core.Clock.Ticks)
+}
+
+// updateReplayControls 处理回放控制：空格播放 / 暂停，数字键 1-8 切换倍速
+func (m *MissionManager) updateReplayControls() {
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		m.replayPaused = !m.replayPaused
+	}
+	for idx, key := range replaySpeedKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			m.replaySpeed = idx + 1
+		}
+	}
+}
+
+// DrawReplay 绘制录像回放画面
+func (m *MissionManager) DrawReplay(screen *ebiten.Image) {
+	m.drawer.Draw(screen, m.state, m.terminal)
+	m.drawer.DrawReplayStatus(screen, m.state, drawer.ReplayStatus{
+		Paused:   m.replayPaused,
+		Finished: m.ReplayFinished(),
+		Speed:    m.replaySpeed,
+		EndTick:  m.replayer.Replay().EndTick,
+		Cheated:  m.replayer.Replay().Cheated,
+	})
+}
@@ -0,0 +1,47 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/narasux/jutland/pkg/mission/object/initialize"
+)
+
+func TestReplayReproducesHeadlessRun(t *testing.T) {
+	recorded := NewHeadless("Midway1942", 42)
+	expected := recorded.RunHeadless(900)
+	rp := recorded.Replay()
+	require.NotEmpty(t, rp.Frames)
+	require.Equal(t, int64(expected.Ticks), rp.EndTick)
+
+	replayed := NewHeadless("Midway1942", 42)
+	replayed.useReplay(rp)
+	require.Nil(t, replayed.Replay())
+	actual := replayed.RunHeadless(expected.Ticks)
+	require.Equal(t, expected, actual)
+	require.True(t, replayed.ReplayFinished())
+
+	require.Len(t, replayed.state.Arena.Ships, len(recorded.state.Arena.Ships))
+	for uid, ship := range recorded.state.Arena.Ships {
+		other, ok := replayed.state.Arena.Ships[uid]
+		require.True(t, ok, uid)
+		require.Equal(t, ship.CurPos, other.CurPos, uid)
+		require.Equal(t, ship.CurHP, other.CurHP, uid)
+	}
+}
+
+func TestReplayReproducesCarrierStrike(t *testing.T) {
+	// 萨马岛开局双方舰队即在视野内，护航航母会立即放飞战机
+	recorded := NewHeadless("Samar1944", 7)
+	expected := recorded.RunHeadless(1200)
+	require.Positive(t, takeOffCount(recorded))
+	rp := recorded.Replay()
+
+	replayed := NewHeadless("Samar1944", 7)
+	replayed.useReplay(rp)
+	actual := replayed.RunHeadless(expected.Ticks)
+	require.Equal(t, expected, actual)
+	require.Equal(t, takeOffCount(recorded), takeOffCount(replayed))
+	require.Equal(t, recorded.state.Checksum(), replayed.state.Checksum())
+}
@@ -0,0 +1,169 @@
+package manager
+
+import (
+	"log"
+	"slices"
+	"time"
+
+	"github.com/ebitenui/ebitenui"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	audioPlayer "github.com/narasux/jutland/pkg/audio/player"
+	"github.com/narasux/jutland/pkg/mission/controller/human"
+	"github.com/narasux/jutland/pkg/mission/drawer"
+	"github.com/narasux/jutland/pkg/mission/hacker"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/save"
+	"github.com/narasux/jutland/pkg/mission/sidebar"
+	"github.com/narasux/jutland/pkg/mission/state"
+	"github.com/narasux/jutland/pkg/utils/magnify"
+)
+
+// Load 根据任务存档创建任务管理器，从存档时的进度继续任务
+func Load(f *save.File, ui *ebitenui.UI) (*MissionManager, error) {
+	m, err := restore(f)
+	if err != nil {
+		return nil, err
+	}
+	magnify.Init()
+	mission := m.state.Core.Mission
+	m.drawer = drawer.NewDrawer(mission)
+	m.sidebar = sidebar.New(mission, ui)
+	m.terminal = hacker.NewTerminal()
+	m.playerHandlers = newPlayerHandlers(m.state, human.NewHandler(m.state.Player.CurPlayer))
+	m.weaponFirePlayer = audioPlayer.NewWeaponFire()
+	return m, nil
+}
+
+// restore 根据存档恢复任务状态、指令集与录像（不包含界面 / 输入相关组件）
+func restore(f *save.File) (*MissionManager, error) {
+	misState, err := state.RestoreMissionState(f.State)
+	if err != nil {
+		return nil, err
+	}
+	instructions, err := restoreInstructions(f.Instructions)
+	if err != nil {
+		return nil, err
+	}
+	pendingInstructions, err := restoreInstructions(f.PendingInstructions)
+	if err != nil {
+		return nil, err
+	}
+	plannedInstructions := []map[string]instr.Instruction{}
+	for _, snaps := range f.PlannedInstructions {
+		instructions, err := restoreInstructions(snaps)
+		if err != nil {
+			return nil, err
+		}
+		plannedInstructions = append(plannedInstructions, instructions)
+	}
+
+	instructionSet := NewInstructionSet()
+	instructionSet.Assign(instructions)
+	m := &MissionManager{
+		state:               misState,
+		instructionSet:      instructionSet,
+		lostShips:           f.LostShips,
+		lostPlanes:          f.LostPlanes,
+		pendingInstructions: pendingInstructions,
+		plannedInstructions: plannedInstructions,
+		// 存档时的游戏设置与录像不一致时，不再继续录制
+		recorder: replay.ResumeRecorder(f.Replay),
+	}
+	m.refreshPlannedOrders()
+	return m, nil
+}
+
+// SaveFile 生成当前进度的任务存档
+// 注：需要在帧间调用（如暂停时），异步寻路中的指令会等待寻路完成
+func (m *MissionManager) SaveFile() (*save.File, error) {
+	if m.replayer != nil {
+		return nil, errors.New("replay can not be saved")
+	}
+	if m.session != nil {
+		return nil, errors.New("netplay can not be saved")
+	}
+	instructions, err := snapshotInstructions(m.instructionSet.Items())
+	if err != nil {
+		return nil, err
+	}
+	pendingInstructions, err := snapshotInstructions(m.pendingInstructions)
+	if err != nil {
+		return nil, err
+	}
+	plannedInstructions := [][]instr.Snapshot{}
+	for _, instructions := range m.plannedInstructions {
+		snaps, err := snapshotInstructions(instructions)
+		if err != nil {
+			return nil, err
+		}
+		plannedInstructions = append(plannedInstructions, snaps)
+	}
+	return &save.File{
+		Version:             save.Version,
+		SavedAt:             time.Now(),
+		State:               m.state.Snapshot(),
+		Instructions:        instructions,
+		PendingInstructions: pendingInstructions,
+		PlannedInstructions: plannedInstructions,
+		LostShips:           m.lostShips,
+		LostPlanes:          m.lostPlanes,
+		Replay:              m.Replay(),
+	}, nil
+}
+
+// Save 保存当前进度到任务存档文件，返回存档路径
+func (m *MissionManager) Save() (string, error) {
+	f, err := m.SaveFile()
+	if err != nil {
+		return "", err
+	}
+	path := save.FilePath(m.state.Core.Mission)
+	return path, save.Save(path, f)
+}
+
+// snapshotInstructions 按 Uid 顺序获取未执行完的指令快照
+func snapshotInstructions(instructions map[string]instr.Instruction) ([]instr.Snapshot, error) {
+	uids := lo.Keys(instructions)
+	slices.Sort(uids)
+
+	snapshots := []instr.Snapshot{}
+	for _, uid := range uids {
+		if instructions[uid].Executed() {
+			continue
+		}
+		snap, err := instr.TakeSnapshot(instructions[uid])
+		if err != nil {
+			return nil, errors.Wrapf(err, "snapshot instruction %s", uid)
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, nil
+}
+
+// restoreInstructions 根据快照重建指令
+func restoreInstructions(snapshots []instr.Snapshot) (map[string]instr.Instruction, error) {
+	instructions := map[string]instr.Instruction{}
+	for _, snap := range snapshots {
+		i, err := instr.RestoreSnapshot(snap)
+		if err != nil {
+			return nil, errors.Wrapf(err, "restore instruction %s", snap.Name)
+		}
+		instructions[i.Uid()] = i
+	}
+	return instructions, nil
+}
+
+// saveProgress 在暂停面板中保存当前进度，并记录保存结果用于面板提示
+func (m *MissionManager) saveProgress() {
+	path, err := m.Save()
+	if err != nil {
+		log.Printf("[ERROR] Failed to save mission: %v", err)
+		m.state.UI.PauseSaveStatus = state.PauseSaveFailed
+		return
+	}
+	log.Printf("[INFO] Mission saved: %s", path)
+	m.state.UI.PauseSaveStatus = state.PauseSaveSucceeded
+}
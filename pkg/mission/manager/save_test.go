@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/controller/computer"
+	_ "github.com/narasux/jutland/pkg/mission/object/initialize"
+	"github.com/narasux/jutland/pkg/mission/save"
+)
+
+func TestSaveAndLoadHeadlessRun(t *testing.T) {
+	saved := NewHeadless("Midway1942", 42)
+	saved.RunHeadless(900)
+	f, err := saved.SaveFile()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "Midway1942"+save.FileExt)
+	require.NoError(t, save.Save(path, f))
+	f, err = save.Load(path)
+	require.NoError(t, err)
+
+	loaded, err := restore(f)
+	require.NoError(t, err)
+	loaded.headless = true
+	loaded.playerHandlers = newPlayerHandlers(loaded.state, computer.NewHandler(loaded.state.Player.CurPlayer))
+
+	require.Equal(t, saved.state.Core.Clock, loaded.state.Core.Clock)
+	require.Equal(t, saved.state.Player, loaded.state.Player)
+	require.Equal(t, saved.Summary(900), loaded.Summary(900))
+	require.Len(t, loaded.state.Arena.Ships, len(saved.state.Arena.Ships))
+	for uid, ship := range saved.state.Arena.Ships {
+		other, ok := loaded.state.Arena.Ships[uid]
+		require.True(t, ok, uid)
+		require.Equal(t, ship.CurPos, other.CurPos, uid)
+		require.Equal(t, ship.CurHP, other.CurHP, uid)
+		require.Equal(t, ship.Aircraft, other.Aircraft, uid)
+	}
+	require.Len(t, loaded.state.Arena.Planes, len(saved.state.Arena.Planes))
+	for uid, plane := range saved.state.Arena.Planes {
+		other, ok := loaded.state.Arena.Planes[uid]
+		require.True(t, ok, uid)
+		require.Equal(t, plane.FlightPhase, other.FlightPhase, uid)
+		require.Equal(t, plane.CurPos, other.CurPos, uid)
+	}
+	require.Len(t, loaded.instructionSet.Items(), len(f.Instructions))
+	// 随机数源从存档位置继续
+	require.Equal(t, saved.state.Rand().Uint64(), loaded.state.Rand().Uint64())
+
+	// 读档后可以继续模拟 & 录制
+	loaded.RunHeadless(300)
+	require.Equal(t, int64(1200), loaded.Replay().EndTick)
+}
@@ -6,54 +6,63 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/narasux/jutland/pkg/audio"
+	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/action"
 	"github.com/narasux/jutland/pkg/mission/state"
 	audioRes "github.com/narasux/jutland/pkg/resources/audio"
 )
 
-// 计算下一帧任务状态
-func (m *MissionManager) updateMissionStatus() {
-	calcNextStatusByShips := func(curStatus state.MissionStatus) state.MissionStatus {
-		// 还有战舰在沉没，游戏继续
-		if len(m.state.Arena.DestroyedShips) != 0 {
-			return curStatus
-		}
-		// 检查所有战舰，判定胜利 / 失败
-		anySelfShip, anyEnemyShip := false, false
-		for _, ship := range m.state.Arena.Ships {
-			if ship.BelongPlayer == m.state.Player.CurPlayer {
-				anySelfShip = true
-			} else {
-				anyEnemyShip = true
-			}
-		}
-		// 自己的船都没了，失败
-		if !anySelfShip && len(m.state.Arena.DestroyedShips) == 0 {
-			return state.MissionFailed
-		}
-		// 敌人都不存在，胜利
-		if !anyEnemyShip && len(m.state.Arena.DestroyedShips) == 0 {
-			return state.MissionSuccess
-		}
+// calcNextStatusByShips 根据敌我（按同盟划分）存活战舰判定胜利 / 失败，不读取任何输入
+func (m *MissionManager) calcNextStatusByShips(curStatus state.MissionStatus) state.MissionStatus {
+	// 还有战舰在沉没，游戏继续
+	if len(m.state.Arena.DestroyedShips) != 0 {
 		return curStatus
 	}
+	// 检查所有战舰，判定胜利 / 失败（中立势力的船不参与判定）
+	curPlayer := m.state.Player.CurPlayer
+	anySelfShip, anyEnemyShip := false, false
+	for _, ship := range m.state.Arena.Ships {
+		if m.state.IsAlly(curPlayer, ship.BelongPlayer) {
+			anySelfShip = true
+		} else if m.state.IsEnemy(curPlayer, ship.BelongPlayer) {
+			anyEnemyShip = true
+		}
+	}
+	// 自己及友军的船都没了，失败
+	if !anySelfShip {
+		return state.MissionFailed
+	}
+	// 敌对势力都不存在，胜利
+	if !anyEnemyShip {
+		return state.MissionSuccess
+	}
+	return curStatus
+}
 
+// 计算下一帧任务状态
+func (m *MissionManager) updateMissionStatus() {
 	switch m.state.Core.MissionStatus {
 	case state.MissionRunning:
 		// 暂停游戏
-		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		if action.IsActionJustPressed(config.KeyActionPause) {
 			m.state.Core.MissionStatus = state.MissionPaused
 			m.state.Core.ConfirmQuitMission = false
+			m.state.UI.PauseSaveStatus = state.PauseSaveNone
 		}
-		m.state.Core.MissionStatus = calcNextStatusByShips(m.state.Core.MissionStatus)
+		// 战术暂停 / 继续（联机对战不可用，双方需要同步推进）
+		if m.session == nil && action.IsActionJustPressed(config.KeyActionTacticalPause) {
+			m.state.UI.TacticalPaused = !m.state.UI.TacticalPaused
+		}
+		m.state.Core.MissionStatus = m.calcNextStatusByShips(m.state.Core.MissionStatus)
 	case state.MissionPaused:
 		if m.state.UI.DebugFlags.IsActive() {
-			// debug 模式下跳过确认面板，Q 直接退出，Esc 直接继续
-			if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+			// debug 模式下跳过确认面板，放弃任务键（默认 q）直接退出，暂停键（默认 Esc）直接继续
+			if action.IsActionJustPressed(config.KeyActionQuitMission) {
 				m.state.Core.MissionStatus = state.MissionFailed
 				m.state.Core.ConfirmQuitMission = true
 				return
 			}
-			if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			if action.IsActionJustPressed(config.KeyActionPause) {
 				m.state.Core.MissionStatus = state.MissionRunning
 				m.state.Core.ConfirmQuitMission = false
 				return
@@ -61,10 +70,16 @@ func (m *MissionManager) updateMissionStatus() {
 			return
 		}
 
+		// 按下保存键（默认 s），保存当前进度（确认放弃任务时不可用）
+		if !m.state.Core.ConfirmQuitMission && action.IsActionJustPressed(config.KeyActionSaveProgress) {
+			m.saveProgress()
+			return
+		}
+
 		input := state.PauseInputNone
-		if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+		if action.IsActionJustPressed(config.KeyActionQuitMission) {
 			input = state.PauseInputQuit
-		} else if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		} else if action.IsActionJustPressed(config.KeyActionPause) {
 			input = state.PauseInputResume
 		} else if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 			sx, sy := ebiten.CursorPosition()
@@ -83,27 +98,27 @@ func (m *MissionManager) updateMissionStatus() {
 		return
 	case state.MissionInMap:
 		// 退出全屏地图模式
-		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		if action.IsActionJustPressed(config.KeyActionBack) {
 			m.state.Core.MissionStatus = state.MissionRunning
 		}
-		m.state.Core.MissionStatus = calcNextStatusByShips(m.state.Core.MissionStatus)
+		m.state.Core.MissionStatus = m.calcNextStatusByShips(m.state.Core.MissionStatus)
 	case state.MissionInTerminal:
 		// 退出终端模式
-		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		if action.IsActionJustPressed(config.KeyActionBack) {
 			m.state.Core.MissionStatus = state.MissionRunning
 		}
 		return
 	case state.MissionInBuilding:
 		// 退出建筑物交互模式
-		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		if action.IsActionJustPressed(config.KeyActionBack) {
 			m.state.Core.MissionStatus = state.MissionRunning
 		}
 	default:
 		m.state.Core.MissionStatus = state.MissionRunning
 	}
 
-	// 按下 m 键，切换地图展示模式
-	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+	// 按下地图键（默认 m），切换地图展示模式
+	if action.IsActionJustPressed(config.KeyActionMap) {
 		m.state.Core.MissionStatus = lo.Ternary(
 			m.state.Core.MissionStatus != state.MissionInMap,
 			state.MissionInMap,
@@ -111,8 +126,8 @@ func (m *MissionManager) updateMissionStatus() {
 		)
 	}
 
-	// 按下 b 键，开启查看增援点模式
-	if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+	// 按下增援键（默认 b），开启查看增援点模式
+	if action.IsActionJustPressed(config.KeyActionReinforce) {
 		m.state.Core.MissionStatus = lo.Ternary(
 			m.state.Core.MissionStatus != state.MissionInBuilding,
 			state.MissionInBuilding,
@@ -120,13 +135,15 @@ func (m *MissionManager) updateMissionStatus() {
 		)
 	}
 
-	// 按下 LeftCtrl，LeftShift 的同时按下 ` 键开启终端
-	if ebiten.IsKeyPressed(ebiten.KeyControlLeft) &&
-		ebiten.IsKeyPressed(ebiten.KeyShiftLeft) &&
-		inpututil.IsKeyJustPressed(ebiten.KeyBackquote) {
+	// 按住编组键（默认左 Ctrl）& 终端修饰键（默认左 Shift）的同时按下终端键（默认 `）开启终端
+	// （联机对战不可用，作弊会导致双方不同步）
+	if m.session == nil &&
+		action.IsActionPressed(config.KeyActionGroupModifier) &&
+		action.IsActionPressed(config.KeyActionTerminalModifier) &&
+		action.IsActionJustPressed(config.KeyActionTerminal) {
 		m.state.Core.MissionStatus = state.MissionInTerminal
 		audio.PlayAudioToEnd(audioRes.NewCheating())
-		// 进入终端会按下 ctrl，此时会导致进入编组模式，需要强制退出下
+		// 进入终端会按下编组键，此时会导致进入编组模式，需要强制退出下
 		m.state.Interaction.IsGrouping = false
 	}
 }
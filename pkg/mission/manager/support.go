@@ -2,12 +2,13 @@ package manager
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
 
 	"github.com/samber/lo"
 
+	"github.com/narasux/jutland/pkg/i18n"
+	"github.com/narasux/jutland/pkg/mission/clock"
 	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
 	objMark "github.com/narasux/jutland/pkg/mission/object/mark"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
 	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
@@ -34,19 +35,20 @@ func (m *MissionManager) updateGameMarks() {
 // 更新建筑物
 func (m *MissionManager) updateBuildings() {
 	// 增援点当然算是建筑物！
-	for _, rp := range m.state.Arena.ReinforcePoints {
+	// 按固定顺序遍历，保证同一种子下集结散开位置可复现
+	for _, rp := range m.state.SortedReinforcePoints() {
+		// 每个玩家（含电脑玩家）都使用自己的资金，没有资金的玩家无法增援
+		funds, _ := m.state.FundsOf(rp.BelongPlayer)
 		if ship := rp.Update(
 			m.state.Arena.ShipUidGenerators[rp.BelongPlayer],
-			// FIXME 目前电脑玩家先不限制金钱
-			lo.Ternary(rp.BelongPlayer == m.state.Player.CurPlayer, m.state.Player.CurFunds, 50000),
+			funds,
+			m.state.Core.Clock.Now(),
 		); ship != nil {
 			m.state.Arena.Ships[ship.Uid] = ship
-			if rp.BelongPlayer == m.state.Player.CurPlayer {
-				fundsCost, _ := objUnit.GetShipCost(ship.Name)
-				m.state.Player.CurFunds -= fundsCost
-			}
+			fundsCost, _ := objUnit.GetShipCost(ship.Name)
+			m.state.AddFunds(rp.BelongPlayer, -fundsCost)
 			// 战舰移动到集结点 & 随机散开 [-3, 3] 的范围（通过 ShipMove 指令实现）
-			x, y := rand.Intn(7)-3, rand.Intn(7)-3
+			x, y := m.state.Rand().IntN(7)-3, m.state.Rand().IntN(7)-3
 			targetPos := objPos.New(rp.RallyPos.MX+x, rp.RallyPos.MY+y)
 			m.instructionSet.Add(instr.NewShipMove(ship.Uid, targetPos))
 		}
@@ -57,7 +59,10 @@ func (m *MissionManager) updateBuildings() {
 	for _, op := range m.state.Arena.OilPlatforms {
 		text := fmt.Sprintf("+%d $", op.Yield)
 		for _, ship := range m.state.Arena.Ships {
-			if ship.Type != objUnit.ShipTypeCargo || ship.BelongPlayer != m.state.Player.CurPlayer {
+			if ship.Type != objUnit.ShipTypeCargo {
+				continue
+			}
+			if _, ok := m.state.FundsOf(ship.BelongPlayer); !ok {
 				continue
 			}
 
@@ -74,33 +79,38 @@ func (m *MissionManager) updateBuildings() {
 			cargo, ok := m.state.Arena.Ships[uid]
 			if !ok {
 				op.RemoveShip(uid)
-			} else if cargo.BelongPlayer == m.state.Player.CurPlayer && ship.Update() {
-				m.state.Player.CurFunds += int64(ship.FundYield)
-				mark := objMark.NewText(cargo.CurPos, text, fontSize, colorx.Gold, 50)
-				m.state.UI.GameMarks[mark.ID] = mark
+				continue
+			}
+			if ship.Update(m.state.Core.Clock.Now()) {
+				m.state.AddFunds(cargo.BelongPlayer, int64(ship.FundYield))
+				// 收益文字只展示己方 & 友军货轮的，避免暴露迷雾中的敌方货轮
+				if m.state.IsAlly(cargo.BelongPlayer, m.state.Player.CurPlayer) {
+					mark := objMark.NewText(cargo.CurPos, text, fontSize, colorx.Gold, 50)
+					m.state.UI.GameMarks[mark.ID] = mark
+				}
 			}
 		}
 	}
 }
 
 // 更新医疗船治疗逻辑
-// 医疗船自动治疗范围内同阵营战舰（含自身），显示绿色浮动文字
-// 注意：治疗间隔不受 config.G.SpeedMultiplier 影响，使用 time.Now() 而非帧计数
+// 医疗船自动治疗范围内己方 & 友军战舰（含自身），显示绿色浮动文字
+// 注意：治疗间隔使用任务时钟，与装填等计时一样受 config.G.SpeedMultiplier 影响，暂停时冻结
 func (m *MissionManager) updateHospitalShipHealing() {
-	now := time.Now().UnixMilli()
+	now := m.state.Core.Clock.Now()
 	for _, ship := range m.state.Arena.Ships {
 		// 只有存活的医疗船才能治疗
 		if ship.Type != objUnit.ShipTypeHospital || ship.CurHP <= 0 {
 			continue
 		}
 		// 检查距上次治疗是否 ≥ 5000ms（5 秒固定间隔）
-		if now-ship.LastHealAt < 5000 {
+		if clock.Since(now, ship.LastHealAt) < 5000 {
 			continue
 		}
-		// 遍历同阵营战舰目标
+		// 遍历己方 & 友军战舰目标
 		for _, target := range m.state.Arena.Ships {
-			// 目标必须是同阵营、存活且未满血
-			if target.BelongPlayer != ship.BelongPlayer || target.CurHP <= 0 || target.CurHP >= target.TotalHP {
+			// 目标必须是己方或友军、存活且未满血
+			if !m.state.IsAlly(ship.BelongPlayer, target.BelongPlayer) || target.CurHP <= 0 || target.CurHP >= target.TotalHP {
 				continue
 			}
 			// 检查目标是否在治疗范围内
@@ -119,3 +129,69 @@ func (m *MissionManager) updateHospitalShipHealing() {
 		ship.LastHealAt = now
 	}
 }
+
+// updateShipFuel 结算战舰航行消耗的燃油（关卡未启用燃油时只清空航行距离）
+func (m *MissionManager) updateShipFuel() {
+	enabled := m.state.Core.MissionMD.ShipFuel
+	for _, ship := range m.state.Arena.Ships {
+		ship.SettleFuel(enabled)
+	}
+}
+
+// updateShipResupply 己方增援点 / 友军货轮附近的战舰定期补给弹药 & 燃油，显示浮动文字
+func (m *MissionManager) updateShipResupply() {
+	now := m.state.Core.Clock.Now()
+	ships := m.state.SortedShips()
+	reinforcePoints := m.state.SortedReinforcePoints()
+	cargos := lo.Filter(ships, func(ship *objUnit.BattleShip, _ int) bool {
+		return ship.Type == objUnit.ShipTypeCargo && ship.CurHP > 0
+	})
+	for _, ship := range ships {
+		if ship.CurHP <= 0 {
+			continue
+		}
+		atReinforcePoint := lo.ContainsBy(reinforcePoints, func(rp *objBuilding.ReinforcePoint) bool {
+			return rp.BelongPlayer == ship.BelongPlayer && rp.Pos.Near(ship.CurPos, objUnit.ResupplyRange)
+		})
+		nearCargo := lo.ContainsBy(cargos, func(cargo *objUnit.BattleShip) bool {
+			return cargo.Uid != ship.Uid && m.state.IsAlly(cargo.BelongPlayer, ship.BelongPlayer) &&
+				cargo.CurPos.Near(ship.CurPos, objUnit.ResupplyRange)
+		})
+		if !atReinforcePoint && !nearCargo {
+			continue
+		}
+		// 补给文字只展示己方 & 友军战舰的，避免暴露迷雾中的敌方战舰
+		if ship.Resupply(now, atReinforcePoint) && m.state.IsAlly(ship.BelongPlayer, m.state.Player.CurPlayer) {
+			mark := objMark.NewText(ship.CurPos, i18n.Text(i18n.MsgShipResupplied), 20, colorx.Gold, 50)
+			m.state.UI.GameMarks[mark.ID] = mark
+		}
+	}
+}
+
+// updateShipRepairs 战舰自行修复战损的武器、引擎 & 舵机
+func (m *MissionManager) updateShipRepairs() {
+	now := m.state.Core.Clock.Now()
+	for _, ship := range m.state.Arena.Ships {
+		if ship.CurHP > 0 {
+			ship.RepairComponents(now)
+		}
+	}
+}
+
+// updateShipHazards 结算战舰火灾 / 进水的持续伤害（医疗船范围内加速扑灭 / 堵漏）
+func (m *MissionManager) updateShipHazards() {
+	now := m.state.Core.Clock.Now()
+	hospitals := []*objUnit.BattleShip{}
+	for _, ship := range m.state.Arena.Ships {
+		if ship.Type == objUnit.ShipTypeHospital && ship.CurHP > 0 {
+			hospitals = append(hospitals, ship)
+		}
+	}
+	for _, ship := range m.state.Arena.Ships {
+		assisted := lo.ContainsBy(hospitals, func(hospital *objUnit.BattleShip) bool {
+			return m.state.IsAlly(hospital.BelongPlayer, ship.BelongPlayer) &&
+				hospital.CurPos.Near(ship.CurPos, objUnit.HospitalShipEffectRange)
+		})
+		ship.UpdateHazards(now, assisted)
+	}
+}
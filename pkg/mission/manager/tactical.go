@@ -0,0 +1,154 @@
+package manager
+
+import (
+	"slices"
+
+	"github.com/samber/lo"
+
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// updateTacticalPause 战术暂停时的一帧：模拟不推进（电脑玩家同样不行动），
+// 只读取当前玩家的输入 & 更新相机与选中的战舰，下达的指令暂存到恢复后依次生效
+func (m *MissionManager) updateTacticalPause(status state.MissionStatus) {
+	if status == state.MissionRunning {
+		m.planInstructions(m.playerHandlers[0].Handle(m.instructionSet.Items(), m.state))
+	}
+	switch status {
+	case state.MissionRunning, state.MissionInMap:
+		m.updateCameraPosition()
+	case state.MissionInBuilding:
+		m.updateReinforcePoints()
+	}
+	m.updateMapBlockPrewarm()
+	if status == state.MissionRunning {
+		if !m.state.UI.SidebarConsumesCursor {
+			m.updateSelectedShips()
+		} else {
+			m.state.Interaction.IsAreaSelecting = false
+		}
+		m.updateShipGroups()
+	}
+}
+
+// planInstructions 暂存战术暂停时下达的一批指令（与上一批完全相同时忽略，如持续按住方向键）
+// 注：同一战舰的同类指令 Uid 相同（如连续追加航点），因此按批暂存，恢复后每帧下发一批
+func (m *MissionManager) planInstructions(instructions map[string]instr.Instruction) {
+	if len(instructions) == 0 {
+		return
+	}
+	if n := len(m.plannedInstructions); n != 0 && sameInstructions(m.plannedInstructions[n-1], instructions) {
+		return
+	}
+	m.plannedInstructions = append(m.plannedInstructions, instructions)
+	m.refreshPlannedOrders()
+}
+
+// submitPlannedInstructions 下发一批战术暂停时暂存的指令
+func (m *MissionManager) submitPlannedInstructions() {
+	if len(m.plannedInstructions) == 0 {
+		return
+	}
+	m.submitInstructions(m.plannedInstructions[0])
+	m.plannedInstructions = m.plannedInstructions[1:]
+	m.refreshPlannedOrders()
+}
+
+// dropPlannedInstructions 恢复后玩家重新下达的指令优先：丢弃同 Uid 的暂存指令，
+// 以及收到新命令 / 设置的战舰尚未下发的暂存指令，避免在之后的帧覆盖新指令
+func (m *MissionManager) dropPlannedInstructions(instructions map[string]instr.Instruction) {
+	if len(m.plannedInstructions) == 0 || len(instructions) == 0 {
+		return
+	}
+	orderedShips := map[string]bool{}
+	for _, i := range instructions {
+		if shipUid, ok := previewShipUid(i); ok {
+			orderedShips[shipUid] = true
+		}
+	}
+	remaining := []map[string]instr.Instruction{}
+	for _, batch := range m.plannedInstructions {
+		batch = lo.OmitBy(batch, func(uid string, i instr.Instruction) bool {
+			if _, ok := instructions[uid]; ok {
+				return true
+			}
+			shipUid, ok := previewShipUid(i)
+			return ok && orderedShips[shipUid]
+		})
+		if len(batch) != 0 {
+			remaining = append(remaining, batch)
+		}
+	}
+	m.plannedInstructions = remaining
+	m.refreshPlannedOrders()
+}
+
+// refreshPlannedOrders 根据暂存的指令，按下达顺序计算各战舰尚未生效的命令 & 设置
+func (m *MissionManager) refreshPlannedOrders() {
+	if len(m.plannedInstructions) == 0 {
+		m.state.UI.PlannedOrders = nil
+		return
+	}
+	planned := map[string]*state.PlannedShipOrders{}
+	plannedOf := func(shipUid string) *state.PlannedShipOrders {
+		if planned[shipUid] == nil {
+			planned[shipUid] = &state.PlannedShipOrders{}
+		}
+		return planned[shipUid]
+	}
+	for _, instructions := range m.plannedInstructions {
+		uids := lo.Keys(instructions)
+		slices.Sort(uids)
+		for _, uid := range uids {
+			switch previewer := instructions[uid].(type) {
+			case instr.OrderPreviewer:
+				shipUid, order, queued := previewer.PreviewOrder()
+				orders := plannedOf(shipUid)
+				if !queued {
+					orders.Replace, orders.Orders = true, nil
+				}
+				orders.Orders = append(orders.Orders, order)
+			case instr.SettingPreviewer:
+				shipUid, apply := previewer.PreviewSetting()
+				ship, ok := m.state.Arena.Ships[shipUid]
+				if !ok {
+					continue
+				}
+				orders := plannedOf(shipUid)
+				if orders.Settings == nil {
+					orders.Settings = lo.ToPtr(ship.Settings())
+				}
+				apply(orders.Settings)
+			}
+		}
+	}
+	m.state.UI.PlannedOrders = planned
+}
+
+// previewShipUid 可预览的命令 / 设置所属的战舰
+func previewShipUid(i instr.Instruction) (string, bool) {
+	switch previewer := i.(type) {
+	case instr.OrderPreviewer:
+		shipUid, _, _ := previewer.PreviewOrder()
+		return shipUid, true
+	case instr.SettingPreviewer:
+		shipUid, _ := previewer.PreviewSetting()
+		return shipUid, true
+	}
+	return "", false
+}
+
+// sameInstructions 两批指令是否完全相同（Uid & 描述一致）
+func sameInstructions(a, b map[string]instr.Instruction) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for uid, i := range a {
+		other, ok := b[uid]
+		if !ok || other.String() != i.String() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+func TestPlannedInstructionsSubmitOneBatchPerTick(t *testing.T) {
+	ship := &objUnit.BattleShip{Uid: "ship", CurHP: 100, CurPos: objPos.New(5, 5), BelongPlayer: faction.HumanAlpha}
+	m := newOrderTestManager(ship)
+	batch := func(i instr.Instruction) map[string]instr.Instruction {
+		return map[string]instr.Instruction{i.Uid(): i}
+	}
+
+	// 同一战舰连续追加的航点 Uid 相同，分批暂存；与上一批相同的指令（持续按键）被忽略
+	m.planInstructions(batch(instr.NewShipMove(ship.Uid, objPos.New(10, 5))))
+	m.planInstructions(batch(instr.NewShipMove(ship.Uid, objPos.New(10, 5))))
+	m.planInstructions(batch(instr.NewShipEnqueueOrder(ship.Uid, objUnit.OrderTypeMove, objPos.New(10, 10), "")))
+	m.planInstructions(batch(instr.NewShipEnqueueOrder(ship.Uid, objUnit.OrderTypeMove, objPos.New(5, 10), "")))
+	m.planInstructions(nil)
+	require.Len(t, m.plannedInstructions, 3)
+
+	planned := m.state.UI.PlannedOrders[ship.Uid]
+	require.NotNil(t, planned)
+	require.True(t, planned.Replace)
+	require.Equal(t, []objUnit.ShipOrder{
+		{Type: objUnit.OrderTypeMove, Pos: objPos.New(10, 5)},
+		{Type: objUnit.OrderTypeMove, Pos: objPos.New(10, 10)},
+		{Type: objUnit.OrderTypeMove, Pos: objPos.New(5, 10)},
+	}, planned.Orders)
+
+	// 恢复后每帧下发一批，预览同步减少
+	m.submitPlannedInstructions()
+	require.Contains(t, m.instructionSet.Items(), instr.GenInstrUid(instr.NameShipMove, ship.Uid))
+	require.False(t, m.state.UI.PlannedOrders[ship.Uid].Replace)
+	require.Len(t, m.state.UI.PlannedOrders[ship.Uid].Orders, 2)
+
+	m.submitPlannedInstructions()
+	m.submitPlannedInstructions()
+	require.Empty(t, m.plannedInstructions)
+	require.Nil(t, m.state.UI.PlannedOrders)
+}
+
+func TestPlannedSettingsToggleFromLastPlannedValue(t *testing.T) {
+	ship := &objUnit.BattleShip{
+		Uid: "ship", CurHP: 100, CurPos: objPos.New(5, 5), BelongPlayer: faction.HumanAlpha,
+		EngineOrder: objUnit.EngineOrderStop,
+	}
+	m := newOrderTestManager(ship)
+	// 与输入处理器一致：按战舰设置（包含尚未生效的设置）决定启用还是禁用
+	pressToggle := func() {
+		var i instr.Instruction = instr.NewDisableWeapon(ship.Uid, objUnit.WeaponTypeMainGun)
+		if settings := m.state.ShipSettings(ship); settings.WeaponDisabled(objUnit.WeaponTypeMainGun) {
+			i = instr.NewEnableWeapon(ship.Uid, objUnit.WeaponTypeMainGun)
+		}
+		m.planInstructions(map[string]instr.Instruction{i.Uid(): i})
+	}
+	pressFaster := func() {
+		i := instr.NewShipEngineOrder(ship.Uid, m.state.ShipSettings(ship).EngineOrder.Faster())
+		m.planInstructions(map[string]instr.Instruction{i.Uid(): i})
+	}
+
+	// 战术暂停时战舰状态冻结，第二次按键需要按暂存的设置切换回启用
+	pressToggle()
+	require.True(t, m.state.ShipSettings(ship).MainGunDisabled)
+	pressToggle()
+	require.Len(t, m.plannedInstructions, 2)
+	require.False(t, m.state.ShipSettings(ship).MainGunDisabled)
+	require.False(t, ship.Weapon.MainGunDisabled)
+
+	// 车钟可以连续加档，预览展示尚未生效的设置
+	pressFaster()
+	pressFaster()
+	require.Equal(t, objUnit.EngineOrderTwoThirds, m.state.ShipSettings(ship).EngineOrder)
+	require.Equal(t, objUnit.EngineOrderStop, ship.EngineOrder)
+	planned := m.state.UI.PlannedOrders[ship.Uid]
+	require.NotNil(t, planned)
+	require.Empty(t, planned.Orders)
+	require.Len(t, planned.Settings.ChangesDisplay(ship.Settings()), 1)
+
+	// 恢复后依次生效，全部下发后不再有尚未生效的设置
+	for len(m.plannedInstructions) != 0 {
+		m.submitPlannedInstructions()
+	}
+	require.Nil(t, m.state.UI.PlannedOrders)
+}
+
+func TestLiveOrderDropsPlannedInstructions(t *testing.T) {
+	ship := &objUnit.BattleShip{Uid: "ship", CurHP: 100, CurPos: objPos.New(5, 5), BelongPlayer: faction.HumanAlpha}
+	other := &objUnit.BattleShip{Uid: "other", CurHP: 100, CurPos: objPos.New(8, 8), BelongPlayer: faction.HumanAlpha}
+	m := newOrderTestManager(ship, other)
+	batch := func(instructions ...instr.Instruction) map[string]instr.Instruction {
+		return lo.KeyBy(instructions, instr.Instruction.Uid)
+	}
+
+	m.planInstructions(batch(instr.NewShipMove(ship.Uid, objPos.New(10, 5)), instr.NewShipMove(other.Uid, objPos.New(10, 8))))
+	m.planInstructions(batch(instr.NewShipEnqueueOrder(ship.Uid, objUnit.OrderTypeMove, objPos.New(10, 10), "")))
+	m.planInstructions(batch(instr.NewDisableWeapon(ship.Uid, objUnit.WeaponTypeMainGun)))
+	m.submitPlannedInstructions()
+	require.Len(t, m.plannedInstructions, 2)
+
+	// 恢复后对战舰重新下达命令：该战舰剩余的暂存指令全部丢弃，不会在之后的帧覆盖新命令
+	m.dropPlannedInstructions(batch(instr.NewShipMove(ship.Uid, objPos.New(2, 2))))
+	require.Empty(t, m.plannedInstructions)
+	require.Nil(t, m.state.UI.PlannedOrders)
+
+	// 其他战舰的暂存指令不受影响
+	m.planInstructions(batch(instr.NewShipEnqueueOrder(other.Uid, objUnit.OrderTypeMove, objPos.New(12, 8), "")))
+	m.dropPlannedInstructions(batch(instr.NewShipEngineOrder(ship.Uid, objUnit.EngineOrderFull)))
+	require.Len(t, m.plannedInstructions, 1)
+	require.Len(t, m.state.UI.PlannedOrders[other.Uid].Orders, 1)
+}
@@ -8,10 +8,11 @@ import (
 
 	_ "github.com/narasux/jutland/pkg/mission/object/initialize"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	"github.com/narasux/jutland/pkg/mission/state"
 )
 
 func TestWarmupMapBlocksReportsCurrentViewReadiness(t *testing.T) {
-	m := New("Midway1942", &ebitenui.UI{})
+	m := New("Midway1942", &ebitenui.UI{}, 1, state.DifficultyNormal)
 	m.state.View.Camera.Pos = objPos.New(100, 137)
 	m.state.View.Camera.Width = 30
 	m.state.View.Camera.Height = 20
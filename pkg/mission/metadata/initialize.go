@@ -34,6 +34,8 @@ type rawMissionMetadata struct {
 	InitShips           []rawInitShipMetadata           `json:"initShips"`
 	InitReinforcePoints []rawInitReinforcePointMetadata `json:"initReinforcePoints"`
 	InitOilPlatforms    []rawInitOilPlatformMetadata    `json:"initOilPlatforms"`
+	Alliances           [][]string                      `json:"alliances"`
+	ShipFuel            bool                            `json:"shipFuel"`
 }
 
 func normalizeMissionCategory(raw string) (MissionCategory, error) {
@@ -69,9 +71,45 @@ type rawInitOilPlatformMetadata struct {
 	Yield  int    `json:"yield"`
 }
 
-// isAllyPlayer 判定是否为友方玩家
-func isAllyPlayer(p faction.Player) bool {
-	return p == faction.HumanAlpha || p == faction.HumanBeta
+// parseAlliances 解析同盟配置
+func parseAlliances(raw [][]string) (faction.Alliances, error) {
+	groups := make([][]faction.Player, 0, len(raw))
+	for _, rawGroup := range raw {
+		group := make([]faction.Player, 0, len(rawGroup))
+		for _, player := range rawGroup {
+			group = append(group, faction.Player(player))
+		}
+		groups = append(groups, group)
+	}
+	return faction.NewAlliances(groups)
+}
+
+// collectPlayers 收集参战玩家（按 faction.AllPlayers 顺序）
+func collectPlayers(
+	alliances faction.Alliances, ships []InitShipMetadata, reinforcePoints []InitReinforcePointMetadata,
+) ([]faction.Player, error) {
+	exists := map[faction.Player]bool{}
+	for player := range alliances {
+		exists[player] = true
+	}
+	for _, s := range ships {
+		exists[s.BelongPlayer] = true
+	}
+	for _, rp := range reinforcePoints {
+		exists[rp.BelongPlayer] = true
+	}
+
+	players := []faction.Player{}
+	for _, player := range faction.AllPlayers {
+		if exists[player] {
+			players = append(players, player)
+			delete(exists, player)
+		}
+	}
+	for player := range exists {
+		return nil, fmt.Errorf("unknown player %q", player)
+	}
+	return players, nil
 }
 
 func init() {
@@ -127,20 +165,29 @@ func init() {
 				Yield:  opMD.Yield,
 			})
 		}
+		// 同盟 & 参战玩家
+		alliances, allianceErr := parseAlliances(md.Alliances)
+		if allianceErr != nil {
+			log.Fatalf("invalid alliances for mission %q: %v", md.Name, allianceErr)
+		}
+		players, playerErr := collectPlayers(alliances, initShips, initReinforcePoints)
+		if playerErr != nil {
+			log.Fatalf("invalid players for mission %q: %v", md.Name, playerErr)
+		}
 		// 统计计算
 		allyShips, enemyShips := 0, 0
 		for _, s := range initShips {
-			if isAllyPlayer(s.BelongPlayer) {
+			if alliances.IsAlly(faction.HumanAlpha, s.BelongPlayer) {
 				allyShips++
-			} else {
+			} else if alliances.IsEnemy(faction.HumanAlpha, s.BelongPlayer) {
 				enemyShips++
 			}
 		}
 		allyReinforce, enemyReinforce, totalSlots := 0, 0, 0
 		for _, rp := range initReinforcePoints {
-			if isAllyPlayer(rp.BelongPlayer) {
+			if alliances.IsAlly(faction.HumanAlpha, rp.BelongPlayer) {
 				allyReinforce++
-			} else {
+			} else if alliances.IsEnemy(faction.HumanAlpha, rp.BelongPlayer) {
 				enemyReinforce++
 			}
 			totalSlots += rp.MaxOncomingShip
@@ -167,6 +214,9 @@ func init() {
 				i18n.LanguageRussian:  md.DescriptionRu,
 				i18n.LanguageJapanese: md.DescriptionJa,
 			},
+			Alliances:           alliances,
+			Players:             players,
+			ShipFuel:            md.ShipFuel,
 			AllyShipCount:       allyShips,
 			EnemyShipCount:      enemyShips,
 			AllyReinforceCount:  allyReinforce,
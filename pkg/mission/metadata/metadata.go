@@ -31,10 +31,15 @@ type MissionMetadata struct {
 	// 关卡描述
 	Description  string
 	descriptions map[i18n.Language]string
-	// 统计信息（加载时计算）
-	AllyShipCount       int // 我方初始舰船数
+	// 同盟关系 & 参战玩家（含中立势力，按 faction.AllPlayers 顺序）
+	Alliances faction.Alliances
+	Players   []faction.Player
+	// 是否启用战舰燃油（按航行距离消耗，需要补给）
+	ShipFuel bool
+	// 统计信息（加载时计算，敌我相对于 HumanAlpha，不含中立势力）
+	AllyShipCount       int // 我方（含友军）初始舰船数
 	EnemyShipCount      int // 敌方初始舰船数
-	AllyReinforceCount  int // 我方增援点数量
+	AllyReinforceCount  int // 我方（含友军）增援点数量
 	EnemyReinforceCount int // 敌方增援点数量
 	OilPlatformCount    int // 油井数量
 	TotalReinforceSlots int // 全部增援槽位总数
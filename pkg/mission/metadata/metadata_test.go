@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
 )
 
 func TestAvailableMissionsFiltersCategoriesAndPreservesOrder(t *testing.T) {
@@ -52,5 +54,49 @@ func TestConfiguredMissionOrder(t *testing.T) {
 	require.Equal(t, []string{
 		"TestAll",
 		"TestAntiAircraft",
+		"TestAlliances",
+		"TestLogistics",
 	}, AvailableMissions(MissionCategoryTest))
 }
+
+func TestParseAlliancesAndCollectPlayers(t *testing.T) {
+	alliances, err := parseAlliances([][]string{{"HA", "HB"}})
+	require.NoError(t, err)
+	require.True(t, alliances.IsAlly(faction.HumanAlpha, faction.HumanBeta))
+	require.True(t, alliances.IsEnemy(faction.ComputerAlpha, faction.ComputerBeta))
+
+	players, err := collectPlayers(
+		alliances,
+		[]InitShipMetadata{{BelongPlayer: faction.Neutral}, {BelongPlayer: faction.ComputerBeta}},
+		[]InitReinforcePointMetadata{{BelongPlayer: faction.ComputerAlpha}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []faction.Player{
+		faction.HumanAlpha, faction.HumanBeta, faction.ComputerAlpha, faction.ComputerBeta, faction.Neutral,
+	}, players)
+
+	_, err = collectPlayers(nil, []InitShipMetadata{{BelongPlayer: "XX"}}, nil)
+	require.ErrorContains(t, err, "unknown player")
+
+	_, err = parseAlliances([][]string{{"HA"}, {"HA", "CA"}})
+	require.Error(t, err)
+}
+
+func TestAllianceMissionMetadata(t *testing.T) {
+	md := Get("TestAlliances")
+	require.True(t, md.Alliances.IsAlly(faction.HumanAlpha, faction.HumanBeta))
+	require.Equal(t, []faction.Player{
+		faction.HumanAlpha, faction.HumanBeta, faction.ComputerAlpha, faction.ComputerBeta, faction.Neutral,
+	}, md.Players)
+	require.Equal(t, 8, md.AllyShipCount)
+	require.Equal(t, 7, md.EnemyShipCount)
+}
+
+func TestShipFuelMissionMetadata(t *testing.T) {
+	md := Get("TestLogistics")
+	require.True(t, md.ShipFuel)
+	require.Equal(t, 256, md.MapCfg.Width)
+	require.True(t, Get("WakeIsland1941").ShipFuel)
+	require.True(t, Get("DarwinHarbour1942").ShipFuel)
+	require.False(t, Get("TestAlliances").ShipFuel)
+}
@@ -0,0 +1,54 @@
+package netplay
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// Connect 按子命令创建 / 加入联机对局，阻塞直到双方握手完成，args 为子命令之后的参数
+// 示例：jutland host --mission Midway1942 --addr :7777，jutland join --addr 192.168.1.2:7777
+func Connect(command string, args []string) (*Session, error) {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	addr := fs.String("addr", DefaultAddr, "address to listen on (host) or connect to (join)")
+	mission := fs.String("mission", "", "mission name in configs/missions.json5 (host only)")
+	seed := fs.Int64("seed", -1, "random seed, negative means generate a new one (host only)")
+	difficulty := fs.String("difficulty", string(state.DifficultyNormal), "computer AI difficulty: easy, normal, hard (host only)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	switch command {
+	case HostCommand:
+		if *mission == "" {
+			return nil, errors.New("--mission is required")
+		}
+		if *seed < 0 {
+			*seed = state.NewRandomSeed()
+		}
+		d, err := state.ParseDifficulty(*difficulty)
+		if err != nil {
+			return nil, err
+		}
+		hello, err := NewHello(*mission, *seed, d)
+		if err != nil {
+			return nil, err
+		}
+		ln, err := net.Listen("tcp", *addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listen %s", *addr)
+		}
+		defer ln.Close()
+		log.Printf("[INFO] Netplay hosting %s (seed %d) on %s, waiting for guest...", *mission, *seed, ln.Addr())
+		return Host(ln, hello)
+	case JoinCommand:
+		log.Printf("[INFO] Netplay joining %s...", *addr)
+		return Join(*addr)
+	default:
+		return nil, errors.Errorf("unknown netplay command %s", command)
+	}
+}
@@ -0,0 +1,105 @@
+// netplay 局域网双人联机：双方通过 TCP 按帧交换玩家指令，以确定性锁步推进同一局任务，并定期比对任务状态校验值检测不同步
+package netplay
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+const (
+	// HostCommand 创建联机对局的子命令名称
+	HostCommand = "host"
+	// JoinCommand 加入联机对局的子命令名称
+	JoinCommand = "join"
+	// DefaultAddr 默认监听地址
+	DefaultAddr = ":7777"
+)
+
+// Version 联机协议版本，指令或模拟逻辑不兼容变更时需要递增
+const Version = 2
+
+// InputDelay 指令延迟帧数：第 N 帧产生的本地指令在第 N + InputDelay 帧执行，为网络传输预留时间
+const InputDelay int64 = 4
+
+// ChecksumInterval 任务状态校验间隔（帧）
+const ChecksumInterval int64 = 60
+
+// 握手超时时间
+const handshakeTimeout = 10 * time.Second
+
+var (
+	// ErrDesync 双方任务状态不同步
+	ErrDesync = errors.New("netplay desync")
+	// ErrPeerLeft 对方已离开对局
+	ErrPeerLeft = errors.New("netplay peer left")
+)
+
+// Hello 主机在连接建立后发给客机的对局信息
+type Hello struct {
+	Version int    `json:"version"`
+	Mission string `json:"mission"`
+	Seed    int64  `json:"seed"`
+	// 影响模拟结果的游戏设置，客机需要与主机保持一致
+	Settings replay.Settings `json:"settings"`
+	// 电脑 AI 难度（任务中其余玩家由电脑控制）
+	Difficulty state.Difficulty `json:"difficulty"`
+	// 主机 / 客机操控的玩家
+	HostPlayer  faction.Player `json:"hostPlayer"`
+	GuestPlayer faction.Player `json:"guestPlayer"`
+}
+
+// NewHello 创建对局信息，主机操控 HumanAlpha，客机操控任务中第一个与主机敌对的玩家
+func NewHello(mission string, seed int64, difficulty state.Difficulty) (Hello, error) {
+	md := metadata.Get(mission)
+	if md.Name == "" {
+		return Hello{}, errors.Errorf("mission %s not found", mission)
+	}
+	hello := Hello{
+		Version:    Version,
+		Mission:    mission,
+		Seed:       seed,
+		Settings:   replay.CurrentSettings(),
+		Difficulty: difficulty.OrDefault(),
+		HostPlayer: faction.HumanAlpha,
+	}
+	for _, player := range md.Players {
+		if md.Alliances.IsEnemy(hello.HostPlayer, player) {
+			hello.GuestPlayer = player
+			return hello, nil
+		}
+	}
+	return Hello{}, errors.Errorf("mission %s has no opponent for the guest player", mission)
+}
+
+// messageType 联机消息类型
+type messageType string
+
+const (
+	// 主机 -> 客机：对局信息
+	messageHello messageType = "hello"
+	// 客机 -> 主机：已准备好
+	messageReady messageType = "ready"
+	// 双向：某一帧的本地指令（及状态校验值）
+	messageFrame messageType = "frame"
+	// 双向：离开对局
+	messageBye messageType = "bye"
+)
+
+// message 联机消息（按行序列化为 JSON）
+type message struct {
+	Type  messageType `json:"type"`
+	Hello *Hello      `json:"hello,omitempty"`
+	// 指令的执行帧 & 指令
+	Tick         int64          `json:"tick,omitempty"`
+	Instructions []instr.Record `json:"instructions,omitempty"`
+	// 状态校验帧 & 校验值（ChecksumTick 为 0 表示不携带校验值）
+	ChecksumTick int64  `json:"checksumTick,omitempty"`
+	Checksum     uint64 `json:"checksum,omitempty"`
+}
@@ -0,0 +1,278 @@
+package netplay
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+)
+
+// Session 联机会话（锁步）
+// 每一帧双方都要发送一次本地指令（可以为空），只有收到对方同一帧的指令后才能推进该帧模拟
+// 注：除读取协程外，方法需要在同一个协程（游戏主循环）中调用
+type Session struct {
+	conn   net.Conn
+	enc    *json.Encoder
+	hello  Hello
+	isHost bool
+
+	// 本地已发送、尚未执行的指令（Key: 执行帧）
+	localFrames map[int64][]instr.Record
+	// 待随下一帧发送的本地校验值
+	pendingChecksumTick int64
+	pendingChecksum     uint64
+
+	mu sync.Mutex
+	// 对方发送的指令（Key: 执行帧）
+	remoteFrames map[int64][]instr.Record
+	// 双方的状态校验值（Key: 校验帧），比对后删除
+	localChecksums  map[int64]uint64
+	remoteChecksums map[int64]uint64
+	// 连接断开 / 对方离开 / 不同步等错误（只保留第一个）
+	err    error
+	closed bool
+}
+
+// Host 在监听器上等待客机连接，发送对局信息并等待客机准备就绪
+func Host(ln net.Listener, hello Hello) (*Session, error) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return nil, errors.Wrap(err, "accept guest")
+	}
+	s := newSession(conn, hello, true)
+	dec := json.NewDecoder(conn)
+
+	_ = conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err = s.enc.Encode(message{Type: messageHello, Hello: &hello}); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "send hello")
+	}
+	var msg message
+	if err = dec.Decode(&msg); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "wait guest ready")
+	}
+	if msg.Type != messageReady {
+		conn.Close()
+		return nil, errors.Errorf("unexpected message %s, expected %s", msg.Type, messageReady)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	go s.read(dec)
+	return s, nil
+}
+
+// Join 连接主机，接收对局信息并告知主机已准备就绪
+func Join(addr string) (*Session, error) {
+	conn, err := net.DialTimeout("tcp", addr, handshakeTimeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connect host %s", addr)
+	}
+	dec := json.NewDecoder(conn)
+
+	_ = conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	var msg message
+	if err = dec.Decode(&msg); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "wait hello")
+	}
+	if msg.Type != messageHello || msg.Hello == nil {
+		conn.Close()
+		return nil, errors.Errorf("unexpected message %s, expected %s", msg.Type, messageHello)
+	}
+	if msg.Hello.Version != Version {
+		conn.Close()
+		return nil, errors.Errorf("unsupported netplay version %d, expected %d", msg.Hello.Version, Version)
+	}
+	s := newSession(conn, *msg.Hello, false)
+	if err = s.enc.Encode(message{Type: messageReady}); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "send ready")
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	go s.read(dec)
+	return s, nil
+}
+
+func newSession(conn net.Conn, hello Hello, isHost bool) *Session {
+	return &Session{
+		conn:            conn,
+		enc:             json.NewEncoder(conn),
+		hello:           hello,
+		isHost:          isHost,
+		localFrames:     map[int64][]instr.Record{},
+		remoteFrames:    map[int64][]instr.Record{},
+		localChecksums:  map[int64]uint64{},
+		remoteChecksums: map[int64]uint64{},
+	}
+}
+
+// Hello 对局信息
+func (s *Session) Hello() Hello {
+	return s.hello
+}
+
+// IsHost 是否为主机
+func (s *Session) IsHost() bool {
+	return s.isHost
+}
+
+// LocalPlayer 本地操控的玩家
+func (s *Session) LocalPlayer() faction.Player {
+	if s.isHost {
+		return s.hello.HostPlayer
+	}
+	return s.hello.GuestPlayer
+}
+
+// RemotePlayer 对方操控的玩家
+func (s *Session) RemotePlayer() faction.Player {
+	if s.isHost {
+		return s.hello.GuestPlayer
+	}
+	return s.hello.HostPlayer
+}
+
+// Err 会话错误（连接断开，对方离开，状态不同步）
+// 注：对方离开 / 连接断开时，已经收到的指令仍可继续执行（如双方在同一帧结束任务，先结束的一方会先离开）
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil && !errors.Is(s.err, ErrDesync) && len(s.remoteFrames) != 0 {
+		return nil
+	}
+	return s.err
+}
+
+// Ready 是否已经收到对方指定帧的指令（前 InputDelay 帧没有指令，总是就绪）
+func (s *Session) Ready(tick int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if errors.Is(s.err, ErrDesync) {
+		return false
+	}
+	if tick <= InputDelay {
+		return true
+	}
+	_, ok := s.remoteFrames[tick]
+	return ok
+}
+
+// Send 发送本地在指定帧产生的指令，这些指令将在 tick + InputDelay 帧由双方同时执行
+func (s *Session) Send(tick int64, records []instr.Record) error {
+	execTick := tick + InputDelay
+	s.localFrames[execTick] = records
+	msg := message{Type: messageFrame, Tick: execTick, Instructions: records}
+	if s.pendingChecksumTick != 0 {
+		msg.ChecksumTick, msg.Checksum = s.pendingChecksumTick, s.pendingChecksum
+		s.pendingChecksumTick = 0
+	}
+	if err := s.enc.Encode(msg); err != nil {
+		s.fail(errors.Wrap(err, "send frame"))
+		return s.Err()
+	}
+	return nil
+}
+
+// Take 取出指定帧双方需要执行的指令（主机在前，客机在后，保证双方执行顺序一致）
+// 注：需要先通过 Ready 确认对方的指令已经到达
+func (s *Session) Take(tick int64) []instr.Record {
+	local := s.localFrames[tick]
+	delete(s.localFrames, tick)
+
+	s.mu.Lock()
+	remote := s.remoteFrames[tick]
+	delete(s.remoteFrames, tick)
+	s.mu.Unlock()
+
+	if s.isHost {
+		return append(local, remote...)
+	}
+	return append(remote, local...)
+}
+
+// RecordChecksum 记录本地指定帧模拟结束时的状态校验值，随下一帧指令发送给对方比对
+func (s *Session) RecordChecksum(tick int64, checksum uint64) {
+	s.pendingChecksumTick, s.pendingChecksum = tick, checksum
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.localChecksums[tick] = checksum
+	s.compareChecksum(tick)
+}
+
+// compareChecksum 双方校验值都已到达时进行比对（需要持有锁）
+func (s *Session) compareChecksum(tick int64) {
+	local, localOk := s.localChecksums[tick]
+	remote, remoteOk := s.remoteChecksums[tick]
+	if !localOk || !remoteOk {
+		return
+	}
+	delete(s.localChecksums, tick)
+	delete(s.remoteChecksums, tick)
+	if local != remote && s.err == nil {
+		s.err = errors.Wrapf(ErrDesync, "tick %d, local %016x, remote %016x", tick, local, remote)
+	}
+}
+
+// Close 通知对方离开并关闭连接
+func (s *Session) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	_ = s.enc.Encode(message{Type: messageBye})
+	return s.conn.Close()
+}
+
+// read 持续读取对方消息（读取协程）
+func (s *Session) read(dec *json.Decoder) {
+	for {
+		var msg message
+		if err := dec.Decode(&msg); err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if !closed {
+				s.fail(errors.Wrap(err, "connection lost"))
+			}
+			return
+		}
+
+		switch msg.Type {
+		case messageFrame:
+			s.mu.Lock()
+			s.remoteFrames[msg.Tick] = msg.Instructions
+			if msg.ChecksumTick != 0 {
+				s.remoteChecksums[msg.ChecksumTick] = msg.Checksum
+				s.compareChecksum(msg.ChecksumTick)
+			}
+			s.mu.Unlock()
+		case messageBye:
+			s.fail(ErrPeerLeft)
+			return
+		default:
+			s.fail(errors.Errorf("unexpected message %s", msg.Type))
+			return
+		}
+	}
+}
+
+// fail 记录会话错误（只保留第一个）
+func (s *Session) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
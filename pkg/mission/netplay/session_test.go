@@ -0,0 +1,100 @@
+package netplay
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+)
+
+// newTestSessions 在本机建立一对联机会话（主机，客机）
+func newTestSessions(t *testing.T) (*Session, *Session) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	hello := Hello{
+		Version: Version, Mission: "Midway1942", Seed: 42,
+		HostPlayer: faction.HumanAlpha, GuestPlayer: faction.ComputerAlpha,
+	}
+	type result struct {
+		session *Session
+		err     error
+	}
+	hostCh := make(chan result, 1)
+	go func() {
+		s, err := Host(ln, hello)
+		hostCh <- result{s, err}
+	}()
+
+	guest, err := Join(ln.Addr().String())
+	require.NoError(t, err)
+	host := <-hostCh
+	require.NoError(t, host.err)
+	t.Cleanup(func() {
+		host.session.Close()
+		guest.Close()
+	})
+	return host.session, guest
+}
+
+func TestHandshake(t *testing.T) {
+	host, guest := newTestSessions(t)
+	require.True(t, host.IsHost())
+	require.False(t, guest.IsHost())
+	require.Equal(t, host.Hello(), guest.Hello())
+	require.Equal(t, faction.HumanAlpha, host.LocalPlayer())
+	require.Equal(t, faction.ComputerAlpha, host.RemotePlayer())
+	require.Equal(t, faction.ComputerAlpha, guest.LocalPlayer())
+	require.Equal(t, faction.HumanAlpha, guest.RemotePlayer())
+}
+
+func TestExchangeFrames(t *testing.T) {
+	host, guest := newTestSessions(t)
+	// 前 InputDelay 帧没有指令，总是就绪
+	require.True(t, host.Ready(InputDelay))
+	require.False(t, host.Ready(InputDelay+1))
+
+	hostRecord := instr.Record{Name: instr.NameCancelSummon, ObjUid: "HumanAlpha/rp-0"}
+	guestRecord := instr.Record{Name: instr.NameCancelSummon, ObjUid: "ComputerAlpha/rp-0"}
+	require.NoError(t, host.Send(1, []instr.Record{hostRecord}))
+	require.NoError(t, guest.Send(1, []instr.Record{guestRecord}))
+
+	tick := 1 + InputDelay
+	require.Eventually(t, func() bool { return host.Ready(tick) && guest.Ready(tick) }, time.Second, time.Millisecond)
+	// 双方得到的指令顺序一致（主机在前）
+	expected := []instr.Record{hostRecord, guestRecord}
+	require.Equal(t, expected, host.Take(tick))
+	require.Equal(t, expected, guest.Take(tick))
+}
+
+func TestDesyncDetection(t *testing.T) {
+	host, guest := newTestSessions(t)
+	host.RecordChecksum(60, 1)
+	guest.RecordChecksum(60, 1)
+	require.NoError(t, host.Send(60, nil))
+	require.NoError(t, guest.Send(60, nil))
+	require.Eventually(t, func() bool { return host.Ready(60 + InputDelay) }, time.Second, time.Millisecond)
+	require.NoError(t, host.Err())
+
+	host.RecordChecksum(120, 1)
+	guest.RecordChecksum(120, 2)
+	require.NoError(t, host.Send(120, nil))
+	require.NoError(t, guest.Send(120, nil))
+	require.Eventually(t, func() bool { return host.Err() != nil && guest.Err() != nil }, time.Second, time.Millisecond)
+	require.ErrorIs(t, host.Err(), ErrDesync)
+	require.ErrorIs(t, guest.Err(), ErrDesync)
+}
+
+func TestPeerLeft(t *testing.T) {
+	host, guest := newTestSessions(t)
+	require.NoError(t, guest.Close())
+	require.Eventually(t, func() bool { return host.Err() != nil }, time.Second, time.Millisecond)
+	require.ErrorIs(t, host.Err(), ErrPeerLeft)
+	require.False(t, host.Ready(InputDelay+1))
+}
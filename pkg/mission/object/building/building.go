@@ -2,7 +2,6 @@ package building
 
 import (
 	"slices"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -16,19 +15,19 @@ type OncomingShip struct {
 	Name      string
 	FundsCost int64
 	TimeCost  int64
-	// 开始的时间戳
+	// 开始时间（任务时间，毫秒）
 	StartedAt int64
 	// 进度
 	Progress float64
 }
 
 // Update ...
-func (s *OncomingShip) Update() (finished bool) {
+func (s *OncomingShip) Update(now int64) (finished bool) {
 	if s.StartedAt == 0 {
-		s.StartedAt = time.Now().UnixMilli()
+		s.StartedAt = now
 		return false
 	}
-	s.Progress = float64(time.Now().UnixMilli()-s.StartedAt) / float64(s.TimeCost) / 10
+	s.Progress = float64(now-s.StartedAt) / float64(s.TimeCost) / 10
 	return s.Progress >= 100
 }
 
@@ -67,6 +66,13 @@ func (p *ReinforcePoint) Summon(shipName string) {
 	})
 }
 
+// CancelLastSummon 取消最后一艘待增援的战舰
+func (p *ReinforcePoint) CancelLastSummon() {
+	if len(p.OncomingShips) > 0 {
+		p.OncomingShips = p.OncomingShips[:len(p.OncomingShips)-1]
+	}
+}
+
 // SetRallyPos 设置集结点
 func (p *ReinforcePoint) SetRallyPos(pos objPos.MapPos) {
 	p.RallyPos = pos
@@ -74,7 +80,7 @@ func (p *ReinforcePoint) SetRallyPos(pos objPos.MapPos) {
 
 // Update ...
 func (p *ReinforcePoint) Update(
-	shipUidGenerator *objUnit.ShipUidGenerator, curFunds int64,
+	shipUidGenerator *objUnit.ShipUidGenerator, curFunds int64, now int64,
 ) *objUnit.BattleShip {
 	if len(p.OncomingShips) == 0 {
 		return nil
@@ -86,7 +92,7 @@ func (p *ReinforcePoint) Update(
 		return nil
 	}
 	// 增援进度计算
-	if finished := oncomingShip.Update(); finished {
+	if finished := oncomingShip.Update(now); finished {
 		p.OncomingShips = p.OncomingShips[1:]
 		return objUnit.NewShip(
 			shipUidGenerator,
@@ -110,6 +116,7 @@ func (p *ReinforcePoint) Progress() int {
 
 // NewReinforcePoint ...
 func NewReinforcePoint(
+	uid string,
 	pos objPos.MapPos,
 	rotation float64,
 	rallyPos objPos.MapPos,
@@ -118,7 +125,7 @@ func NewReinforcePoint(
 	providedShipNames []string,
 ) *ReinforcePoint {
 	return &ReinforcePoint{
-		Uid:               uuid.NewString(),
+		Uid:               uid,
 		Pos:               pos,
 		Rotation:          rotation,
 		RallyPos:          rallyPos,
@@ -136,19 +143,19 @@ type LoadingOilShip struct {
 	FundYield int
 	// 装载耗时
 	TimeCost int64
-	// 开始的时间戳
+	// 开始时间（任务时间，毫秒）
 	StartedAt int64
 	// 进度
 	Progress float64
 }
 
 // Update ...
-func (s *LoadingOilShip) Update() (finished bool) {
+func (s *LoadingOilShip) Update(now int64) (finished bool) {
 	if s.StartedAt == 0 {
-		s.StartedAt = time.Now().UnixMilli()
+		s.StartedAt = now
 		return false
 	}
-	s.Progress = float64(time.Now().UnixMilli()-s.StartedAt) / float64(s.TimeCost) / 10
+	s.Progress = float64(now-s.StartedAt) / float64(s.TimeCost) / 10
 
 	// 如果进度到达 100，重置并返回 true
 	if s.Progress >= 100 {
@@ -55,6 +55,28 @@ const (
 	CriticalTypeTenTimes
 )
 
+// ArmorResult 命中战舰装甲的结果
+type ArmorResult int
+
+const (
+	// ArmorResultNone 不结算装甲（鱼雷 / 镭射，或尚未命中战舰）
+	ArmorResultNone ArmorResult = iota
+	// ArmorResultPenetration 击穿
+	ArmorResultPenetration
+	// ArmorResultOverPenetration 过度击穿（装甲太薄，引信未触发）
+	ArmorResultOverPenetration
+	// ArmorResultNonPenetration 未击穿
+	ArmorResultNonPenetration
+	// ArmorResultRicochet 跳弹
+	ArmorResultRicochet
+)
+
+// 穿深随飞行距离衰减：每前进一格地图衰减的比例 & 衰减下限
+const (
+	penetrationDecayPerBlock = 0.015
+	minPenetrationRate       = 0.35
+)
+
 // 火炮 / 鱼雷弹药
 type Bullet struct {
 	// 弹药名称
@@ -67,6 +89,8 @@ type Bullet struct {
 	Damage float64 `json:"damage"`
 	// 暴击概率（理论上口径越大越容易被暴击，但是暴击率不应该太高）
 	CriticalRate float64 `json:"criticalRate"`
+	// 穿深（毫米，炮口 / 投放处），随飞行距离衰减，鱼雷 / 镭射不使用
+	Penetration float64 `json:"penetration"`
 	// 生命（前进太多要消亡）
 	Life int
 
@@ -98,6 +122,8 @@ type Bullet struct {
 	RealDamage float64
 	// 造成暴击类型
 	CriticalType CriticalType
+	// 最近一次命中战舰装甲的结果
+	ArmorResult ArmorResult
 	// 击中的对象类型
 	HitObjType object.Type
 	// 近炸触发半径，仅火箭弹使用
@@ -129,6 +155,12 @@ func (b *Bullet) Forward() {
 	b.ForwardAge++
 }
 
+// CurPenetration 当前穿深（按已经飞行的距离衰减）
+func (b *Bullet) CurPenetration() float64 {
+	traveled := float64(b.ForwardAge) * b.Speed
+	return b.Penetration * max(minPenetrationRate, 1-traveled*penetrationDecayPerBlock)
+}
+
 // GenTrails 生成尾流
 func (b *Bullet) GenTrails() []*trail.Trail {
 	// 已经命中的没有尾流
@@ -239,6 +271,7 @@ func New(
 	b.BelongPlayer = shooterBelongPlayer
 
 	b.CriticalType = CriticalTypeNone
+	b.ArmorResult = ArmorResultNone
 	b.HitObjType = object.TypeNone
 	return &b
 }
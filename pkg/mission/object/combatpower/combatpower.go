@@ -22,6 +22,15 @@ const (
 	shipProjectionKilometersPerMapUnit = 2.0
 	// 飞机航程初始化时除以 14.4 转成运行时距离。
 	planeProjectionKilometersPerMapUnit = 14.4
+	// 装甲参照厚度（毫米）取中型装甲（重巡舷侧 / 战列舰甲板），穿深不足的武器对舰输出按比例折算，
+	// 下限与实战中未击穿的伤害比例一致；装甲厚度同样以它为单位折算成额外 EHP。
+	referenceArmorThickness  = 150.0
+	minimumPenetrationFactor = 0.1
+	armorEHPWeight           = 0.25
+	// 舰船 EHP 按对舰输出的大致构成分摊：平射炮弹对舷侧装甲、曲射炮弹 / 炸弹对甲板装甲、鱼雷对固定减伤。
+	beltEHPWeight    = 0.45
+	deckEHPWeight    = 0.3
+	torpedoEHPWeight = 0.25
 )
 
 type powerAccumulator struct {
@@ -57,7 +66,7 @@ func CalculatePlane(plane *objUnit.Plane, bullets map[string]*objBullet.Bullet)
 	acc := newPowerAccumulator()
 	for _, gun := range plane.Weapon.Guns {
 		if gun.AntiShip {
-			effectiveness := gunEffectiveness(gun, false, true)
+			effectiveness := gunEffectiveness(gun, false, true) * penetrationFactor(gun.BulletName, bullets)
 			acc.addAntiShip(gun.Name, gunDPS(gun, bullets)*effectiveness, gunBurst(gun, bullets)*effectiveness)
 		}
 		if gun.AntiAircraft {
@@ -68,7 +77,7 @@ func CalculatePlane(plane *objUnit.Plane, bullets map[string]*objBullet.Bullet)
 	for _, releaser := range plane.Weapon.Bombs {
 		effectiveness := weaponEffectiveness(
 			0.55, 0, releaser.Range, releaser.LeftFiringArc, releaser.RightFiringArc, false,
-		)
+		) * penetrationFactor(releaser.BulletName, bullets)
 		acc.addAntiShip(
 			releaser.Name, releaserDPS(releaser, bullets)*effectiveness,
 			releaserBurst(releaser, bullets)*effectiveness,
@@ -89,7 +98,7 @@ func CalculatePlane(plane *objUnit.Plane, bullets map[string]*objBullet.Bullet)
 			effectiveness := weaponEffectiveness(
 				0.45, rocket.BulletSpread, rocket.Range,
 				rocket.LeftFiringArc, rocket.RightFiringArc, false,
-			)
+			) * penetrationFactor(rocket.BulletName, bullets)
 			acc.addAntiShip(rocket.Name, dps*effectiveness, planeRocketBurst(rocket, bullets)*effectiveness)
 		}
 		if rocket.AntiAircraft {
@@ -131,7 +140,7 @@ func CalculateShip(
 	} {
 		for _, gun := range guns {
 			if gun.AntiShip {
-				effectiveness := gunEffectiveness(gun, false, false)
+				effectiveness := gunEffectiveness(gun, false, false) * penetrationFactor(gun.BulletName, bullets)
 				acc.addAntiShip(gun.Name, gunDPS(gun, bullets)*effectiveness, gunBurst(gun, bullets)*effectiveness)
 			}
 			if gun.AntiAircraft {
@@ -157,7 +166,7 @@ func CalculateShip(
 			effectiveness := weaponEffectiveness(
 				0.45, rocket.BulletSpread, rocket.Range,
 				rocket.LeftFiringArc, rocket.RightFiringArc, false,
-			)
+			) * penetrationFactor(rocket.BulletName, bullets)
 			acc.addAntiShip(rocket.Name, dps*effectiveness, shipRocketBurst(rocket, bullets)*effectiveness)
 		}
 		if rocket.AntiAircraft {
@@ -471,6 +480,19 @@ func planeRocketBurst(launcher *objUnit.PlaneRocketLauncher, bullets map[string]
 	return float64(launcher.RocketCount) * expectedDamage(launcher.BulletName, bullets)
 }
 
+func penetrationFactor(bulletName string, bullets map[string]*objBullet.Bullet) float64 {
+	// 穿深系数只作用于对舰输出：炮弹、炸弹、火箭按穿深 / 参照装甲折算，鱼雷与镭射不结算装甲。
+	bullet, ok := bullets[bulletName]
+	if !ok || bullet == nil {
+		return 1
+	}
+	switch bullet.Type {
+	case objBullet.TypeShell, objBullet.TypeBomb, objBullet.TypeRocket:
+		return clamp(bullet.Penetration/referenceArmorThickness, minimumPenetrationFactor, 1)
+	}
+	return 1
+}
+
 func gunEffectiveness(gun *objUnit.Gun, antiAir, planeShooter bool) float64 {
 	// 命中系数把基础命中率、散布、射界和射程合在一起，得到一个可比较的有效输出倍率。
 	if gun == nil {
@@ -537,13 +559,19 @@ func firingArcCoverage(leftArc, rightArc objUnit.FiringArc) float64 {
 }
 
 func shipEHP(ship *objUnit.BattleShip) float64 {
-	// 舰船 EHP 分成水平与垂直两套减伤，按 6:4 加权，近似反映不同武器类型的穿透差异。
+	// 与伤害结算一致：炮弹 / 炸弹只按舷侧 / 甲板装甲结算，鱼雷只按水平伤害减免结算，
+	// 三部分按对舰输出的大致构成加权，装甲越厚，越多的小口径命中无法击穿。
 	if ship == nil || ship.TotalHP <= 0 {
 		return 0
 	}
-	horizontal := ship.TotalHP / max(minimumDamageRate, 1-ship.HorizontalDamageReduction)
-	vertical := ship.TotalHP / max(minimumDamageRate, 1-ship.VerticalDamageReduction)
-	return 0.6*horizontal + 0.4*vertical
+	belt := ship.TotalHP * armorFactor(ship.BeltArmor)
+	deck := ship.TotalHP * armorFactor(ship.DeckArmor)
+	torpedo := ship.TotalHP / max(minimumDamageRate, 1-ship.HorizontalDamageReduction)
+	return beltEHPWeight*belt + deckEHPWeight*deck + torpedoEHPWeight*torpedo
+}
+
+func armorFactor(thickness float64) float64 {
+	return 1 + max(0, thickness)/referenceArmorThickness*armorEHPWeight
 }
 
 func planeEHP(plane *objUnit.Plane) float64 {
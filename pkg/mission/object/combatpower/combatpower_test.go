@@ -86,7 +86,7 @@ func TestEffectiveHP(t *testing.T) {
 	ship := &objUnit.BattleShip{
 		TotalHP: 1000, HorizontalDamageReduction: 0.5, VerticalDamageReduction: 0.25,
 	}
-	if got, want := shipEHP(ship), 0.6*2000+0.4*(1000.0/0.75); math.Abs(got-want) > 1e-9 {
+	if got, want := shipEHP(ship), 0.45*1000+0.3*1000+0.25*2000; math.Abs(got-want) > 1e-9 {
 		t.Fatalf("shipEHP() = %v, want %v", got, want)
 	}
 
@@ -98,8 +98,27 @@ func TestEffectiveHP(t *testing.T) {
 	invulnerable := &objUnit.BattleShip{
 		TotalHP: 1, HorizontalDamageReduction: 1, VerticalDamageReduction: 1,
 	}
-	if got := shipEHP(invulnerable); got != 1000 || math.IsInf(got, 0) || math.IsNaN(got) {
-		t.Fatalf("shipEHP() for full reduction = %v, want finite 1000", got)
+	if got, want := shipEHP(invulnerable), 0.45+0.3+0.25*1000; math.Abs(got-want) > 1e-9 || math.IsInf(got, 0) {
+		t.Fatalf("shipEHP() for full reduction = %v, want finite %v", got, want)
+	}
+}
+
+func TestArmorAndPenetration(t *testing.T) {
+	armored := &objUnit.BattleShip{TotalHP: 1000, BeltArmor: 300, DeckArmor: 150}
+	if got, want := shipEHP(armored), 0.45*1000*1.5+0.3*1000*1.25+0.25*1000; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("shipEHP() with armor = %v, want %v", got, want)
+	}
+
+	bullets := map[string]*objBullet.Bullet{
+		"small":   {Type: objBullet.TypeShell, Penetration: 45},
+		"heavy":   {Type: objBullet.TypeShell, Penetration: 700},
+		"tiny":    {Type: objBullet.TypeShell, Penetration: 5},
+		"torpedo": {Type: objBullet.TypeTorpedo},
+	}
+	for name, want := range map[string]float64{"small": 0.3, "heavy": 1, "tiny": minimumPenetrationFactor, "torpedo": 1} {
+		if got := penetrationFactor(name, bullets); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("penetrationFactor(%s) = %v, want %v", name, got, want)
+		}
 	}
 }
 
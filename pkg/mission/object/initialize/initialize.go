@@ -67,6 +67,7 @@ func initGunMap() {
 	for _, g := range guns {
 		g.Range /= 2
 		g.BulletSpeed /= 4000
+		g.AmmoCapacity = max(0, g.AmmoCapacity)
 		objUnit.GunMap[g.Name] = &g
 	}
 	log.Println("guns data loaded from json5 file")
@@ -89,6 +90,7 @@ func initTorpedoLauncherMap() {
 	for _, lc := range torpedoLaunchers {
 		lc.Range /= 2
 		lc.BulletSpeed /= 600
+		lc.AmmoCapacity = max(0, lc.AmmoCapacity)
 		objUnit.TorpedoLauncherMap[lc.Name] = &lc
 	}
 	log.Println("torpedo launchers data loaded from json5 file")
@@ -111,6 +113,7 @@ func initRocketLauncherMap() {
 	for _, lc := range rocketLaunchers {
 		lc.Range /= 2
 		lc.BulletSpeed /= 4000
+		lc.AmmoCapacity = max(0, lc.AmmoCapacity)
 		objUnit.RocketLauncherMap[lc.Name] = &lc
 	}
 	log.Println("rocket launchers data loaded from json5 file")
@@ -176,11 +179,14 @@ func initPlaneMap() {
 	for _, p := range planes {
 		// 机关炮
 		for _, gunMD := range p.Weapon.GunsMD {
-			p.Weapon.Guns = append(p.Weapon.Guns, objUnit.NewGun(
+			gun := objUnit.NewGun(
 				gunMD.Name, gunMD.PosPercent,
 				objUnit.FiringArc{Start: gunMD.LeftFiringArc[0], End: gunMD.LeftFiringArc[1]},
 				objUnit.FiringArc{Start: gunMD.RightFiringArc[0], End: gunMD.RightFiringArc[1]},
-			))
+			)
+			// 战机的续航受航程限制，机关炮不计弹药
+			gun.AmmoCapacity, gun.Ammo = 0, 0
+			p.Weapon.Guns = append(p.Weapon.Guns, gun)
 		}
 		// 炸弹
 		for _, bombMD := range p.Weapon.BombsMD {
@@ -229,6 +235,10 @@ func initPlaneMap() {
 			}
 		}
 
+		// 未配置探测范围的，按机种推算
+		if p.DetectionRange <= 0 {
+			p.DetectionRange = objUnit.DefaultPlaneDetectionRange(&p)
+		}
 		// 当前生命值
 		p.CurHP = p.TotalHP
 		// 折算速度（公里换成节）
@@ -338,6 +348,10 @@ func initShipMap() {
 			// 根据飞机名称，设置飞机目标类型
 			s.Aircraft.Groups[i].TargetType = objUnit.GetPlaneTargetObjType(s.Aircraft.Groups[i].Name)
 		}
+		// 未配置探测范围的，按舰种推算
+		if s.DetectionRange <= 0 {
+			s.DetectionRange = objUnit.DefaultShipDetectionRange(&s)
+		}
 		// 初始化当前生命值
 		s.CurHP = s.TotalHP
 		// 计算吨位（即最大生命值）
@@ -348,6 +362,12 @@ func initShipMap() {
 		// 检查伤害减免值不能超过 1
 		s.HorizontalDamageReduction = min(1, s.HorizontalDamageReduction)
 		s.VerticalDamageReduction = min(1, s.VerticalDamageReduction)
+		// 装甲厚度不能为负数
+		s.BeltArmor = max(0, s.BeltArmor)
+		s.DeckArmor = max(0, s.DeckArmor)
+		// 初始满油
+		s.FuelCapacity = max(0, s.FuelCapacity)
+		s.Fuel = s.FuelCapacity
 
 		objUnit.ShipMap[s.Name] = &s
 		objUnit.AllShipNames = append(objUnit.AllShipNames, s.Name)
@@ -1,11 +1,63 @@
 package unit
 
 import (
-	"time"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
 
+	"github.com/narasux/jutland/pkg/i18n"
+	"github.com/narasux/jutland/pkg/mission/clock"
 	"github.com/narasux/jutland/pkg/mission/object"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
 )
 
+// AirPatrolRadius 战斗空中巡逻的拦截半径，巡逻的战斗机只拦截该范围内的敌机
+const AirPatrolRadius = 8.0
+
+// airPatrolOrbitRadius 战斗机在巡逻位置上空盘旋的半径
+const airPatrolOrbitRadius = 2.5
+
+// airPatrolOrbitStep 盘旋时引导点领先战斗机的角度（弧度）
+const airPatrolOrbitStep = 0.6
+
+// AirStrike 指定空袭：指定分组的战机轮番起飞，攻击同一艘敌舰
+type AirStrike struct {
+	// 出击的战机分组（名称）
+	Group string
+	// 打击目标（uid）
+	TargetUid string
+}
+
+// AirPatrol 战斗空中巡逻（CAP）：战斗机在指定位置或友舰上空盘旋，拦截附近的敌机
+type AirPatrol struct {
+	// 巡逻位置
+	Pos objPos.MapPos
+	// 掩护的友舰（uid），非空时巡逻位置跟随该战舰
+	GuardUid string
+}
+
+// Center 巡逻中心，掩护的友舰已经不存在时返回 false
+func (ap *AirPatrol) Center(ships map[string]*BattleShip) (objPos.MapPos, bool) {
+	if ap.GuardUid == "" {
+		return ap.Pos, true
+	}
+	ship, ok := ships[ap.GuardUid]
+	if !ok {
+		return objPos.MapPos{}, false
+	}
+	return ship.CurPos, true
+}
+
+// AirPatrolOrbitPos 战斗机绕巡逻中心盘旋时的下一个引导点（始终领先战机一小段圆弧）
+func AirPatrolOrbitPos(center, planePos objPos.MapPos) objPos.MapPos {
+	theta := math.Atan2(planePos.RY-center.RY, planePos.RX-center.RX) + airPatrolOrbitStep
+	return objPos.NewR(
+		center.RX+airPatrolOrbitRadius*math.Cos(theta),
+		center.RY+airPatrolOrbitRadius*math.Sin(theta),
+	)
+}
+
 // ShipAircraft 战舰上的飞机，也能算是武器吧 :D
 type ShipAircraft struct {
 	// TakeOffTime 起飞耗时（单位：秒）
@@ -17,13 +69,47 @@ type ShipAircraft struct {
 	Disable bool
 	// 是否拥有舰载机
 	HasPlane bool
-	// 最近起飞时间（毫秒时间戳)
+	// 最近起飞时间（任务时间，毫秒）
 	LatestTakeOffAt int64
+	// 累计起飞架次，用于生成可复现的战机 Uid
+	TakeOffCount int
+	// 留在甲板上（不再自动出击）
+	HoldOnDeck bool
+	// 指定空袭（nil 表示没有）
+	Strike *AirStrike
+	// 战斗空中巡逻（nil 表示没有）
+	Patrol *AirPatrol
 
 	// 以下字段只服务于局内回收调度，不参与配置序列化。
 	landingSlots map[string]int
 }
 
+// OpsDisplay 舰载机指令展示用名称（自动出击时为空）
+func (sa *ShipAircraft) OpsDisplay() string {
+	labels := []string{}
+	if sa.HoldOnDeck {
+		labels = append(labels, i18n.Text(i18n.MsgAirOpsHold))
+	}
+	if sa.Strike != nil {
+		labels = append(labels, i18n.Format(i18n.MsgAirOpsStrike, map[string]any{
+			"Group": GetPlaneDisplayName(sa.Strike.Group),
+		}))
+	}
+	if sa.Patrol != nil {
+		labels = append(labels, i18n.Text(i18n.MsgAirOpsPatrol))
+	}
+	return strings.Join(labels, " / ")
+}
+
+// GroupsDisplay 各分组舰上剩余 / 总数量展示
+func (sa *ShipAircraft) GroupsDisplay() string {
+	labels := make([]string, 0, len(sa.Groups))
+	for _, g := range sa.Groups {
+		labels = append(labels, fmt.Sprintf("%s %d/%d", GetPlaneDisplayName(g.Name), g.CurCount, g.MaxCount))
+	}
+	return strings.Join(labels, " / ")
+}
+
 // RequestLanding 为返航飞机分配稳定的回收槽位。
 func (sa *ShipAircraft) RequestLanding(planeUID string) int {
 	if slot, exists := sa.landingSlots[planeUID]; exists {
@@ -53,14 +139,23 @@ func (sa *ShipAircraft) CancelLanding(planeUID string) {
 }
 
 // TakeOff 起飞战机（不区分飞机种类，只看打击对象类型）
-func (sa *ShipAircraft) TakeOff(ship *BattleShip, targetObjType object.Type) *Plane {
+func (sa *ShipAircraft) TakeOff(ship *BattleShip, targetObjType object.Type, now int64) *Plane {
+	return sa.takeOff(ship, now, func(g PlaneGroup) bool { return g.TargetType == targetObjType })
+}
+
+// TakeOffGroup 起飞指定分组的战机
+func (sa *ShipAircraft) TakeOffGroup(ship *BattleShip, groupName string, now int64) *Plane {
+	return sa.takeOff(ship, now, func(g PlaneGroup) bool { return g.Name == groupName })
+}
+
+func (sa *ShipAircraft) takeOff(ship *BattleShip, now int64, match func(PlaneGroup) bool) *Plane {
 	// 判断起飞冷却，冷却中不允许起飞
-	if sa.LatestTakeOffAt+int64(sa.TakeOffTime*1e3) > time.Now().UnixMilli() {
+	if float64(clock.Since(now, sa.LatestTakeOffAt)) < sa.TakeOffTime*1e3 {
 		return nil
 	}
 
 	for idx, g := range sa.Groups {
-		if g.TargetType != targetObjType {
+		if !match(g) {
 			continue
 		}
 		if g.CurCount <= 0 {
@@ -68,14 +163,73 @@ func (sa *ShipAircraft) TakeOff(ship *BattleShip, targetObjType object.Type) *Pl
 		}
 		// 非指针需要通过索引修改
 		sa.Groups[idx].CurCount--
-		sa.LatestTakeOffAt = time.Now().UnixMilli()
-		plane := NewPlane(g.Name, ship.CurPos, ship.CurRotation, ship.Uid, ship.BelongPlayer)
+		sa.LatestTakeOffAt = now
+		// 累计起飞架次随存档保存，读档后继续生成不重复的 Uid
+		sa.TakeOffCount++
+		plane := NewPlane(
+			g.Name, PlaneUid(ship.Uid, sa.TakeOffCount), ship.CurPos, ship.CurRotation, ship.Uid, ship.BelongPlayer,
+		)
 		plane.StartTakeoff(ship)
 		return plane
 	}
 	return nil
 }
 
+// GroupCount 指定分组留在舰上的战机数量
+func (sa *ShipAircraft) GroupCount(groupName string) int64 {
+	for _, g := range sa.Groups {
+		if g.Name == groupName {
+			return g.CurCount
+		}
+	}
+	return 0
+}
+
+// NextStrikeGroup 对目标发起空袭使用的分组：已经在空袭该目标时切换到下一个对舰分组，
+// 否则使用第一个对舰分组（只考虑舰上还有战机的分组，都没有时返回空）
+func (sa *ShipAircraft) NextStrikeGroup(targetUid string) string {
+	groups := []string{}
+	for _, g := range sa.Groups {
+		if g.TargetType == object.TypeShip && g.CurCount > 0 {
+			groups = append(groups, g.Name)
+		}
+	}
+	if len(groups) == 0 {
+		return ""
+	}
+	if sa.Strike == nil || sa.Strike.TargetUid != targetUid {
+		return groups[0]
+	}
+	idx := slices.Index(groups, sa.Strike.Group)
+	return groups[(idx+1)%len(groups)]
+}
+
+// LaunchStrike 下达空袭命令（只有对舰分组可以执行），同时解除留在甲板上
+func (sa *ShipAircraft) LaunchStrike(groupName, targetUid string) bool {
+	if !slices.ContainsFunc(sa.Groups, func(g PlaneGroup) bool {
+		return g.Name == groupName && g.TargetType == object.TypeShip
+	}) {
+		return false
+	}
+	sa.Strike = &AirStrike{Group: groupName, TargetUid: targetUid}
+	sa.HoldOnDeck = false
+	return true
+}
+
+// SetPatrol 设置战斗空中巡逻，同时解除留在甲板上
+func (sa *ShipAircraft) SetPatrol(patrol AirPatrol) {
+	sa.Patrol = &patrol
+	sa.HoldOnDeck = false
+}
+
+// SetHold 设置是否留在甲板上，留在甲板上时取消空袭 & 战斗空中巡逻
+func (sa *ShipAircraft) SetHold(hold bool) {
+	sa.HoldOnDeck = hold
+	if hold {
+		sa.Strike, sa.Patrol = nil, nil
+	}
+}
+
 // Recovery 回收飞机
 func (sa *ShipAircraft) Recovery(plane *Plane) {
 	sa.CancelLanding(plane.Uid)
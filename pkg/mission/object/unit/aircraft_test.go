@@ -0,0 +1,113 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/narasux/jutland/pkg/mission/object"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+func newAirOpsTestAircraft() *ShipAircraft {
+	return &ShipAircraft{
+		TakeOffTime: 3,
+		HasPlane:    true,
+		Groups: []PlaneGroup{
+			{Name: "test-fighter", MaxCount: 4, TargetType: object.TypePlane, CurCount: 4},
+			{Name: "test-dive-bomber", MaxCount: 2, TargetType: object.TypeShip, CurCount: 2},
+			{Name: "test-torpedo-bomber", MaxCount: 2, TargetType: object.TypeShip, CurCount: 2},
+		},
+	}
+}
+
+func TestAirStrikeGroupSelection(t *testing.T) {
+	aircraft := newAirOpsTestAircraft()
+
+	if group := aircraft.NextStrikeGroup("enemy"); group != "test-dive-bomber" {
+		t.Fatalf("first strike group = %q, want test-dive-bomber", group)
+	}
+	// 战斗机分组不能执行对舰空袭
+	if aircraft.LaunchStrike("test-fighter", "enemy") {
+		t.Fatal("fighter group should not launch a strike")
+	}
+	if !aircraft.LaunchStrike("test-dive-bomber", "enemy") {
+		t.Fatal("dive bomber group should launch a strike")
+	}
+	// 对同一目标再次下令切换到下一个分组，换目标时从第一个分组开始
+	if group := aircraft.NextStrikeGroup("enemy"); group != "test-torpedo-bomber" {
+		t.Fatalf("next strike group = %q, want test-torpedo-bomber", group)
+	}
+	if group := aircraft.NextStrikeGroup("other"); group != "test-dive-bomber" {
+		t.Fatalf("strike group for new target = %q, want test-dive-bomber", group)
+	}
+	// 舰上没有战机的分组不参与选择
+	aircraft.Groups[2].CurCount = 0
+	if group := aircraft.NextStrikeGroup("enemy"); group != "test-dive-bomber" {
+		t.Fatalf("strike group without torpedo bombers = %q, want test-dive-bomber", group)
+	}
+	aircraft.Groups[1].CurCount = 0
+	if group := aircraft.NextStrikeGroup("enemy"); group != "" {
+		t.Fatalf("strike group without bombers = %q, want empty", group)
+	}
+}
+
+func TestAirOpsHoldClearsOrders(t *testing.T) {
+	aircraft := newAirOpsTestAircraft()
+	aircraft.LaunchStrike("test-dive-bomber", "enemy")
+	aircraft.SetPatrol(AirPatrol{Pos: objPos.New(5, 5)})
+
+	aircraft.SetHold(true)
+	if !aircraft.HoldOnDeck || aircraft.Strike != nil || aircraft.Patrol != nil {
+		t.Fatalf("hold should cancel strike and patrol: %+v", aircraft)
+	}
+	// 下达新的指令时解除留在甲板上
+	aircraft.SetPatrol(AirPatrol{GuardUid: "friend"})
+	if aircraft.HoldOnDeck {
+		t.Fatal("patrol should release aircraft held on deck")
+	}
+	if _, ok := aircraft.Patrol.Center(map[string]*BattleShip{}); ok {
+		t.Fatal("patrol over a sunk ship should have no center")
+	}
+	center, ok := aircraft.Patrol.Center(map[string]*BattleShip{"friend": {CurPos: objPos.New(7, 8)}})
+	if !ok || center != objPos.New(7, 8) {
+		t.Fatalf("patrol center = %v, want friend position", center)
+	}
+}
+
+func TestTakeOffGroup(t *testing.T) {
+	useDefaultSettings(t)
+	for _, name := range []string{"test-fighter", "test-dive-bomber"} {
+		PlaneMap[name] = &Plane{Name: name, MaxSpeed: 0.1}
+	}
+	t.Cleanup(func() {
+		delete(PlaneMap, "test-fighter")
+		delete(PlaneMap, "test-dive-bomber")
+	})
+	aircraft := newAirOpsTestAircraft()
+	ship := &BattleShip{Uid: "carrier", Length: 200, CurPos: objPos.NewR(10, 10)}
+
+	plane := aircraft.TakeOffGroup(ship, "test-dive-bomber", 1000)
+	if plane == nil || plane.Name != "test-dive-bomber" || plane.Uid != "carrier/plane-1" {
+		t.Fatalf("take off plane = %+v, want carrier/plane-1 from dive bomber group", plane)
+	}
+	if count := aircraft.GroupCount("test-dive-bomber"); count != 1 {
+		t.Fatalf("dive bomber count = %d, want 1", count)
+	}
+	// 起飞冷却中不能再起飞
+	if plane := aircraft.TakeOffGroup(ship, "test-dive-bomber", 2000); plane != nil {
+		t.Fatal("take off during cooldown")
+	}
+	// 按累计起飞架次生成 Uid，与起飞时刻 / 分组无关
+	if plane := aircraft.TakeOffGroup(ship, "test-fighter", 4000); plane == nil || plane.Name != "test-fighter" ||
+		plane.Uid != "carrier/plane-2" {
+		t.Fatalf("take off plane = %+v, want fighter carrier/plane-2", plane)
+	}
+}
+
+func TestAirPatrolOrbitPos(t *testing.T) {
+	center := objPos.NewR(10, 10)
+	pos := AirPatrolOrbitPos(center, objPos.NewR(20, 10))
+
+	requireClose(t, pos.Distance(center), airPatrolOrbitRadius)
+	requireClose(t, math.Atan2(pos.RY-center.RY, pos.RX-center.RX), airPatrolOrbitStep)
+}
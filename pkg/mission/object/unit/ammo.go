@@ -0,0 +1,207 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/narasux/jutland/pkg/mission/clock"
+)
+
+// 弹药：火炮按齐射次数、鱼雷发射器 / 火箭炮按鱼雷 / 火箭弹枚数计算弹药容量（0 表示不限弹药），
+// 弹药耗尽后无法发射，需要到己方增援点或友军货轮附近补给
+const (
+	// ResupplyRange 增援点 / 货轮的补给范围
+	ResupplyRange = 4
+	// ResupplyInterval 补给间隔（任务时间，毫秒）
+	ResupplyInterval = 5000
+	// 每次补给恢复的弹药 & 燃油比例（按弹药 / 燃油容量，弹药至少 1 发）
+	resupplyRate = 0.1
+	// 己方增援点的补给倍率
+	reinforcePointResupplyRate = 2
+)
+
+// ammoMount 限制弹药的武器
+type ammoMount interface {
+	OutOfAmmo() bool
+	AmmoState() (ammo, capacity int)
+	Resupply(rate float64) int
+}
+
+var (
+	_ ammoMount = (*Gun)(nil)
+	_ ammoMount = (*TorpedoLauncher)(nil)
+	_ ammoMount = (*RocketLauncher)(nil)
+)
+
+// outOfAmmo 弹药是否耗尽（不限弹药时为 false）
+func outOfAmmo(ammo, capacity int) bool {
+	return capacity > 0 && ammo <= 0
+}
+
+// resupplyAmmo 按弹药容量比例补给（不超过容量），返回实际补给数量
+func resupplyAmmo(ammo *int, capacity int, rate float64) int {
+	if capacity <= 0 || *ammo >= capacity {
+		return 0
+	}
+	amount := min(capacity-*ammo, max(1, int(math.Ceil(float64(capacity)*rate))))
+	*ammo += amount
+	return amount
+}
+
+// OutOfAmmo 弹药是否耗尽
+func (g *Gun) OutOfAmmo() bool {
+	return outOfAmmo(g.Ammo, g.AmmoCapacity)
+}
+
+// AmmoState 剩余弹药 & 弹药容量
+func (g *Gun) AmmoState() (int, int) {
+	return g.Ammo, g.AmmoCapacity
+}
+
+// Resupply 补给弹药
+func (g *Gun) Resupply(rate float64) int {
+	return resupplyAmmo(&g.Ammo, g.AmmoCapacity, rate)
+}
+
+// consumeAmmo 消耗一次齐射的弹药
+func (g *Gun) consumeAmmo() {
+	if g.AmmoCapacity > 0 {
+		g.Ammo--
+	}
+}
+
+// OutOfAmmo 弹药是否耗尽
+func (lc *TorpedoLauncher) OutOfAmmo() bool {
+	return outOfAmmo(lc.Ammo, lc.AmmoCapacity)
+}
+
+// AmmoState 剩余弹药 & 弹药容量
+func (lc *TorpedoLauncher) AmmoState() (int, int) {
+	return lc.Ammo, lc.AmmoCapacity
+}
+
+// Resupply 补给弹药
+func (lc *TorpedoLauncher) Resupply(rate float64) int {
+	return resupplyAmmo(&lc.Ammo, lc.AmmoCapacity, rate)
+}
+
+// consumeAmmo 消耗一枚鱼雷
+func (lc *TorpedoLauncher) consumeAmmo() {
+	if lc.AmmoCapacity > 0 {
+		lc.Ammo--
+	}
+}
+
+// OutOfAmmo 弹药是否耗尽
+func (r *RocketLauncher) OutOfAmmo() bool {
+	return outOfAmmo(r.Ammo, r.AmmoCapacity)
+}
+
+// AmmoState 剩余弹药 & 弹药容量
+func (r *RocketLauncher) AmmoState() (int, int) {
+	return r.Ammo, r.AmmoCapacity
+}
+
+// Resupply 补给弹药
+func (r *RocketLauncher) Resupply(rate float64) int {
+	return resupplyAmmo(&r.Ammo, r.AmmoCapacity, rate)
+}
+
+// consumeAmmo 消耗一枚火箭弹
+func (r *RocketLauncher) consumeAmmo() {
+	if r.AmmoCapacity > 0 {
+		r.Ammo--
+	}
+}
+
+// ammoMounts 该类武器的全部挂载
+func (w *ShipWeapon) ammoMounts(t WeaponType) []ammoMount {
+	mounts := []ammoMount{}
+	switch t {
+	case WeaponTypeMainGun:
+		for _, g := range w.MainGuns {
+			mounts = append(mounts, g)
+		}
+	case WeaponTypeSecondaryGun:
+		for _, g := range w.SecondaryGuns {
+			mounts = append(mounts, g)
+		}
+	case WeaponTypeAntiAircraftGun:
+		for _, g := range w.AntiAircraftGuns {
+			mounts = append(mounts, g)
+		}
+	case WeaponTypeTorpedo:
+		for _, lc := range w.Torpedoes {
+			mounts = append(mounts, lc)
+		}
+	case WeaponTypeRocket:
+		for _, r := range w.Rockets {
+			mounts = append(mounts, r)
+		}
+	}
+	return mounts
+}
+
+// AmmoWeaponTypes 可能限制弹药的武器类型（按展示顺序）
+var AmmoWeaponTypes = []WeaponType{
+	WeaponTypeMainGun,
+	WeaponTypeSecondaryGun,
+	WeaponTypeAntiAircraftGun,
+	WeaponTypeTorpedo,
+	WeaponTypeRocket,
+}
+
+// Ammo 该类武器的剩余弹药 & 弹药容量之和（不限弹药的武器不计）
+func (w *ShipWeapon) Ammo(t WeaponType) (ammo, capacity int) {
+	for _, mount := range w.ammoMounts(t) {
+		a, c := mount.AmmoState()
+		if c > 0 {
+			ammo, capacity = ammo+a, capacity+c
+		}
+	}
+	return ammo, capacity
+}
+
+// OutOfAmmo 该类武器是否全部弹药耗尽（没有该类武器 / 存在不限弹药的武器时为 false）
+func (w *ShipWeapon) OutOfAmmo(t WeaponType) bool {
+	mounts := w.ammoMounts(t)
+	if len(mounts) == 0 {
+		return false
+	}
+	for _, mount := range mounts {
+		if !mount.OutOfAmmo() {
+			return false
+		}
+	}
+	return true
+}
+
+// AmmoRate 全部限制弹药的武器的剩余弹药比例（按各类武器的最低值，不限弹药时为 1）
+func (w *ShipWeapon) AmmoRate() float64 {
+	rate := 1.0
+	for _, t := range AmmoWeaponTypes {
+		if ammo, capacity := w.Ammo(t); capacity > 0 {
+			rate = min(rate, float64(ammo)/float64(capacity))
+		}
+	}
+	return rate
+}
+
+// Resupply 在补给范围内补给弹药 & 燃油（固定间隔），atReinforcePoint 表示在己方增援点附近（补给更快），返回是否补给了弹药或燃油
+func (s *BattleShip) Resupply(now int64, atReinforcePoint bool) bool {
+	if s.CurHP <= 0 || clock.Since(now, s.LastResupplyAt) < ResupplyInterval {
+		return false
+	}
+	rate := resupplyRate
+	if atReinforcePoint {
+		rate *= reinforcePointResupplyRate
+	}
+	resupplied := 0
+	for _, t := range AmmoWeaponTypes {
+		for _, mount := range s.Weapon.ammoMounts(t) {
+			resupplied += mount.Resupply(rate)
+		}
+	}
+	refueled := s.refuel(rate)
+	s.LastResupplyAt = now
+	return resupplied > 0 || refueled
+}
@@ -0,0 +1,67 @@
+package unit
+
+import (
+	"testing"
+)
+
+func TestShipWeaponAmmo(t *testing.T) {
+	ship := newDamageTestShip()
+	ship.Weapon = ShipWeapon{
+		MainGuns: []*Gun{
+			{AmmoCapacity: 100, Ammo: 0},
+			{AmmoCapacity: 100, Ammo: 0},
+		},
+		// 不限弹药的防空炮不会耗尽
+		AntiAircraftGuns: []*Gun{{}},
+		Torpedoes:        []*TorpedoLauncher{{BulletCount: 4, AmmoCapacity: 8, Ammo: 3}},
+	}
+
+	if !ship.Weapon.OutOfAmmo(WeaponTypeMainGun) || ship.Weapon.OutOfAmmo(WeaponTypeAntiAircraftGun) {
+		t.Fatalf("main guns should be out of ammo and unlimited anti-aircraft guns should not")
+	}
+	if ship.Weapon.OutOfAmmo(WeaponTypeRocket) {
+		t.Fatalf("ship without rockets should not be out of rocket ammo")
+	}
+	if ammo, capacity := ship.Weapon.Ammo(WeaponTypeTorpedo); ammo != 3 || capacity != 8 {
+		t.Fatalf("expected torpedo ammo 3/8, got %d/%d", ammo, capacity)
+	}
+	if ship.Weapon.MainGunReloaded(0) {
+		t.Fatalf("main guns without ammo should not count as reloaded")
+	}
+	requireClose(t, ship.Weapon.AmmoRate(), 0)
+
+	// 弹药耗尽的火炮不可发射
+	if bullets := ship.Weapon.MainGuns[0].Fire(nil, nil, 1e6, nil); len(bullets) != 0 {
+		t.Fatalf("gun without ammo should not fire")
+	}
+}
+
+func TestShipResupply(t *testing.T) {
+	ship := newDamageTestShip()
+	ship.Weapon = ShipWeapon{
+		MainGuns:  []*Gun{{AmmoCapacity: 100, Ammo: 95}},
+		Torpedoes: []*TorpedoLauncher{{BulletCount: 2, AmmoCapacity: 4, Ammo: 0}},
+	}
+
+	// 按容量比例补给（至少 1 发，不超过容量）
+	if !ship.Resupply(ResupplyInterval, false) {
+		t.Fatalf("ship should be resupplied")
+	}
+	if ship.Weapon.MainGuns[0].Ammo != 100 || ship.Weapon.Torpedoes[0].Ammo != 1 {
+		t.Fatalf("expected ammo 100 / 1, got %d / %d", ship.Weapon.MainGuns[0].Ammo, ship.Weapon.Torpedoes[0].Ammo)
+	}
+
+	// 补给间隔内不补给，增援点附近补给更快
+	if ship.Resupply(ResupplyInterval+1000, true) {
+		t.Fatalf("ship should not be resupplied within the interval")
+	}
+	if !ship.Resupply(ResupplyInterval*2, true) || ship.Weapon.Torpedoes[0].Ammo != 2 {
+		t.Fatalf("expected torpedo ammo 2 at reinforce point, got %d", ship.Weapon.Torpedoes[0].Ammo)
+	}
+
+	// 满弹药时不再补给
+	ship.Weapon.Torpedoes[0].Ammo = 4
+	if ship.Resupply(ResupplyInterval*3, true) {
+		t.Fatalf("fully stocked ship should not be resupplied")
+	}
+}
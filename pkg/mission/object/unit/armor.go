@@ -0,0 +1,55 @@
+package unit
+
+import (
+	"math"
+
+	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
+)
+
+// 装甲结算：平射命中舷侧装甲带（按入射角倾斜，角度过小且穿深不足时跳弹），曲射 / 炸弹命中甲板装甲，
+// 穿深不足时未击穿，大口径炮弹命中过薄的装甲时引信未触发（过度击穿）
+const (
+	// 弹道与舰体纵轴的夹角（度）小于该值时跳弹（舰首 / 舰尾方向的命中）
+	ricochetAngle = 20.0
+	// 倾斜带来的等效装甲厚度最大倍数
+	maxEffectiveArmorRate = 3.0
+	// 过度击穿：口径不小于该值，且等效装甲不足口径的一定比例时引信不触发
+	overPenetrationMinDiameter = 203
+	overPenetrationArmorRate   = 1.0 / 6
+	// 跳弹 / 未击穿 / 过度击穿时造成的伤害比例
+	ricochetDamageRate        = 0.05
+	nonPenetrationDamageRate  = 0.1
+	overPenetrationDamageRate = 0.35
+)
+
+// impactAngle 弹道与舰体纵轴的夹角（度，0 ~ 90，90 为垂直命中舷侧）
+func (s *BattleShip) impactAngle(bulletRotation float64) float64 {
+	relative := math.Mod(math.Abs(bulletRotation-s.CurRotation), 180)
+	return min(relative, 180-relative)
+}
+
+// resolveArmor 按当前穿深与入射角结算命中装甲的结果，返回结果与伤害比例
+// 注：鱼雷（命中水线以下）/ 镭射不结算装甲
+func (s *BattleShip) resolveArmor(bullet *objBullet.Bullet) (objBullet.ArmorResult, float64) {
+	if bullet.Type != objBullet.TypeShell && bullet.Type != objBullet.TypeBomb && bullet.Type != objBullet.TypeRocket {
+		return objBullet.ArmorResultNone, 1
+	}
+
+	penetration, armor := bullet.CurPenetration(), s.DeckArmor
+	if bullet.ShotType == objBullet.ShotTypeDirect {
+		// 入射角过小，且穿深不足以击穿最大倾斜的装甲时跳弹
+		impact := s.impactAngle(bullet.Rotation)
+		if s.BeltArmor > 0 && impact < ricochetAngle && penetration < s.BeltArmor*maxEffectiveArmorRate {
+			return objBullet.ArmorResultRicochet, ricochetDamageRate
+		}
+		armor = s.BeltArmor * min(maxEffectiveArmorRate, 1/math.Sin(impact*math.Pi/180))
+	}
+
+	if penetration < armor {
+		return objBullet.ArmorResultNonPenetration, nonPenetrationDamageRate
+	}
+	if bullet.Diameter >= overPenetrationMinDiameter && armor < float64(bullet.Diameter)*overPenetrationArmorRate {
+		return objBullet.ArmorResultOverPenetration, overPenetrationDamageRate
+	}
+	return objBullet.ArmorResultPenetration, 1
+}
@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
+)
+
+func newArmorTestShip() *BattleShip {
+	return &BattleShip{CurHP: 1000, TotalHP: 1000, BeltArmor: 300, DeckArmor: 150, CurRotation: 0}
+}
+
+func TestResolveArmorByImpactAngle(t *testing.T) {
+	ship := newArmorTestShip()
+	shell := &objBullet.Bullet{Type: objBullet.TypeShell, Diameter: 406, Penetration: 700, ShotType: objBullet.ShotTypeDirect}
+
+	// 垂直命中舷侧：击穿
+	shell.Rotation = 90
+	if result, rate := ship.resolveArmor(shell); result != objBullet.ArmorResultPenetration || rate != 1 {
+		t.Fatalf("broadside hit should penetrate, got %v / %v", result, rate)
+	}
+	// 倾斜命中：等效装甲增加，穿深不足时未击穿
+	shell.Rotation = 25
+	if result, _ := ship.resolveArmor(shell); result != objBullet.ArmorResultNonPenetration {
+		t.Fatalf("angled hit should not penetrate, got %v", result)
+	}
+	// 舰尾方向命中：跳弹
+	shell.Rotation = 190
+	requireClose(t, ship.impactAngle(shell.Rotation), 10)
+	if result, rate := ship.resolveArmor(shell); result != objBullet.ArmorResultRicochet || rate != ricochetDamageRate {
+		t.Fatalf("stern hit should ricochet, got %v / %v", result, rate)
+	}
+	// 穿深衰减后不足以击穿
+	shell.Rotation, shell.ForwardAge, shell.Speed = 90, 100, 0.4
+	requireClose(t, shell.CurPenetration(), 700*0.4)
+	if result, _ := ship.resolveArmor(shell); result != objBullet.ArmorResultNonPenetration {
+		t.Fatalf("long range hit should not penetrate, got %v", result)
+	}
+}
+
+func TestResolveArmorDeckAndOverPenetration(t *testing.T) {
+	ship := newArmorTestShip()
+	bomb := &objBullet.Bullet{Type: objBullet.TypeBomb, Diameter: 450, Penetration: 140, ShotType: objBullet.ShotTypeArcing}
+	if result, _ := ship.resolveArmor(bomb); result != objBullet.ArmorResultNonPenetration {
+		t.Fatalf("bomb should not penetrate thick deck, got %v", result)
+	}
+
+	// 大口径炮弹命中薄装甲：过度击穿
+	ship.BeltArmor, ship.DeckArmor = 0, 0
+	shell := &objBullet.Bullet{Type: objBullet.TypeShell, Diameter: 406, Penetration: 700, ShotType: objBullet.ShotTypeDirect}
+	if result, rate := ship.resolveArmor(shell); result != objBullet.ArmorResultOverPenetration || rate != overPenetrationDamageRate {
+		t.Fatalf("heavy shell should over-penetrate unarmored hull, got %v / %v", result, rate)
+	}
+	shell.Diameter, shell.Penetration = 127, 140
+	if result, _ := ship.resolveArmor(shell); result != objBullet.ArmorResultPenetration {
+		t.Fatalf("light shell should penetrate unarmored hull, got %v", result)
+	}
+
+	// 鱼雷不结算装甲
+	torpedo := &objBullet.Bullet{Type: objBullet.TypeTorpedo, ShotType: objBullet.ShotTypeDirect}
+	if result, rate := ship.resolveArmor(torpedo); result != objBullet.ArmorResultNone || rate != 1 {
+		t.Fatalf("torpedo should ignore armor, got %v / %v", result, rate)
+	}
+}
+
+func TestHurtByArmorReplacesFlatReduction(t *testing.T) {
+	ship := newArmorTestShip()
+	ship.HorizontalDamageReduction, ship.VerticalDamageReduction = 0.5, 0.25
+	rng := rand.New(fixedSource(0))
+
+	// 击穿装甲的炮弹造成全额伤害，不再叠加固定的伤害减免
+	shell := &objBullet.Bullet{
+		Type: objBullet.TypeShell, Diameter: 406, Penetration: 700, ShotType: objBullet.ShotTypeDirect,
+		Rotation: 90, Damage: 100,
+	}
+	ship.HurtBy(shell, 0, rng)
+	requireClose(t, shell.RealDamage, 100)
+
+	// 不结算装甲的鱼雷仍按水平伤害减免
+	torpedo := &objBullet.Bullet{Type: objBullet.TypeTorpedo, ShotType: objBullet.ShotTypeDirect, Damage: 100}
+	ship.HurtBy(torpedo, 0, rng)
+	requireClose(t, torpedo.RealDamage, 50)
+}
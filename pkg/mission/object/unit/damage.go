@@ -0,0 +1,173 @@
+package unit
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/narasux/jutland/pkg/common/constants"
+	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+// 部件战损：命中有概率击毁命中点附近的武器，或击伤引擎（降低航速 & 加速度）/ 舵机（降低转向速度），一段时间后自行修复
+const (
+	// 单次命中击伤部件的概率为实际伤害占总生命值比例的倍数（不超过上限）
+	componentDamageRate    = 3.0
+	componentDamageMaxRate = 0.25
+	// 命中点与武器位置的最大距离（舰体半长比例，与 PosPercent 一致），超出则不会击毁该武器
+	mountHitRange = 0.2
+	// 引擎舱（舰体中后部）& 舵机（舰尾）所在的区间（舰体半长比例）
+	engineRoomFront = 0.1
+	engineRoomBack  = -0.6
+	rudderBack      = -0.75
+	// 修复耗时（任务时间，毫秒）
+	mountRepairTime  = 45000
+	engineRepairTime = 30000
+	rudderRepairTime = 20000
+	// 引擎受损时的航速 & 加速度比例，舵机受损时的转向速度比例
+	damagedEngineRate = 0.5
+	damagedRudderRate = 0.4
+)
+
+// ShipDamage 战舰引擎 / 舵机战损情况（修复完成的任务时间，毫秒，0 表示完好）
+type ShipDamage struct {
+	EngineRepairAt int64
+	RudderRepairAt int64
+}
+
+// shipMount 战舰上可以被击毁的武器（火炮 / 鱼雷发射器 / 火箭炮）
+type shipMount struct {
+	posPercent float64
+	repairAt   *int64
+}
+
+// mounts 战舰上的所有武器（按主炮，副炮，防空炮，鱼雷，火箭炮的顺序）
+func (s *BattleShip) mounts() []shipMount {
+	mounts := []shipMount{}
+	for _, guns := range [][]*Gun{s.Weapon.MainGuns, s.Weapon.SecondaryGuns, s.Weapon.AntiAircraftGuns} {
+		for _, g := range guns {
+			mounts = append(mounts, shipMount{posPercent: g.PosPercent, repairAt: &g.RepairAt})
+		}
+	}
+	for _, lc := range s.Weapon.Torpedoes {
+		mounts = append(mounts, shipMount{posPercent: lc.PosPercent, repairAt: &lc.RepairAt})
+	}
+	for _, r := range s.Weapon.Rockets {
+		mounts = append(mounts, shipMount{posPercent: r.PosPercent, repairAt: &r.RepairAt})
+	}
+	return mounts
+}
+
+// hitPosPercent 命中点在舰体纵轴上的相对位置（与武器 PosPercent 一致：舰首为正，舰尾为负，按舰体半长计算）
+func (s *BattleShip) hitPosPercent(pos objPos.MapPos) float64 {
+	halfLength := s.Length / constants.MapBlockSize / 2
+	if halfLength <= 0 {
+		return 0
+	}
+	// 舰首方向为 (sin, -cos)，与武器位置的计算方式一致
+	rad := s.CurRotation * math.Pi / 180
+	offset := (pos.RX-s.CurPos.RX)*math.Sin(rad) - (pos.RY-s.CurPos.RY)*math.Cos(rad)
+	return max(-1, min(1, offset/halfLength))
+}
+
+// damageComponents 按命中点与实际伤害随机击伤部件
+// 注：无论是否击伤都只消耗一个随机数，击伤时同一个随机数决定优先击毁武器还是引擎 / 舵机
+func (s *BattleShip) damageComponents(bullet *objBullet.Bullet, realDamage float64, now int64, rng *rand.Rand) {
+	if s.CurHP <= 0 || s.TotalHP <= 0 {
+		return
+	}
+	rate := min(componentDamageMaxRate, realDamage/s.TotalHP*componentDamageRate)
+	roll := rng.Float64()
+	if roll >= rate {
+		return
+	}
+
+	hit := s.hitPosPercent(bullet.CurPos)
+	inEngineRoom := engineRoomBack <= hit && hit <= engineRoomFront
+	inRudder := hit <= rudderBack
+	if roll < rate/2 || (!inEngineRoom && !inRudder) {
+		// 击毁命中点附近最近的完好武器
+		var nearest *int64
+		nearestDist := mountHitRange
+		for _, m := range s.mounts() {
+			if dist := math.Abs(m.posPercent - hit); *m.repairAt == 0 && dist <= nearestDist {
+				nearest, nearestDist = m.repairAt, dist
+			}
+		}
+		if nearest != nil {
+			*nearest = now + mountRepairTime
+			return
+		}
+	}
+	if inEngineRoom && s.Damage.EngineRepairAt == 0 {
+		s.Damage.EngineRepairAt = now + engineRepairTime
+	} else if inRudder && s.Damage.RudderRepairAt == 0 {
+		s.Damage.RudderRepairAt = now + rudderRepairTime
+	}
+}
+
+// RepairComponents 修复已到修复时间的武器、引擎 & 舵机
+func (s *BattleShip) RepairComponents(now int64) {
+	for _, m := range s.mounts() {
+		if *m.repairAt != 0 && now >= *m.repairAt {
+			*m.repairAt = 0
+		}
+	}
+	if s.Damage.EngineRepairAt != 0 && now >= s.Damage.EngineRepairAt {
+		s.Damage.EngineRepairAt = 0
+	}
+	if s.Damage.RudderRepairAt != 0 && now >= s.Damage.RudderRepairAt {
+		s.Damage.RudderRepairAt = 0
+	}
+}
+
+// EngineDamaged 引擎是否受损
+func (s *BattleShip) EngineDamaged() bool {
+	return s.Damage.EngineRepairAt != 0
+}
+
+// RudderDamaged 舵机是否受损
+func (s *BattleShip) RudderDamaged() bool {
+	return s.Damage.RudderRepairAt != 0
+}
+
+// EffectiveMaxSpeed 考虑引擎战损、进水 & 燃油不足后的最大速度
+func (s *BattleShip) EffectiveMaxSpeed() float64 {
+	maxSpeed := s.MaxSpeed * (1 - float64(s.Flooding())*floodSpeedPenalty) * s.fuelSpeedRate()
+	if s.EngineDamaged() {
+		return maxSpeed * damagedEngineRate
+	}
+	return maxSpeed
+}
+
+// EffectiveAcceleration 考虑引擎战损后的加速度
+func (s *BattleShip) EffectiveAcceleration() float64 {
+	if s.EngineDamaged() {
+		return s.Acceleration * damagedEngineRate
+	}
+	return s.Acceleration
+}
+
+// EffectiveRotateSpeed 考虑舵机战损后的转向速度
+func (s *BattleShip) EffectiveRotateSpeed() float64 {
+	if s.RudderDamaged() {
+		return s.RotateSpeed * damagedRudderRate
+	}
+	return s.RotateSpeed
+}
+
+// DamagedMountPositions 战损中的武器所在的地图位置（用于绘制战损标识）
+func (s *BattleShip) DamagedMountPositions() []objPos.MapPos {
+	positions := []objPos.MapPos{}
+	for _, m := range s.mounts() {
+		if *m.repairAt == 0 {
+			continue
+		}
+		pos := s.CurPos.Copy()
+		offset := m.posPercent * s.Length / constants.MapBlockSize / 2
+		pos.AddRx(math.Sin(s.CurRotation*math.Pi/180) * offset)
+		pos.SubRy(math.Cos(s.CurRotation*math.Pi/180) * offset)
+		positions = append(positions, pos)
+	}
+	return positions
+}
@@ -0,0 +1,91 @@
+package unit
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/narasux/jutland/pkg/common/constants"
+	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+// fixedSource 固定输出的随机数源（0 对应 Float64 为 0，即必定击伤部件）
+type fixedSource uint64
+
+func (s fixedSource) Uint64() uint64 {
+	return uint64(s)
+}
+
+func newDamageTestShip() *BattleShip {
+	return &BattleShip{
+		CurHP: 1000, TotalHP: 1000, MaxSpeed: 0.3, Acceleration: 0.1, RotateSpeed: 5,
+		Length: 4 * constants.MapBlockSize, CurPos: objPos.NewR(10, 10),
+		Weapon: ShipWeapon{
+			MainGuns:  []*Gun{{PosPercent: 0.5}, {PosPercent: -0.5}},
+			Torpedoes: []*TorpedoLauncher{{PosPercent: 0}},
+		},
+	}
+}
+
+// hitAt 命中舰体纵轴上指定位置（舰首朝北，舰体半长为 2 格）
+func hitAt(ship *BattleShip, posPercent float64) *objBullet.Bullet {
+	return &objBullet.Bullet{CurPos: objPos.NewR(ship.CurPos.RX, ship.CurPos.RY-posPercent*2), Damage: 1000}
+}
+
+func TestDamageComponentsKnocksOutNearestMount(t *testing.T) {
+	ship := newDamageTestShip()
+	rng := rand.New(fixedSource(0))
+
+	requireClose(t, ship.hitPosPercent(hitAt(ship, 0.45).CurPos), 0.45)
+	ship.damageComponents(hitAt(ship, 0.45), 1000, 100, rng)
+	if ship.Weapon.MainGuns[0].RepairAt != 100+mountRepairTime || ship.Weapon.MainGuns[1].Damaged() {
+		t.Fatalf("only the fore turret should be knocked out, got %+v", ship.Weapon.MainGuns)
+	}
+	if ship.Weapon.MainGuns[0].Fire(ship, ship, 200, rng) != nil {
+		t.Fatalf("knocked out turret should not fire")
+	}
+	// 命中点附近已经没有完好的武器，引擎舱区间外不再击伤其他部件
+	ship.damageComponents(hitAt(ship, 0.45), 1000, 200, rng)
+	if ship.Weapon.Torpedoes[0].Damaged() || ship.EngineDamaged() || ship.RudderDamaged() {
+		t.Fatalf("far mounts and engine should stay intact")
+	}
+
+	// 武器全部战损后，该类武器视为不可用
+	ship.damageComponents(hitAt(ship, -0.5), 1000, 300, rng)
+	if !ship.Weapon.AllDamaged(WeaponTypeMainGun) || ship.Weapon.AllDamaged(WeaponTypeTorpedo) {
+		t.Fatalf("all main guns should be damaged")
+	}
+}
+
+func TestDamageComponentsEngineAndRudder(t *testing.T) {
+	ship := newDamageTestShip()
+	ship.Weapon = ShipWeapon{}
+	rng := rand.New(fixedSource(0))
+
+	ship.damageComponents(hitAt(ship, -0.3), 1000, 100, rng)
+	ship.damageComponents(hitAt(ship, -0.9), 1000, 100, rng)
+	if !ship.EngineDamaged() || !ship.RudderDamaged() {
+		t.Fatalf("engine and rudder should be damaged, got %+v", ship.Damage)
+	}
+	requireClose(t, ship.EffectiveMaxSpeed(), 0.3*damagedEngineRate)
+	requireClose(t, ship.EffectiveAcceleration(), 0.1*damagedEngineRate)
+	requireClose(t, ship.EffectiveRotateSpeed(), 5*damagedRudderRate)
+
+	// 到达修复时间后自行修复
+	ship.RepairComponents(100 + rudderRepairTime)
+	if !ship.EngineDamaged() || ship.RudderDamaged() {
+		t.Fatalf("only rudder should be repaired, got %+v", ship.Damage)
+	}
+	ship.RepairComponents(100 + engineRepairTime)
+	requireClose(t, ship.EffectiveMaxSpeed(), 0.3)
+}
+
+func TestDamageComponentsRateByDamage(t *testing.T) {
+	ship := newDamageTestShip()
+	// 随机数接近 1，低伤害命中不会击伤部件
+	rng := rand.New(fixedSource(1<<53 - 1))
+	ship.damageComponents(hitAt(ship, 0.5), 10, 100, rng)
+	if ship.Weapon.MainGuns[0].Damaged() {
+		t.Fatalf("light hit should not knock out turret")
+	}
+}
@@ -0,0 +1,38 @@
+package unit
+
+// 各舰种默认探测范围（地图格）
+var defaultShipDetectionRanges = map[ShipType]float64{
+	ShipTypeAircraftCarrier: 18,
+	ShipTypeBattleShip:      20,
+	ShipTypeCruiser:         22,
+	ShipTypeDestroyer:       18,
+	ShipTypeFrigate:         16,
+	ShipTypeTorpedoBoat:     14,
+	ShipTypeCargo:           10,
+	ShipTypeHospital:        10,
+}
+
+// 各机种默认探测范围（地图格）
+var defaultPlaneDetectionRanges = map[PlaneType]float64{
+	PlaneTypeFighter:       14,
+	PlaneTypeDiveBomber:    16,
+	PlaneTypeTorpedoBomber: 16,
+}
+
+// DefaultShipDetectionRange 战舰默认探测范围，不小于战舰武器的最大射程
+func DefaultShipDetectionRange(s *BattleShip) float64 {
+	detectionRange, ok := defaultShipDetectionRanges[s.Type]
+	if !ok {
+		detectionRange = 24
+	}
+	return max(detectionRange, s.Weapon.MaxToShipRange, s.Weapon.MaxToPlaneRange)
+}
+
+// DefaultPlaneDetectionRange 战机默认探测范围，不小于战机武器的最大射程
+func DefaultPlaneDetectionRange(p *Plane) float64 {
+	detectionRange, ok := defaultPlaneDetectionRanges[p.Type]
+	if !ok {
+		detectionRange = 14
+	}
+	return max(detectionRange, p.Weapon.MaxToShipRange, p.Weapon.MaxToPlaneRange)
+}
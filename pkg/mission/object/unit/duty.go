@@ -0,0 +1,72 @@
+package unit
+
+import (
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+// DutyType 值守任务类型
+type DutyType string
+
+const (
+	// DutyNone 没有值守任务
+	DutyNone DutyType = ""
+	// DutyEscort 护航：在友舰周围保持阵位，迎击逼近友舰的敌舰
+	DutyEscort DutyType = "escort"
+	// DutyGuard 警戒：迎击进入警戒区域的敌舰，敌舰离开后返回警戒点
+	DutyGuard DutyType = "guard"
+)
+
+// ShipDuty 战舰值守任务（由任务管理器每帧推进，直到被新的命令取消）
+type ShipDuty struct {
+	Type DutyType
+	// 护航对象 Uid
+	TargetUid string
+	// 警戒点 & 警戒半径
+	Anchor objPos.MapPos
+	Radius float64
+	// 警戒时正在追击的敌舰 Uid
+	ChaseUid string
+}
+
+// HasDuty 是否有值守任务
+func (s *BattleShip) HasDuty() bool {
+	return s.Duty.Type != DutyNone
+}
+
+// IsIdle 是否空闲：停航，没有攻击目标 / 排队命令 / 值守任务，且不是编队跟随舰
+func (s *BattleShip) IsIdle() bool {
+	return s.CurSpeed == 0 && s.AttackTarget == "" && len(s.Orders) == 0 && !s.HasDuty() &&
+		(!s.InFormation() || s.IsFormationGuide())
+}
+
+// AssignDuty 指派值守任务：取代排队命令，并脱离编队
+func (s *BattleShip) AssignDuty(duty ShipDuty) {
+	if duty.Type == DutyNone {
+		s.ClearDuty()
+		return
+	}
+	duty.ChaseUid = ""
+	s.Duty = duty
+	s.ClearOrders()
+	if s.InFormation() {
+		s.LeaveFormation()
+	}
+}
+
+// ClearDuty 取消值守任务
+func (s *BattleShip) ClearDuty() {
+	s.Duty = ShipDuty{}
+}
+
+// Patrol 在多个航点之间循环巡逻（取代现有的排队命令与值守任务）
+func (s *BattleShip) Patrol(points []objPos.MapPos) {
+	s.ClearDuty()
+	s.ClearOrders()
+	// 编队跟随舰不执行排队命令，需要先脱离编队
+	if s.InFormation() && !s.IsFormationGuide() {
+		s.LeaveFormation()
+	}
+	for _, pos := range points {
+		s.EnqueueOrder(ShipOrder{Type: OrderTypePatrol, Pos: pos})
+	}
+}
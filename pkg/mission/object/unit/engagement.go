@@ -0,0 +1,350 @@
+package unit
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/narasux/jutland/pkg/i18n"
+	"github.com/narasux/jutland/pkg/mission/clock"
+	"github.com/narasux/jutland/pkg/mission/object"
+	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+// FireStance 交战规则
+type FireStance string
+
+const (
+	// FireStanceFireAtWill 自由开火（默认）：攻击射程内所有敌人
+	FireStanceFireAtWill FireStance = ""
+	// FireStanceReturnFire 还击：只攻击指定目标，以及近期攻击过自己的敌人
+	FireStanceReturnFire FireStance = "returnFire"
+	// FireStanceHoldFire 停火：只攻击指定目标
+	FireStanceHoldFire FireStance = "holdFire"
+)
+
+// FireStances 可选交战规则（按切换顺序）
+var FireStances = []FireStance{FireStanceFireAtWill, FireStanceReturnFire, FireStanceHoldFire}
+
+// Next 下一个交战规则（循环切换）
+func (s FireStance) Next() FireStance {
+	idx := slices.Index(FireStances, s)
+	return FireStances[(idx+1)%len(FireStances)]
+}
+
+// ToDisplay 交战规则展示用名称
+func (s FireStance) ToDisplay() string {
+	switch s {
+	case FireStanceReturnFire:
+		return i18n.Text(i18n.MsgFireStanceReturnFire)
+	case FireStanceHoldFire:
+		return i18n.Text(i18n.MsgFireStanceHoldFire)
+	}
+	return i18n.Text(i18n.MsgFireStanceFireAtWill)
+}
+
+// TargetPolicy 武器目标选择策略
+type TargetPolicy string
+
+const (
+	// TargetPolicyDefault 按武器类型的默认策略
+	TargetPolicyDefault TargetPolicy = ""
+	// TargetPolicyLargest 吨位最大的目标（战舰优先）
+	TargetPolicyLargest TargetPolicy = "largest"
+	// TargetPolicyWeakest 生命值比例最低的目标
+	TargetPolicyWeakest TargetPolicy = "weakest"
+	// TargetPolicyNearest 距离最近的目标
+	TargetPolicyNearest TargetPolicy = "nearest"
+	// TargetPolicyPlanesFirst 战机优先，其次按距离
+	TargetPolicyPlanesFirst TargetPolicy = "planesFirst"
+)
+
+// TargetPolicies 可选目标策略（按切换顺序）
+var TargetPolicies = []TargetPolicy{
+	TargetPolicyLargest,
+	TargetPolicyWeakest,
+	TargetPolicyNearest,
+	TargetPolicyPlanesFirst,
+}
+
+// DefaultTargetPolicies 各类武器的默认目标策略：主炮 / 鱼雷打大船，副炮 / 火箭炮打最近的，防空炮优先打飞机
+var DefaultTargetPolicies = map[WeaponType]TargetPolicy{
+	WeaponTypeMainGun:         TargetPolicyLargest,
+	WeaponTypeSecondaryGun:    TargetPolicyNearest,
+	WeaponTypeAntiAircraftGun: TargetPolicyPlanesFirst,
+	WeaponTypeTorpedo:         TargetPolicyLargest,
+	WeaponTypeRocket:          TargetPolicyNearest,
+}
+
+// Next 下一个目标策略（循环切换）
+func (p TargetPolicy) Next() TargetPolicy {
+	idx := slices.Index(TargetPolicies, p)
+	return TargetPolicies[(idx+1)%len(TargetPolicies)]
+}
+
+// ToDisplay 目标策略展示用名称
+func (p TargetPolicy) ToDisplay() string {
+	switch p {
+	case TargetPolicyLargest:
+		return i18n.Text(i18n.MsgTargetPolicyLargest)
+	case TargetPolicyWeakest:
+		return i18n.Text(i18n.MsgTargetPolicyWeakest)
+	case TargetPolicyNearest:
+		return i18n.Text(i18n.MsgTargetPolicyNearest)
+	case TargetPolicyPlanesFirst:
+		return i18n.Text(i18n.MsgTargetPolicyPlanesFirst)
+	}
+	return ""
+}
+
+// WeaponDisplayName 武器类型展示用名称（目标策略相关）
+func WeaponDisplayName(weaponType WeaponType) string {
+	switch weaponType {
+	case WeaponTypeMainGun:
+		return i18n.Text(i18n.MsgWeaponMainGun)
+	case WeaponTypeSecondaryGun:
+		return i18n.Text(i18n.MsgWeaponSecondaryGun)
+	case WeaponTypeAntiAircraftGun:
+		return i18n.Text(i18n.MsgWeaponAntiAircraftGun)
+	case WeaponTypeTorpedo:
+		return i18n.Text(i18n.MsgWeaponTorpedo)
+	case WeaponTypeRocket:
+		return i18n.Text(i18n.MsgWeaponRocket)
+	}
+	return string(weaponType)
+}
+
+// StandOffRates 可选的保持距离比例（按切换顺序，0 表示逼近目标）
+var StandOffRates = []float64{0, 0.5, 0.7, 0.85}
+
+// DefaultStandOffRates 各舰种默认的保持距离比例（战列舰不该直接突脸）
+var DefaultStandOffRates = map[ShipType]float64{
+	ShipTypeBattleShip: 0.7,
+}
+
+// 保持距离比例上限，需要确保保持距离时目标仍在射程内
+const maxStandOffRate = 0.95
+
+// NextStandOffRate 下一个保持距离比例（循环切换，非预设值切换到第一个预设值）
+func NextStandOffRate(rate float64) float64 {
+	idx := slices.Index(StandOffRates, rate)
+	return StandOffRates[(idx+1)%len(StandOffRates)]
+}
+
+// StandOffDisplay 保持距离展示用名称（逼近目标时为空）
+func StandOffDisplay(rate float64) string {
+	if rate <= 0 {
+		return ""
+	}
+	return i18n.Format(i18n.MsgStandOffRange, map[string]any{"Percent": int(math.Round(rate * 100))})
+}
+
+// SetStandOffRate 设置与攻击目标保持的距离（对舰最大射程的比例）
+func (s *BattleShip) SetStandOffRate(rate float64) {
+	if math.IsNaN(rate) {
+		rate = 0
+	}
+	s.StandOffRate = max(0, min(maxStandOffRate, rate))
+}
+
+// StandOffDistance 与攻击目标保持的距离（地图格），0 表示逼近目标
+func (s *BattleShip) StandOffDistance() float64 {
+	return s.StandOffRate * s.Weapon.MaxToShipRange
+}
+
+// SetFireStance 设置交战规则
+func (s *BattleShip) SetFireStance(stance FireStance) {
+	s.FireStance = stance
+}
+
+// TargetPolicyOf 获取某类武器的目标策略（未设置时为默认策略）
+func (s *BattleShip) TargetPolicyOf(weaponType WeaponType) TargetPolicy {
+	return targetPolicyOf(s.TargetPolicies, weaponType)
+}
+
+// SetTargetPolicy 设置某类武器的目标策略（WeaponTypeAll + 默认策略表示全部恢复默认）
+func (s *BattleShip) SetTargetPolicy(weaponType WeaponType, policy TargetPolicy) {
+	s.TargetPolicies = setTargetPolicy(s.TargetPolicies, weaponType, policy)
+}
+
+// targetPolicyOf 从目标策略表中获取某类武器的目标策略（未设置时为默认策略）
+func targetPolicyOf(policies map[WeaponType]TargetPolicy, weaponType WeaponType) TargetPolicy {
+	if policy := policies[weaponType]; policy != TargetPolicyDefault {
+		return policy
+	}
+	return DefaultTargetPolicies[weaponType]
+}
+
+// setTargetPolicy 在目标策略表中设置某类武器的目标策略，返回更新后的策略表
+func setTargetPolicy(
+	policies map[WeaponType]TargetPolicy, weaponType WeaponType, policy TargetPolicy,
+) map[WeaponType]TargetPolicy {
+	if weaponType == WeaponTypeAll {
+		if policy == TargetPolicyDefault {
+			return nil
+		}
+		for wt := range DefaultTargetPolicies {
+			policies = setTargetPolicy(policies, wt, policy)
+		}
+		return policies
+	}
+	if policies == nil {
+		policies = map[WeaponType]TargetPolicy{}
+	}
+	if policy == TargetPolicyDefault {
+		delete(policies, weaponType)
+		return policies
+	}
+	policies[weaponType] = policy
+	return policies
+}
+
+// 还击的记忆时间（毫秒）：该时间内攻击过自己的敌人会被还击
+const returnFireMemory int64 = 30 * 1e3
+
+// RecordAttacker 记录攻击过自己的敌人（战舰 / 战机 Uid），用于还击
+func (s *BattleShip) RecordAttacker(uid string, now int64) {
+	if s.Attackers == nil {
+		s.Attackers = map[string]int64{}
+	}
+	for attacker, at := range s.Attackers {
+		if clock.Since(now, at) > returnFireMemory {
+			delete(s.Attackers, attacker)
+		}
+	}
+	s.Attackers[uid] = now
+}
+
+// RecentlyAttackedBy 指定敌人近期是否攻击过自己
+func (s *BattleShip) RecentlyAttackedBy(uid string, now int64) bool {
+	at, ok := s.Attackers[uid]
+	return ok && clock.Since(now, at) <= returnFireMemory
+}
+
+// FireByPolicy 按各类武器的目标策略开火：每门武器依次尝试排序后的目标，向第一个能够射击的目标开火
+// priority 为指定攻击目标（可以为 nil），排在其他目标之前（战机优先时排在战机之后）
+func (s *BattleShip) FireByPolicy(
+	priority Hurtable, targets []Hurtable, now int64, rng *rand.Rand,
+) (shotBullets []*objBullet.Bullet) {
+	// 如果生命值为 0，那还 Fire 个锤子，直接返回
+	if s.CurHP <= 0 || len(targets) == 0 {
+		return
+	}
+	ranked := map[TargetPolicy][]Hurtable{}
+	rankedBy := func(weaponType WeaponType) []Hurtable {
+		policy := s.TargetPolicyOf(weaponType)
+		if _, ok := ranked[policy]; !ok {
+			ranked[policy] = prioritize(RankTargets(policy, s.CurPos, targets), priority, policy)
+		}
+		return ranked[policy]
+	}
+	shotBullets = append(shotBullets, fireAtRanked(s, s.Weapon.MainGuns, rankedBy(WeaponTypeMainGun), now, rng)...)
+	shotBullets = append(shotBullets, fireAtRanked(s, s.Weapon.SecondaryGuns, rankedBy(WeaponTypeSecondaryGun), now, rng)...)
+	shotBullets = append(shotBullets, fireAtRanked(s, s.Weapon.AntiAircraftGuns, rankedBy(WeaponTypeAntiAircraftGun), now, rng)...)
+	shotBullets = append(shotBullets, fireAtRanked(s, s.Weapon.Torpedoes, rankedBy(WeaponTypeTorpedo), now, rng)...)
+	shotBullets = append(shotBullets, fireAtRanked(s, s.Weapon.Rockets, rankedBy(WeaponTypeRocket), now, rng)...)
+	return shotBullets
+}
+
+// fireAtRanked 每门武器向排序后第一个能够射击（类型匹配，在射程 & 射界内）的目标开火
+func fireAtRanked[W AttackWeapon](
+	shooter Attacker, weapons []W, targets []Hurtable, now int64, rng *rand.Rand,
+) (shotBullets []*objBullet.Bullet) {
+	for _, weapon := range weapons {
+		for _, target := range targets {
+			if bullets := weapon.Fire(shooter, target, now, rng); len(bullets) != 0 {
+				shotBullets = append(shotBullets, bullets...)
+				break
+			}
+		}
+	}
+	return shotBullets
+}
+
+// RankTargets 按目标策略对目标排序（稳定排序，其余条件相同时按距离，再按原有顺序）
+func RankTargets(policy TargetPolicy, pos objPos.MapPos, targets []Hurtable) []Hurtable {
+	ranked := slices.Clone(targets)
+	distance := func(t Hurtable) float64 {
+		return pos.Distance(t.MovementState().CurPos)
+	}
+	byDistance := func(a, b Hurtable) int {
+		return cmp.Compare(distance(a), distance(b))
+	}
+	isPlane := func(t Hurtable) bool {
+		return t.ObjType() == object.TypePlane
+	}
+
+	switch policy {
+	case TargetPolicyLargest:
+		slices.SortStableFunc(ranked, func(a, b Hurtable) int {
+			if c := cmp.Compare(targetTonnage(b), targetTonnage(a)); c != 0 {
+				return c
+			}
+			return byDistance(a, b)
+		})
+	case TargetPolicyWeakest:
+		slices.SortStableFunc(ranked, func(a, b Hurtable) int {
+			if c := cmp.Compare(targetHPRate(a), targetHPRate(b)); c != 0 {
+				return c
+			}
+			return byDistance(a, b)
+		})
+	case TargetPolicyPlanesFirst:
+		slices.SortStableFunc(ranked, func(a, b Hurtable) int {
+			if pa, pb := isPlane(a), isPlane(b); pa != pb {
+				if pa {
+					return -1
+				}
+				return 1
+			}
+			return byDistance(a, b)
+		})
+	default:
+		slices.SortStableFunc(ranked, byDistance)
+	}
+	return ranked
+}
+
+// prioritize 将指定攻击目标移到排序结果的最前面（战机优先时，指定的战舰排在战机之后）
+func prioritize(ranked []Hurtable, priority Hurtable, policy TargetPolicy) []Hurtable {
+	if priority == nil {
+		return ranked
+	}
+	idx := slices.IndexFunc(ranked, func(t Hurtable) bool { return t.ID() == priority.ID() })
+	if idx < 0 {
+		return ranked
+	}
+	ranked = slices.Delete(ranked, idx, idx+1)
+	pos := 0
+	if policy == TargetPolicyPlanesFirst && priority.ObjType() != object.TypePlane {
+		for pos < len(ranked) && ranked[pos].ObjType() == object.TypePlane {
+			pos++
+		}
+	}
+	return slices.Insert(ranked, pos, priority)
+}
+
+// targetTonnage 目标吨位（战机不参与比较，视为 0）
+func targetTonnage(t Hurtable) float64 {
+	if ship, ok := t.(*BattleShip); ok {
+		return ship.Tonnage
+	}
+	return 0
+}
+
+// targetHPRate 目标剩余生命值比例
+func targetHPRate(t Hurtable) float64 {
+	switch t := t.(type) {
+	case *BattleShip:
+		if t.TotalHP > 0 {
+			return t.CurHP / t.TotalHP
+		}
+	case *Plane:
+		if t.TotalHP > 0 {
+			return t.CurHP / t.TotalHP
+		}
+	}
+	return 1
+}
@@ -0,0 +1,104 @@
+package unit
+
+import (
+	"testing"
+
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+func rankedUids(targets []Hurtable) []string {
+	uids := make([]string, 0, len(targets))
+	for _, t := range targets {
+		uids = append(uids, t.ID())
+	}
+	return uids
+}
+
+func requireUids(t *testing.T, got []Hurtable, want ...string) {
+	t.Helper()
+	uids := rankedUids(got)
+	if len(uids) != len(want) {
+		t.Fatalf("want %v, got %v", want, uids)
+	}
+	for idx := range want {
+		if uids[idx] != want[idx] {
+			t.Fatalf("want %v, got %v", want, uids)
+		}
+	}
+}
+
+func TestRankTargets(t *testing.T) {
+	pos := objPos.NewR(0, 0)
+	small := &BattleShip{Uid: "small", Tonnage: 2000, CurHP: 10, TotalHP: 100, CurPos: objPos.NewR(0, 3)}
+	big := &BattleShip{Uid: "big", Tonnage: 40000, CurHP: 900, TotalHP: 1000, CurPos: objPos.NewR(0, 8)}
+	plane := &Plane{Uid: "plane", CurHP: 50, TotalHP: 100, CurPos: objPos.NewR(0, 5)}
+	targets := []Hurtable{big, plane, small}
+
+	requireUids(t, RankTargets(TargetPolicyLargest, pos, targets), "big", "small", "plane")
+	requireUids(t, RankTargets(TargetPolicyWeakest, pos, targets), "small", "plane", "big")
+	requireUids(t, RankTargets(TargetPolicyNearest, pos, targets), "small", "plane", "big")
+	requireUids(t, RankTargets(TargetPolicyPlanesFirst, pos, targets), "plane", "small", "big")
+	// 排序不修改原有切片
+	requireUids(t, targets, "big", "plane", "small")
+
+	// 指定攻击目标排在最前面，战机优先时排在战机之后
+	requireUids(t, prioritize(RankTargets(TargetPolicyNearest, pos, targets), big, TargetPolicyNearest),
+		"big", "small", "plane")
+	requireUids(t, prioritize(RankTargets(TargetPolicyPlanesFirst, pos, targets), big, TargetPolicyPlanesFirst),
+		"plane", "big", "small")
+}
+
+func TestTargetPolicySettings(t *testing.T) {
+	ship := &BattleShip{}
+	if ship.TargetPolicyOf(WeaponTypeAntiAircraftGun) != TargetPolicyPlanesFirst {
+		t.Fatalf("anti aircraft guns should target planes first by default")
+	}
+	ship.SetTargetPolicy(WeaponTypeMainGun, ship.TargetPolicyOf(WeaponTypeMainGun).Next())
+	if ship.TargetPolicyOf(WeaponTypeMainGun) != TargetPolicyWeakest {
+		t.Fatalf("want %q, got %q", TargetPolicyWeakest, ship.TargetPolicyOf(WeaponTypeMainGun))
+	}
+	ship.SetTargetPolicy(WeaponTypeAll, TargetPolicyDefault)
+	if len(ship.TargetPolicies) != 0 || ship.TargetPolicyOf(WeaponTypeMainGun) != TargetPolicyLargest {
+		t.Fatalf("policies should be reset to default, got %v", ship.TargetPolicies)
+	}
+
+	stance := FireStanceFireAtWill
+	for _, want := range []FireStance{FireStanceReturnFire, FireStanceHoldFire, FireStanceFireAtWill} {
+		if stance = stance.Next(); stance != want {
+			t.Fatalf("want stance %q, got %q", want, stance)
+		}
+	}
+}
+
+func TestRecentlyAttackedBy(t *testing.T) {
+	ship := &BattleShip{}
+	ship.RecordAttacker("enemy-1", 1000)
+	if !ship.RecentlyAttackedBy("enemy-1", 1000+returnFireMemory) {
+		t.Fatalf("attacker should be remembered")
+	}
+	if ship.RecentlyAttackedBy("enemy-1", 1001+returnFireMemory) || ship.RecentlyAttackedBy("enemy-2", 1000) {
+		t.Fatalf("attacker should be forgotten")
+	}
+	// 记录新的攻击者时清理过期记录
+	ship.RecordAttacker("enemy-2", 2000+returnFireMemory)
+	if _, ok := ship.Attackers["enemy-1"]; ok {
+		t.Fatalf("expired attacker should be pruned")
+	}
+}
+
+func TestStandOffRate(t *testing.T) {
+	ship := &BattleShip{Weapon: ShipWeapon{MaxToShipRange: 10}}
+	rate := ship.StandOffRate
+	for _, want := range []float64{0.5, 0.7, 0.85, 0} {
+		if rate = NextStandOffRate(rate); rate != want {
+			t.Fatalf("want stand-off rate %v, got %v", want, rate)
+		}
+	}
+	ship.SetStandOffRate(0.7)
+	requireClose(t, ship.StandOffDistance(), 7)
+	// 超出范围的比例会被修正，保证目标仍在射程内
+	ship.SetStandOffRate(2)
+	requireClose(t, ship.StandOffRate, maxStandOffRate)
+	ship.SetStandOffRate(-1)
+	requireClose(t, ship.StandOffDistance(), 0)
+}
@@ -0,0 +1,213 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/narasux/jutland/pkg/common/constants"
+	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/i18n"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	"github.com/narasux/jutland/pkg/resources/mapcfg"
+)
+
+// FormationType 编队阵型
+type FormationType string
+
+const (
+	// FormationNone 不在编队中
+	FormationNone FormationType = ""
+	// FormationLineAhead 单纵阵
+	FormationLineAhead FormationType = "lineAhead"
+	// FormationLineAbreast 单横阵
+	FormationLineAbreast FormationType = "lineAbreast"
+	// FormationWedge 楔形阵
+	FormationWedge FormationType = "wedge"
+	// FormationScreen 环形警戒阵（围绕向导舰）
+	FormationScreen FormationType = "screen"
+)
+
+// Formations 可选阵型（按切换顺序）
+var Formations = []FormationType{
+	FormationLineAhead,
+	FormationLineAbreast,
+	FormationWedge,
+	FormationScreen,
+}
+
+// ToDisplay 阵型展示用名称
+func (t FormationType) ToDisplay() string {
+	switch t {
+	case FormationLineAhead:
+		return i18n.Text(i18n.MsgFormationLineAhead)
+	case FormationLineAbreast:
+		return i18n.Text(i18n.MsgFormationLineAbreast)
+	case FormationWedge:
+		return i18n.Text(i18n.MsgFormationWedge)
+	case FormationScreen:
+		return i18n.Text(i18n.MsgFormationScreen)
+	}
+	return ""
+}
+
+// FormationSlot 编队位置（相对向导舰航向，单位：地图格）
+type FormationSlot struct {
+	// 沿航向的前后偏移（向前为正）
+	Forward float64
+	// 垂直航向的左右偏移（右舷为正）
+	Lateral float64
+}
+
+// Pos 根据向导舰位置 & 航向计算编队位置
+func (slot FormationSlot) Pos(guidePos objPos.MapPos, guideRotation float64) objPos.MapPos {
+	rad := guideRotation * math.Pi / 180
+	sin, cos := math.Sin(rad), math.Cos(rad)
+	return objPos.NewR(
+		guidePos.RX+slot.Forward*sin+slot.Lateral*cos,
+		guidePos.RY-slot.Forward*cos+slot.Lateral*sin,
+	)
+}
+
+// ShipFormation 战舰编队状态
+type ShipFormation struct {
+	Type FormationType
+	// 向导舰 Uid（向导舰为自身 Uid）
+	GuideUid string
+	// 编队位置（向导舰为零值）
+	Slot FormationSlot
+}
+
+// InFormation 是否在编队中
+func (s *BattleShip) InFormation() bool {
+	return s.Formation.Type != FormationNone
+}
+
+// IsFormationGuide 是否为编队向导舰
+func (s *BattleShip) IsFormationGuide() bool {
+	return s.InFormation() && s.Formation.GuideUid == s.Uid
+}
+
+// JoinFormation 加入编队，speedLimit 为编队航速（仅向导舰使用，0 表示不限速）
+func (s *BattleShip) JoinFormation(formation ShipFormation, speedLimit float64) {
+	if formation.Type == FormationNone {
+		s.LeaveFormation()
+		return
+	}
+	s.Formation = formation
+	s.SpeedLimit = speedLimit
+	// 编队成员由编队控制移动，不再执行值守任务
+	s.ClearDuty()
+	// 跟随舰由编队控制移动，不再执行排队命令
+	if !s.IsFormationGuide() {
+		s.ClearOrders()
+	}
+}
+
+// LeaveFormation 脱离编队
+func (s *BattleShip) LeaveFormation() {
+	s.Formation = ShipFormation{}
+	s.SpeedLimit = 0
+}
+
+// FormationSpacing 编队间距：最长舰船长度的 1.5 倍，至少 2 格
+func FormationSpacing(ships []*BattleShip) float64 {
+	spacing := 2.0
+	for _, ship := range ships {
+		spacing = max(spacing, ship.Length/constants.MapBlockSize*1.5)
+	}
+	return spacing
+}
+
+// FormationSlots 计算跟随舰的编队位置（不含向导舰），按离向导舰由近到远的顺序
+func FormationSlots(formationType FormationType, count int, spacing float64) []FormationSlot {
+	slots := make([]FormationSlot, 0, count)
+	for idx := 1; idx <= count; idx++ {
+		// 左右两翼交替排列：1 -> 右 1，2 -> 左 1，3 -> 右 2 ...
+		rank := float64((idx + 1) / 2)
+		side := 1.0
+		if idx%2 == 0 {
+			side = -1
+		}
+		switch formationType {
+		case FormationLineAhead:
+			slots = append(slots, FormationSlot{Forward: -float64(idx) * spacing})
+		case FormationLineAbreast:
+			slots = append(slots, FormationSlot{Lateral: side * rank * spacing})
+		case FormationWedge:
+			slots = append(slots, FormationSlot{Forward: -rank * spacing, Lateral: side * rank * spacing})
+		case FormationScreen:
+			// 均匀分布在向导舰四周的圆上，半径随舰船数量增大，保证相邻舰船间距
+			radius := max(2*spacing, float64(count)*spacing/(2*math.Pi))
+			angle := 2 * math.Pi * float64(idx-1) / float64(count)
+			slots = append(slots, FormationSlot{Forward: radius * math.Cos(angle), Lateral: radius * math.Sin(angle)})
+		}
+	}
+	return slots
+}
+
+// 编队航行参数
+const (
+	// 距离编队位置在该范围内视为到位，与向导舰同向同速
+	formationStationDistance = 0.5
+	// 距离编队位置超过该距离时全速追赶
+	formationCatchUpDistance = 3.0
+	// 超前编队位置不超过该距离时保持航向减速等待，不掉头
+	formationOverrunDistance = 2.0
+)
+
+// FollowFormation 编队跟随：驶向编队位置（离得越远越快），到位后与向导舰同向同速
+func (s *BattleShip) FollowFormation(
+	mapCfg *mapcfg.MapCfg, slotPos objPos.MapPos, guideRotation, guideSpeed float64,
+) {
+	// 如果生命值为 0，肯定是走不动，直接返回
+	if s.CurHP <= 0 {
+		return
+	}
+
+	// 应用全局速度倍率（向导舰速度已经应用过）
+	multiplier := config.G.SpeedMultiplier
+	maxSpeed := s.EffectiveMaxSpeed() * multiplier
+	acceleration := s.EffectiveAcceleration() * multiplier
+	rotateSpeed := s.EffectiveRotateSpeed() * multiplier
+
+	targetRotation, targetSpeed := guideRotation, guideSpeed
+	if dist := s.CurPos.Distance(slotPos); dist > formationStationDistance {
+		slotRotation := s.CurPos.Angle(slotPos)
+		if guideSpeed > 0 && dist < formationOverrunDistance && angleBetween(slotRotation, guideRotation) > 90 {
+			// 已经冲到编队位置前面，保持航向减速，等编队位置追上来
+			targetSpeed = guideSpeed / 2
+		} else {
+			targetRotation = slotRotation
+			targetSpeed = guideSpeed + (maxSpeed-guideSpeed)*min(1, dist/formationCatchUpDistance)
+		}
+	}
+	targetSpeed = min(targetSpeed, maxSpeed)
+
+	if s.CurRotation != targetRotation {
+		s.turnTo(targetRotation, rotateSpeed)
+	}
+	if s.CurSpeed < targetSpeed {
+		s.CurSpeed = min(targetSpeed, s.CurSpeed+acceleration)
+	} else {
+		s.CurSpeed = max(targetSpeed, s.CurSpeed-acceleration*2)
+	}
+	if s.CurSpeed == 0 {
+		return
+	}
+
+	nextPos := s.CurPos.Copy()
+	nextPos.AddRx(math.Sin(s.CurRotation*math.Pi/180) * s.CurSpeed)
+	nextPos.SubRy(math.Cos(s.CurRotation*math.Pi/180) * s.CurSpeed)
+	nextPos.EnsureBorder(float64(mapCfg.Width-2), float64(mapCfg.Height-2))
+	// 编队位置被陆地挡住时停船，等向导舰绕过后再跟上
+	if mapCfg.Map.IsLand(nextPos.MX, nextPos.MY) && !s.CanOnLand() {
+		s.CurSpeed = 0
+		return
+	}
+	s.CurPos = nextPos
+	s.SailedDistance += math.Abs(s.CurSpeed)
+}
+
+// angleBetween 两个角度之间的夹角（0 - 180）
+func angleBetween(a, b float64) float64 {
+	return math.Abs(math.Mod(a-b+540, 360) - 180)
+}
@@ -0,0 +1,65 @@
+package unit
+
+import (
+	"testing"
+
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	"github.com/narasux/jutland/pkg/resources/mapcfg"
+)
+
+func TestFormationSlotPosFollowsGuideHeading(t *testing.T) {
+	guidePos := objPos.NewR(10, 10)
+	// 航向正北时，身后 2 格在南侧，右舷 3 格在东侧
+	pos := FormationSlot{Forward: -2, Lateral: 3}.Pos(guidePos, 0)
+	requireClose(t, pos.RX, 13)
+	requireClose(t, pos.RY, 12)
+
+	// 航向正东时，身后在西侧，右舷在南侧
+	pos = FormationSlot{Forward: -2, Lateral: 3}.Pos(guidePos, 90)
+	requireClose(t, pos.RX, 8)
+	requireClose(t, pos.RY, 13)
+}
+
+func TestFormationSlots(t *testing.T) {
+	lineAhead := FormationSlots(FormationLineAhead, 3, 2)
+	for idx, slot := range lineAhead {
+		requireClose(t, slot.Forward, -2*float64(idx+1))
+		requireClose(t, slot.Lateral, 0)
+	}
+
+	// 左右两翼交替排列
+	wedge := FormationSlots(FormationWedge, 3, 2)
+	requireClose(t, wedge[0].Lateral, 2)
+	requireClose(t, wedge[1].Lateral, -2)
+	requireClose(t, wedge[2].Lateral, 4)
+	requireClose(t, wedge[2].Forward, -4)
+
+	// 环形警戒阵距离向导舰的距离相同
+	origin := objPos.NewR(0, 0)
+	for _, slot := range FormationSlots(FormationScreen, 4, 2) {
+		requireClose(t, origin.Distance(objPos.NewR(slot.Forward, slot.Lateral)), 4)
+	}
+}
+
+func TestFollowFormationMatchesGuideOnStation(t *testing.T) {
+	useDefaultSettings(t)
+	mapCfg := &mapcfg.MapCfg{Width: 100, Height: 100}
+	ship := &BattleShip{
+		CurHP: 100, MaxSpeed: 1, Acceleration: 0.1, RotateSpeed: 5,
+		CurPos: objPos.NewR(10, 10), CurRotation: 80, CurSpeed: 0.3,
+	}
+
+	// 已经在编队位置上：与向导舰同向同速
+	ship.FollowFormation(mapCfg, objPos.NewR(10, 10), 90, 0.3)
+	requireClose(t, ship.CurRotation, 85)
+	requireClose(t, ship.CurSpeed, 0.3)
+
+	// 落后于编队位置：转向编队位置并加速追赶
+	ship = &BattleShip{
+		CurHP: 100, MaxSpeed: 1, Acceleration: 0.1, RotateSpeed: 5,
+		CurPos: objPos.NewR(10, 10), CurRotation: 90, CurSpeed: 0.3,
+	}
+	ship.FollowFormation(mapCfg, objPos.NewR(20, 10), 90, 0.3)
+	requireClose(t, ship.CurSpeed, 0.4)
+	requireClose(t, ship.CurPos.RX, 10.4)
+}
@@ -0,0 +1,57 @@
+package unit
+
+// 燃油：启用燃油的关卡中，战舰按航行距离（每帧的当前航速）消耗燃油，燃油不足时限制航速，
+// 在己方增援点或友军货轮附近与弹药一起补给；燃油容量为 0 或关卡未启用燃油时不受影响
+const (
+	// 燃油比例低于该值时限制航速
+	lowFuelRate = 0.2
+	// 燃油不足 / 耗尽时的最大航速比例
+	lowFuelSpeedRate   = 0.5
+	emptyFuelSpeedRate = 0.1
+)
+
+// FuelRate 剩余燃油比例（不限燃油时为 1）
+func (s *BattleShip) FuelRate() float64 {
+	if s.FuelCapacity <= 0 {
+		return 1
+	}
+	return min(1, max(0, s.Fuel/s.FuelCapacity))
+}
+
+// LowFuel 燃油是否不足（航速受限）
+func (s *BattleShip) LowFuel() bool {
+	return s.FuelRate() < lowFuelRate
+}
+
+// OutOfFuel 燃油是否耗尽
+func (s *BattleShip) OutOfFuel() bool {
+	return s.FuelCapacity > 0 && s.Fuel <= 0
+}
+
+// fuelSpeedRate 燃油情况对应的最大航速比例
+func (s *BattleShip) fuelSpeedRate() float64 {
+	if s.OutOfFuel() {
+		return emptyFuelSpeedRate
+	}
+	if s.LowFuel() {
+		return lowFuelSpeedRate
+	}
+	return 1
+}
+
+// SettleFuel 结算上次结算后航行消耗的燃油，enabled 为 false（关卡未启用燃油）时只清空航行距离
+func (s *BattleShip) SettleFuel(enabled bool) {
+	if enabled && s.FuelCapacity > 0 {
+		s.Fuel = max(0, s.Fuel-s.SailedDistance)
+	}
+	s.SailedDistance = 0
+}
+
+// refuel 按燃油容量比例补给燃油，返回是否补给了燃油
+func (s *BattleShip) refuel(rate float64) bool {
+	if s.FuelCapacity <= 0 || s.Fuel >= s.FuelCapacity {
+		return false
+	}
+	s.Fuel = min(s.FuelCapacity, s.Fuel+s.FuelCapacity*rate)
+	return true
+}
@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"testing"
+)
+
+func TestShipSettleFuel(t *testing.T) {
+	ship := newDamageTestShip()
+	ship.FuelCapacity, ship.Fuel = 100, 100
+
+	// 关卡未启用燃油时只清空航行距离
+	ship.SailedDistance = 30
+	ship.SettleFuel(false)
+	requireClose(t, ship.Fuel, 100)
+	requireClose(t, ship.SailedDistance, 0)
+
+	// 启用燃油时按航行距离消耗，不低于 0
+	ship.SailedDistance = 30
+	ship.SettleFuel(true)
+	requireClose(t, ship.Fuel, 70)
+	ship.SailedDistance = 100
+	ship.SettleFuel(true)
+	requireClose(t, ship.Fuel, 0)
+	if !ship.OutOfFuel() {
+		t.Fatalf("ship should be out of fuel")
+	}
+
+	// 不限燃油的战舰不消耗燃油
+	unlimited := newDamageTestShip()
+	unlimited.SailedDistance = 30
+	unlimited.SettleFuel(true)
+	if unlimited.OutOfFuel() || unlimited.LowFuel() {
+		t.Fatalf("ship without fuel capacity should never run out of fuel")
+	}
+	requireClose(t, unlimited.FuelRate(), 1)
+}
+
+func TestShipFuelSpeedCap(t *testing.T) {
+	ship := newDamageTestShip()
+	ship.FuelCapacity, ship.Fuel = 100, 50
+	requireClose(t, ship.EffectiveMaxSpeed(), 0.3)
+
+	// 燃油不足时限制航速，耗尽后只能低速航行
+	ship.Fuel = 10
+	requireClose(t, ship.EffectiveMaxSpeed(), 0.3*lowFuelSpeedRate)
+	ship.Fuel = 0
+	requireClose(t, ship.EffectiveMaxSpeed(), 0.3*emptyFuelSpeedRate)
+}
+
+func TestShipRefuel(t *testing.T) {
+	ship := newDamageTestShip()
+	ship.Weapon = ShipWeapon{}
+	ship.FuelCapacity, ship.Fuel = 100, 0
+
+	// 与弹药一起补给燃油，增援点附近补给更快
+	if !ship.Resupply(ResupplyInterval, false) {
+		t.Fatalf("ship should be refueled")
+	}
+	requireClose(t, ship.Fuel, 10)
+	if !ship.Resupply(ResupplyInterval*2, true) {
+		t.Fatalf("ship should be refueled at reinforce point")
+	}
+	requireClose(t, ship.Fuel, 30)
+
+	// 满燃油时不再补给
+	ship.Fuel = 100
+	if ship.Resupply(ResupplyInterval*3, true) {
+		t.Fatalf("fully fueled ship should not be refueled")
+	}
+}
@@ -3,12 +3,12 @@ package unit
 import (
 	"log"
 	"math"
-	"math/rand"
-	"time"
+	"math/rand/v2"
 
 	"github.com/mohae/deepcopy"
 	"github.com/narasux/jutland/pkg/common/constants"
 	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/clock"
 	"github.com/narasux/jutland/pkg/mission/object"
 	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
@@ -25,6 +25,8 @@ type Gun struct {
 	BulletCount int `json:"bulletCount"`
 	// 装填时间（单位: s）
 	ReloadTime float64 `json:"reloadTime"`
+	// 弹药容量（齐射次数，0 表示不限弹药）
+	AmmoCapacity int `json:"ammoCapacity"`
 	// 射程
 	Range float64 `json:"range"`
 	// 造价
@@ -46,10 +48,14 @@ type Gun struct {
 	// 右射界 (0, 180]
 	RightFiringArc FiringArc
 
-	// 当前火炮是否可用（如战损 / 禁用）
+	// 当前火炮是否被禁用（玩家关闭武器）
 	Disable bool
-	// 装填开始时间（毫秒时间戳)
+	// 战损修复完成的任务时间（毫秒，0 表示完好），战损期间不可发射
+	RepairAt int64
+	// 装填开始时间（任务时间，毫秒）
 	ReloadStartAt int64
+	// 剩余弹药（齐射次数）
+	Ammo int
 }
 
 var _ AttackWeapon = (*Gun)(nil)
@@ -65,10 +71,14 @@ func (g *Gun) IsAvailableAntiType(objType object.Type) bool {
 	return false
 }
 
+// Damaged 是否战损中
+func (g *Gun) Damaged() bool {
+	return g.RepairAt != 0
+}
+
 // Reloaded 是否已装填完成
-func (g *Gun) Reloaded() bool {
-	elapsed := time.Now().UnixMilli() - g.ReloadStartAt
-	return float64(elapsed)*config.G.SpeedMultiplier >= g.ReloadTime*1e3
+func (g *Gun) Reloaded(now int64) bool {
+	return float64(clock.Since(now, g.ReloadStartAt)) >= g.ReloadTime*1e3
 }
 
 // InShotRange 是否在射程 / 射界内
@@ -86,9 +96,9 @@ func (g *Gun) InShotRange(shipCurRotation float64, curPos, targetPos objPos.MapP
 }
 
 // Fire 发射
-func (g *Gun) Fire(shooter Attacker, enemy Hurtable) (bullets []*objBullet.Bullet) {
+func (g *Gun) Fire(shooter Attacker, enemy Hurtable, now int64, rng *rand.Rand) (bullets []*objBullet.Bullet) {
 	// 未启用 / 重新装填中 / 对象类型不匹配，不可发射
-	if g.Disable || !g.Reloaded() || !g.IsAvailableAntiType(enemy.ObjType()) {
+	if g.Disable || g.Damaged() || g.OutOfAmmo() || !g.Reloaded(now) || !g.IsAvailableAntiType(enemy.ObjType()) {
 		return
 	}
 
@@ -113,7 +123,8 @@ func (g *Gun) Fire(shooter Attacker, enemy Hurtable) (bullets []*objBullet.Bulle
 	if !g.InShotRange(sState.CurRotation, curPos, targetPos) {
 		return
 	}
-	g.ReloadStartAt = time.Now().UnixMilli()
+	g.ReloadStartAt = now
+	g.consumeAmmo()
 
 	distance := curPos.Distance(targetPos)
 	// 火炮炮弹生命值与目标距离相关，15 对于 0.4 速度的炮弹来说，相当于 6 格地图，在大多数火炮散布范围之内
@@ -137,9 +148,9 @@ func (g *Gun) Fire(shooter Attacker, enemy Hurtable) (bullets []*objBullet.Bulle
 
 	for i := 0; i < g.BulletCount; i++ {
 		pos := targetPos.Copy()
-		// rand.Intn(3) - 1 算方向，rand.Float64() 算距离
-		pos.AddRx(float64(rand.Intn(3)-1) * rand.Float64() * radius)
-		pos.AddRy(float64(rand.Intn(3)-1) * rand.Float64() * radius)
+		// rng.IntN(3) - 1 算方向，rng.Float64() 算距离
+		pos.AddRx(float64(rng.IntN(3)-1) * rng.Float64() * radius)
+		pos.AddRy(float64(rng.IntN(3)-1) * rng.Float64() * radius)
 		bullets = append(bullets, objBullet.New(
 			g.BulletName, curPos, pos,
 			shooter.ID(), shooter.ObjType(), shooter.Player(),
@@ -163,5 +174,6 @@ func NewGun(name string, posPercent float64, leftFireArc, rightFireArc FiringArc
 	g.PosPercent = posPercent
 	g.LeftFiringArc = leftFireArc
 	g.RightFiringArc = rightFireArc
+	g.Ammo = g.AmmoCapacity
 	return &g
 }
@@ -0,0 +1,144 @@
+package unit
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
+)
+
+// 持续伤害：炮弹 / 炸弹 / 火箭弹命中可能引发火灾，鱼雷命中必定造成进水，
+// 每处火灾 / 进水在持续时间内按总生命值比例持续掉血，可以叠加（有上限），损管可以一次性扑灭 / 堵漏
+const (
+	// 单次命中引发火灾的概率为实际伤害占总生命值比例的倍数（不超过上限），暴击必定引发火灾
+	fireStartRate    = 5.0
+	fireStartMaxRate = 0.3
+	// 火灾 / 进水的最大叠加数量
+	maxFires  = 4
+	maxFloods = 3
+	// 每处火灾 / 进水的持续时间（任务时间，毫秒）
+	fireDuration  = 30000
+	floodDuration = 45000
+	// 每处火灾 / 进水每秒造成的伤害（总生命值比例）
+	fireDamageRate  = 0.0015
+	floodDamageRate = 0.002
+	// 每处进水降低的航速比例
+	floodSpeedPenalty = 0.1
+	// 暴击的鱼雷额外造成的进水数量
+	criticalTorpedoExtraFloods = 2
+	// 损管冷却时间（任务时间，毫秒）
+	damageControlCooldown = 60000
+	// 医疗船范围内，火灾 / 进水 & 损管冷却的恢复倍率
+	hospitalHazardRecoveryRate = 2
+)
+
+// ShipHazards 战舰火灾 / 进水情况
+type ShipHazards struct {
+	// 各处火灾 / 进水的剩余持续时间（毫秒）
+	Fires  []int64
+	Floods []int64
+	// 损管冷却结束的任务时间（毫秒，0 表示可用）
+	DamageControlReadyAt int64
+	// 上次结算持续伤害的任务时间（毫秒）
+	UpdatedAt int64
+}
+
+// addHazard 增加一处火灾 / 进水，达到上限时刷新剩余时间最短的一处
+func addHazard(hazards []int64, limit int, duration int64) []int64 {
+	if len(hazards) < limit {
+		return append(hazards, duration)
+	}
+	hazards[slices.Index(hazards, slices.Min(hazards))] = duration
+	return hazards
+}
+
+// decayHazards 推进火灾 / 进水的剩余时间，移除已经结束的
+func decayHazards(hazards []int64, elapsed int64) []int64 {
+	remains := hazards[:0]
+	for _, h := range hazards {
+		if h -= elapsed; h > 0 {
+			remains = append(remains, h)
+		}
+	}
+	return remains
+}
+
+// startHazards 按弹药类型引发火灾 / 进水
+// 注：鱼雷必定进水，不消耗随机数；炮弹 / 炸弹 / 火箭弹消耗一个随机数判定是否起火
+func (s *BattleShip) startHazards(
+	bullet *objBullet.Bullet, realDamage float64, criticalType objBullet.CriticalType, rng *rand.Rand,
+) {
+	if s.CurHP <= 0 || s.TotalHP <= 0 {
+		return
+	}
+	switch bullet.Type {
+	case objBullet.TypeTorpedo:
+		floods := 1
+		if criticalType != objBullet.CriticalTypeNone {
+			floods += criticalTorpedoExtraFloods
+		}
+		for range floods {
+			s.Hazards.Floods = addHazard(s.Hazards.Floods, maxFloods, floodDuration)
+		}
+	case objBullet.TypeShell, objBullet.TypeBomb, objBullet.TypeRocket:
+		rate := min(fireStartMaxRate, realDamage/s.TotalHP*fireStartRate)
+		if rng.Float64() < rate || criticalType != objBullet.CriticalTypeNone {
+			s.Hazards.Fires = addHazard(s.Hazards.Fires, maxFires, fireDuration)
+		}
+	}
+}
+
+// UpdateHazards 结算火灾 / 进水的持续伤害，assisted 表示在医疗船的范围内（加速扑灭 / 堵漏 & 损管冷却）
+func (s *BattleShip) UpdateHazards(now int64, assisted bool) {
+	elapsed := max(0, now-s.Hazards.UpdatedAt)
+	if s.Hazards.UpdatedAt == 0 {
+		elapsed = 0
+	}
+	s.Hazards.UpdatedAt = now
+	if elapsed == 0 || s.CurHP <= 0 {
+		return
+	}
+
+	damage := (float64(len(s.Hazards.Fires))*fireDamageRate + float64(len(s.Hazards.Floods))*floodDamageRate) *
+		s.TotalHP * float64(elapsed) / 1e3
+	s.CurHP = max(0, s.CurHP-damage)
+
+	recovery := elapsed
+	if assisted {
+		recovery *= hospitalHazardRecoveryRate
+		// 冷却结束时间提前（额外恢复的部分）
+		if s.Hazards.DamageControlReadyAt != 0 {
+			s.Hazards.DamageControlReadyAt -= recovery - elapsed
+		}
+	}
+	s.Hazards.Fires = decayHazards(s.Hazards.Fires, recovery)
+	s.Hazards.Floods = decayHazards(s.Hazards.Floods, recovery)
+	if s.Hazards.DamageControlReadyAt != 0 && now >= s.Hazards.DamageControlReadyAt {
+		s.Hazards.DamageControlReadyAt = 0
+	}
+}
+
+// OnFire 火灾数量
+func (s *BattleShip) OnFire() int {
+	return len(s.Hazards.Fires)
+}
+
+// Flooding 进水数量
+func (s *BattleShip) Flooding() int {
+	return len(s.Hazards.Floods)
+}
+
+// CanDamageControl 是否可以执行损管（冷却完毕 & 存在火灾或进水）
+func (s *BattleShip) CanDamageControl() bool {
+	return s.Hazards.DamageControlReadyAt == 0 && (s.OnFire() > 0 || s.Flooding() > 0)
+}
+
+// DamageControl 执行损管：扑灭全部火灾 & 堵住全部进水，进入冷却
+func (s *BattleShip) DamageControl(now int64) bool {
+	if !s.CanDamageControl() {
+		return false
+	}
+	s.Hazards.Fires, s.Hazards.Floods = nil, nil
+	s.Hazards.DamageControlReadyAt = now + damageControlCooldown
+	return true
+}
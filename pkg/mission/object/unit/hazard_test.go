@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
+)
+
+func TestStartHazardsByBulletType(t *testing.T) {
+	ship := newDamageTestShip()
+	never, always := rand.New(fixedSource(1<<53-1)), rand.New(fixedSource(0))
+
+	// 炮弹按伤害比例判定起火，暴击必定起火
+	shell := &objBullet.Bullet{Type: objBullet.TypeShell}
+	ship.startHazards(shell, 10, objBullet.CriticalTypeNone, never)
+	if ship.OnFire() != 0 {
+		t.Fatalf("shell hit should not start a fire, got %d", ship.OnFire())
+	}
+	ship.startHazards(shell, 10, objBullet.CriticalTypeThreeTimes, never)
+	ship.startHazards(shell, 10, objBullet.CriticalTypeNone, always)
+	if ship.OnFire() != 2 || ship.Flooding() != 0 {
+		t.Fatalf("expected 2 fires and no flooding, got %d / %d", ship.OnFire(), ship.Flooding())
+	}
+
+	// 鱼雷必定进水，暴击额外进水，不超过上限
+	torpedo := &objBullet.Bullet{Type: objBullet.TypeTorpedo}
+	ship.startHazards(torpedo, 10, objBullet.CriticalTypeNone, never)
+	if ship.Flooding() != 1 || ship.OnFire() != 2 {
+		t.Fatalf("torpedo hit should only flood, got %d / %d", ship.OnFire(), ship.Flooding())
+	}
+	ship.startHazards(torpedo, 10, objBullet.CriticalTypeTenTimes, never)
+	if ship.Flooding() != maxFloods {
+		t.Fatalf("flooding should stack up to %d, got %d", maxFloods, ship.Flooding())
+	}
+	requireClose(t, ship.EffectiveMaxSpeed(), 0.3*(1-maxFloods*floodSpeedPenalty))
+}
+
+func TestAddHazardRefreshesShortestWhenFull(t *testing.T) {
+	hazards := []int64{100, 50, 200}
+	hazards = addHazard(hazards, 3, 1000)
+	if len(hazards) != 3 || hazards[1] != 1000 {
+		t.Fatalf("shortest hazard should be refreshed, got %v", hazards)
+	}
+}
+
+func TestUpdateHazardsDamageAndRecovery(t *testing.T) {
+	ship := newDamageTestShip()
+	ship.Hazards.Fires = []int64{fireDuration, 500}
+	ship.Hazards.Floods = []int64{floodDuration}
+
+	// 首次结算只记录时间
+	ship.UpdateHazards(1000, false)
+	requireClose(t, ship.CurHP, 1000)
+
+	ship.UpdateHazards(2000, false)
+	requireClose(t, ship.CurHP, 1000-(2*fireDamageRate+floodDamageRate)*1000)
+	if ship.OnFire() != 1 || ship.Hazards.Fires[0] != fireDuration-1000 {
+		t.Fatalf("expired fire should be removed, got %v", ship.Hazards.Fires)
+	}
+
+	// 医疗船范围内加速恢复
+	ship.UpdateHazards(3000, true)
+	if ship.Hazards.Fires[0] != fireDuration-3000 || ship.Hazards.Floods[0] != floodDuration-3000 {
+		t.Fatalf("hospital should speed up recovery, got %v / %v", ship.Hazards.Fires, ship.Hazards.Floods)
+	}
+}
+
+func TestDamageControlCooldown(t *testing.T) {
+	ship := newDamageTestShip()
+	if ship.DamageControl(1000) {
+		t.Fatalf("damage control without hazards should be ignored")
+	}
+
+	ship.Hazards.Fires = []int64{fireDuration}
+	ship.Hazards.Floods = []int64{floodDuration}
+	if !ship.DamageControl(1000) || ship.OnFire() != 0 || ship.Flooding() != 0 {
+		t.Fatalf("damage control should clear all hazards")
+	}
+
+	ship.Hazards.Fires = []int64{fireDuration}
+	if ship.DamageControl(2000) {
+		t.Fatalf("damage control should be on cooldown")
+	}
+	ship.UpdateHazards(2000, false)
+	ship.UpdateHazards(1000+damageControlCooldown, false)
+	if ship.Hazards.DamageControlReadyAt != 0 {
+		t.Fatalf("damage control should be ready after cooldown")
+	}
+}
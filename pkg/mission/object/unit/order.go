@@ -0,0 +1,72 @@
+package unit
+
+import (
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+// OrderType 排队命令类型
+type OrderType string
+
+const (
+	// OrderTypeMove 前往航点
+	OrderTypeMove OrderType = "move"
+	// OrderTypeAttack 攻击指定敌舰（跟随目标直到其被击沉或离开视野）
+	OrderTypeAttack OrderType = "attack"
+	// OrderTypePatrol 巡逻航点（到达后重新排到队尾，循环航行）
+	OrderTypePatrol OrderType = "patrol"
+	// OrderTypeAttackMove 攻击移动：前往航点，途中迎击遭遇的敌舰，敌舰被击沉或离开后继续前进
+	OrderTypeAttackMove OrderType = "attackMove"
+)
+
+// ShipOrder 战舰排队命令（Shift + 右键追加的航点）
+type ShipOrder struct {
+	Type OrderType
+	// 航点位置（攻击命令为下达时目标所在位置）
+	Pos objPos.MapPos
+	// 攻击目标（敌舰 Uid；攻击移动为途中正在迎击的敌舰）
+	TargetUid string
+	// 是否已开始执行（已下发移动 / 攻击指令）
+	Started bool
+}
+
+// EnqueueOrder 追加排队命令
+func (s *BattleShip) EnqueueOrder(order ShipOrder) {
+	order.Started = false
+	s.Orders = append(s.Orders, order)
+}
+
+// AttackMove 攻击移动前往指定位置（取代现有的排队命令与值守任务）
+func (s *BattleShip) AttackMove(pos objPos.MapPos) {
+	s.ClearDuty()
+	s.ClearOrders()
+	// 编队跟随舰不执行排队命令，需要先脱离编队
+	if s.InFormation() && !s.IsFormationGuide() {
+		s.LeaveFormation()
+	}
+	s.EnqueueOrder(ShipOrder{Type: OrderTypeAttackMove, Pos: pos})
+}
+
+// CancelLastOrder 撤销最后一条排队命令
+func (s *BattleShip) CancelLastOrder() {
+	if len(s.Orders) == 0 {
+		return
+	}
+	s.Orders = s.Orders[:len(s.Orders)-1]
+}
+
+// ClearOrders 清空排队命令
+func (s *BattleShip) ClearOrders() {
+	s.Orders = nil
+}
+
+// FinishOrder 当前（队首）命令执行完成，巡逻航点重新排到队尾
+func (s *BattleShip) FinishOrder() {
+	if len(s.Orders) == 0 {
+		return
+	}
+	order := s.Orders[0]
+	s.Orders = s.Orders[1:]
+	if order.Type == OrderTypePatrol {
+		s.EnqueueOrder(order)
+	}
+}
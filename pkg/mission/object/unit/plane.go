@@ -3,13 +3,12 @@ package unit
 import (
 	"fmt"
 	"log"
-	"math/rand"
-	"time"
+	"math/rand/v2"
 
-	"github.com/google/uuid"
 	"github.com/mohae/deepcopy"
 
 	"github.com/narasux/jutland/pkg/i18n"
+	"github.com/narasux/jutland/pkg/mission/clock"
 	"github.com/narasux/jutland/pkg/mission/faction"
 	"github.com/narasux/jutland/pkg/mission/object"
 	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
@@ -79,6 +78,8 @@ type Plane struct {
 	TimeCost int64 `json:"timeCost"`
 	// 吨位
 	Tonnage float64 `json:"tonnage"`
+	// 探测范围（地图格，未配置时按机种默认值）
+	DetectionRange float64 `json:"detectionRange"`
 	// 武器
 	Weapon PlaneWeapon `json:"weapon"`
 	// 战力评估（配置与武器初始化完成后计算）
@@ -100,6 +101,10 @@ type Plane struct {
 	RemainRange float64
 	// 当前攻击目标 (uid)
 	CurAttackTarget string
+	// 指定空袭的打击目标 (uid)，一击脱离后继续攻击该目标
+	StrikeTarget string
+	// 是否已被母舰召回
+	Recalled bool
 	// 飞行阶段（起飞 / 巡航 / 降落）
 	FlightPhase PlaneFlightPhase
 	// 当前飞行阶段起点
@@ -185,29 +190,28 @@ func (p *Plane) GeometricSize() UnitGeometricSize {
 }
 
 // Fire 向指定目标发射武器
-func (p *Plane) Fire(enemy Hurtable) (shotBullets []*objBullet.Bullet) {
+func (p *Plane) Fire(enemy Hurtable, now int64, rng *rand.Rand) (shotBullets []*objBullet.Bullet) {
 	// 如果生命值为 0，那还 Fire 个锤子，直接返回
 	if p.CurHP <= 0 {
 		return
 	}
 	// 机炮不用记录射击时间
 	for i := 0; i < len(p.Weapon.Guns); i++ {
-		shotBullets = append(shotBullets, p.Weapon.Guns[i].Fire(p, enemy)...)
+		shotBullets = append(shotBullets, p.Weapon.Guns[i].Fire(p, enemy, now, rng)...)
 	}
 	for i := 0; i < len(p.Weapon.Rockets); i++ {
-		shotBullets = append(shotBullets, p.Weapon.Rockets[i].Fire(p, enemy)...)
+		shotBullets = append(shotBullets, p.Weapon.Rockets[i].Fire(p, enemy, now, rng)...)
 	}
 	// 释放器类武器，有最小的释放间隔限制，且目前只能攻击战舰。
 	if enemy.ObjType() == object.TypeShip {
-		timeNow := time.Now().UnixMilli()
-		if timeNow > p.Weapon.LatestReleaseAt+p.Weapon.ReleaseInterval*1e3 {
+		if clock.Since(now, p.Weapon.LatestReleaseAt) > p.Weapon.ReleaseInterval*1e3 {
 			for _, releasers := range [2][]*Releaser{
 				p.Weapon.Bombs, p.Weapon.Torpedoes,
 			} {
 				for i := 0; i < len(releasers); i++ {
-					if bullets := releasers[i].Fire(p, enemy); len(bullets) > 0 {
+					if bullets := releasers[i].Fire(p, enemy, now, rng); len(bullets) > 0 {
 						shotBullets = append(shotBullets, bullets...)
-						p.Weapon.LatestReleaseAt = timeNow
+						p.Weapon.LatestReleaseAt = now
 						break
 					}
 				}
@@ -231,13 +235,13 @@ func (p *Plane) TorpedoPathCrossesLand(enemy Hurtable, terrain *mapcfg.MapData)
 }
 
 // HurtBy 受到伤害
-func (p *Plane) HurtBy(bullet *objBullet.Bullet) {
+func (p *Plane) HurtBy(bullet *objBullet.Bullet, _ int64, rng *rand.Rand) {
 	// 计算真实伤害，飞机比较脆，所以伤害要再额外乘以 3
 	realDamage := bullet.Damage * (1 - p.DamageReduction) * 3
 
 	// 暴击伤害的机制，一发大口径可能直接起飞，支持多段暴击
 	criticalType := objBullet.CriticalTypeNone
-	randVal := rand.Float64()
+	randVal := rng.Float64()
 	if randVal < bullet.CriticalRate/10 {
 		realDamage *= 10
 		criticalType = objBullet.CriticalTypeTenTimes
@@ -264,10 +268,18 @@ func (p *Plane) MoveTo(mapCfg *mapcfg.MapCfg, targetPos, enemyPos objPos.MapPos,
 	p.movementStrategy.MoveTo(p, mapCfg, targetPos, enemyPos, targetSpeed)
 }
 
+// Cruise 以最大速度飞向指定位置（巡逻等非交战飞行）
+func (p *Plane) Cruise(mapCfg *mapcfg.MapCfg, targetPos objPos.MapPos) {
+	if p.CurHP <= 0 {
+		return
+	}
+	executePlaneMovement(p, mapCfg, targetPos, p.MaxSpeed*gameSpeedMultiplier())
+}
+
 // MustReturn 必须返航
 func (p *Plane) MustReturn() bool {
-	// 如果剩余航程 <= 0，必须返航
-	if p.RemainRange <= 0 {
+	// 如果剩余航程 <= 0，或者已被母舰召回，必须返航
+	if p.RemainRange <= 0 || p.Recalled {
 		return true
 	}
 	// 轰炸机 / 鱼雷机，只要没有进攻武器了，就返航（我滴任务完成啦！）
@@ -299,9 +311,16 @@ var PlaneMap = map[string]*Plane{}
 // AllPlaneNames 保存可用飞机模板名称，顺序由配置初始化过程确定。
 var AllPlaneNames = []string{}
 
+// PlaneUid 舰载机 Uid：按母舰 Uid 和母舰的累计起飞架次生成（而非随机 uuid），
+// 战机按 Uid 排序后消耗任务随机数，保证同一种子下战斗可复现、联机对战双方一致
+func PlaneUid(shipUid string, takeOffCount int) string {
+	return fmt.Sprintf("%s/plane-%d", shipUid, takeOffCount)
+}
+
 // NewPlane 生成飞机
 func NewPlane(
 	name string,
+	uid string,
 	curPos objPos.MapPos,
 	rotation float64,
 	shipUid string,
@@ -313,7 +332,7 @@ func NewPlane(
 	}
 	p := deepcopy.Copy(*plane).(Plane)
 
-	p.Uid = uuid.New().String()
+	p.Uid = uid
 	p.CurSpeed = p.MaxSpeed
 	p.CurPos = curPos
 	p.CurRotation = rotation
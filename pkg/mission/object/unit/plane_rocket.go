@@ -3,13 +3,13 @@ package unit
 import (
 	"log"
 	"math"
-	"math/rand"
-	"time"
+	"math/rand/v2"
 
 	"github.com/mohae/deepcopy"
 
 	"github.com/narasux/jutland/pkg/common/constants"
 	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/clock"
 	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
 	"github.com/narasux/jutland/pkg/utils/geometry"
@@ -48,7 +48,7 @@ type PlaneRocketLauncher struct {
 
 	// 已发射数量，飞机火箭弹不在空中重装
 	ShotCount int
-	// 最近发射时间（毫秒时间戳）
+	// 最近发射时间（任务时间，毫秒）
 	LatestFireAt int64
 }
 
@@ -60,11 +60,11 @@ func (r *PlaneRocketLauncher) Exhausted() bool {
 }
 
 // Reloaded 是否满足下一枚火箭弹发射间隔；飞机火箭不重装，仅检查挂载余量。
-func (r *PlaneRocketLauncher) Reloaded() bool {
+func (r *PlaneRocketLauncher) Reloaded(now int64) bool {
 	if r.Exhausted() {
 		return false
 	}
-	return float64(time.Now().UnixMilli()-r.LatestFireAt)*config.G.SpeedMultiplier >= r.ShotInterval*1e3
+	return float64(clock.Since(now, r.LatestFireAt)) >= r.ShotInterval*1e3
 }
 
 // InShotRange 是否在射程 / 射界内。
@@ -77,8 +77,8 @@ func (r *PlaneRocketLauncher) InShotRange(planeCurRotation float64, curPos, targ
 }
 
 // Fire 发射下一枚飞机火箭弹；目标类型由飞机当前目标规则决定。
-func (r *PlaneRocketLauncher) Fire(shooter Attacker, enemy Hurtable) (bullets []*objBullet.Bullet) {
-	if !r.Reloaded() {
+func (r *PlaneRocketLauncher) Fire(shooter Attacker, enemy Hurtable, now int64, rng *rand.Rand) (bullets []*objBullet.Bullet) {
+	if !r.Reloaded(now) {
 		return nil
 	}
 
@@ -104,8 +104,8 @@ func (r *PlaneRocketLauncher) Fire(shooter Attacker, enemy Hurtable) (bullets []
 	radius := float64(r.BulletSpread) / constants.MapBlockSize * rangePercent
 
 	pos := targetPos.Copy()
-	pos.AddRx(float64(rand.Intn(3)-1) * rand.Float64() * radius)
-	pos.AddRy(float64(rand.Intn(3)-1) * rand.Float64() * radius)
+	pos.AddRx(float64(rng.IntN(3)-1) * rng.Float64() * radius)
+	pos.AddRy(float64(rng.IntN(3)-1) * rng.Float64() * radius)
 	bt := objBullet.New(
 		r.BulletName, curPos, pos,
 		shooter.ID(), shooter.ObjType(), shooter.Player(),
@@ -116,7 +116,7 @@ func (r *PlaneRocketLauncher) Fire(shooter Attacker, enemy Hurtable) (bullets []
 	bullets = append(bullets, bt)
 
 	r.ShotCount++
-	r.LatestFireAt = time.Now().UnixMilli()
+	r.LatestFireAt = now
 	return bullets
 }
 
@@ -3,6 +3,7 @@ package unit
 import (
 	"log"
 	"math"
+	"math/rand/v2"
 
 	"github.com/mohae/deepcopy"
 	"github.com/samber/lo"
@@ -106,7 +107,7 @@ func (r *Releaser) pathCrossesLand(
 }
 
 // Fire 发射
-func (r *Releaser) Fire(shooter Attacker, enemy Hurtable) (bullets []*objBullet.Bullet) {
+func (r *Releaser) Fire(shooter Attacker, enemy Hurtable, _ int64, _ *rand.Rand) (bullets []*objBullet.Bullet) {
 	sState, targetPos, bulletSpeed, ok := r.shotParameters(shooter, enemy)
 	if !ok {
 		return
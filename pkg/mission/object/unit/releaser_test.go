@@ -51,7 +51,7 @@ func TestAerialTorpedoCanAttackShorelineTargetWithoutRunningFarPastIt(t *testing
 	if torpedo.pathCrossesLand(plane, target, &terrain) {
 		t.Fatal("land behind target incorrectly blocked torpedo release")
 	}
-	bullets := torpedo.Fire(plane, target)
+	bullets := torpedo.Fire(plane, target, 1, nil)
 	if len(bullets) != 1 {
 		t.Fatalf("released torpedoes = %d, want 1", len(bullets))
 	}
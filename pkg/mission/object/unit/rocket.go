@@ -3,13 +3,13 @@ package unit
 import (
 	"log"
 	"math"
-	"math/rand"
-	"time"
+	"math/rand/v2"
 
 	"github.com/mohae/deepcopy"
 
 	"github.com/narasux/jutland/pkg/common/constants"
 	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/clock"
 	"github.com/narasux/jutland/pkg/mission/object"
 	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
@@ -32,6 +32,8 @@ type RocketLauncher struct {
 	GroupInterval float64 `json:"groupInterval"`
 	// 装填时间（单位: s）
 	ReloadTime float64 `json:"reloadTime"`
+	// 弹药容量（火箭弹枚数，含已装填的，0 表示不限弹药）
+	AmmoCapacity int `json:"ammoCapacity"`
 	// 射程
 	Range float64 `json:"range"`
 	// 火箭弹散布
@@ -55,14 +57,18 @@ type RocketLauncher struct {
 	// 右射界 (0, 180]
 	RightFiringArc FiringArc
 
-	// 当前火箭炮是否可用（如战损 / 禁用）
+	// 当前火箭炮是否被禁用（玩家关闭武器）
 	Disable bool
-	// 装填开始时间（毫秒时间戳）
+	// 战损修复完成的任务时间（毫秒，0 表示完好），战损期间不可发射
+	RepairAt int64
+	// 装填开始时间（任务时间，毫秒）
 	ReloadStartAt int64
-	// 最近发射时间（毫秒时间戳）
+	// 最近发射时间（任务时间，毫秒）
 	LatestFireAt int64
 	// 本次装填已发射数量
 	ShotCountBeforeReload int
+	// 剩余弹药（火箭弹枚数）
+	Ammo int
 }
 
 var _ AttackWeapon = (*RocketLauncher)(nil)
@@ -78,11 +84,14 @@ func (r *RocketLauncher) IsAvailableAntiType(objType object.Type) bool {
 	return false
 }
 
+// Damaged 是否战损中
+func (r *RocketLauncher) Damaged() bool {
+	return r.RepairAt != 0
+}
+
 // Reloaded 是否已装填并满足下一枚火箭弹的发射间隔
-func (r *RocketLauncher) Reloaded() bool {
-	timeNow := time.Now().UnixMilli()
-	speedMult := config.G.SpeedMultiplier
-	if float64(timeNow-r.ReloadStartAt)*speedMult < r.ReloadTime*1e3 {
+func (r *RocketLauncher) Reloaded(now int64) bool {
+	if float64(clock.Since(now, r.ReloadStartAt)) < r.ReloadTime*1e3 {
 		return false
 	}
 	if r.ShotCountBeforeReload <= 0 {
@@ -94,7 +103,7 @@ func (r *RocketLauncher) Reloaded() bool {
 	if r.ShotCountBeforeReload%groupSize == 0 {
 		interval = r.GroupInterval
 	}
-	if float64(timeNow-r.LatestFireAt)*speedMult < interval*1e3 {
+	if float64(clock.Since(now, r.LatestFireAt)) < interval*1e3 {
 		return false
 	}
 	return r.ShotCountBeforeReload < r.RocketCount
@@ -115,8 +124,8 @@ func (r *RocketLauncher) InShotRange(shipCurRotation float64, curPos, targetPos
 }
 
 // Fire 发射下一枚火箭弹；每组按单发间隔逐发打完
-func (r *RocketLauncher) Fire(shooter Attacker, enemy Hurtable) (bullets []*objBullet.Bullet) {
-	if r.Disable || !r.Reloaded() || !r.IsAvailableAntiType(enemy.ObjType()) {
+func (r *RocketLauncher) Fire(shooter Attacker, enemy Hurtable, now int64, rng *rand.Rand) (bullets []*objBullet.Bullet) {
+	if r.Disable || r.Damaged() || r.OutOfAmmo() || !r.Reloaded(now) || !r.IsAvailableAntiType(enemy.ObjType()) {
 		return nil
 	}
 
@@ -142,8 +151,8 @@ func (r *RocketLauncher) Fire(shooter Attacker, enemy Hurtable) (bullets []*objB
 	radius := float64(r.BulletSpread) / constants.MapBlockSize * rangePercent
 
 	pos := targetPos.Copy()
-	pos.AddRx(float64(rand.Intn(3)-1) * rand.Float64() * radius)
-	pos.AddRy(float64(rand.Intn(3)-1) * rand.Float64() * radius)
+	pos.AddRx(float64(rng.IntN(3)-1) * rng.Float64() * radius)
+	pos.AddRy(float64(rng.IntN(3)-1) * rng.Float64() * radius)
 	bt := objBullet.New(
 		r.BulletName, curPos, pos,
 		shooter.ID(), shooter.ObjType(), shooter.Player(),
@@ -154,11 +163,11 @@ func (r *RocketLauncher) Fire(shooter Attacker, enemy Hurtable) (bullets []*objB
 	bullets = append(bullets, bt)
 
 	r.ShotCountBeforeReload++
-	timeNow := time.Now().UnixMilli()
-	r.LatestFireAt = timeNow
+	r.consumeAmmo()
+	r.LatestFireAt = now
 	if r.ShotCountBeforeReload >= r.RocketCount {
 		r.ShotCountBeforeReload = 0
-		r.ReloadStartAt = timeNow
+		r.ReloadStartAt = now
 	}
 
 	return bullets
@@ -177,5 +186,6 @@ func NewRocketLauncher(name string, posPercent float64, leftFireArc, rightFireAr
 	r.PosPercent = posPercent
 	r.LeftFiringArc = leftFireArc
 	r.RightFiringArc = rightFireArc
+	r.Ammo = r.AmmoCapacity
 	return &r
 }
@@ -0,0 +1,141 @@
+package unit
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/samber/lo"
+
+	"github.com/narasux/jutland/pkg/i18n"
+)
+
+// ShipSettings 战舰可由玩家切换的设置（武器开关、交战规则、目标策略、保持距离、车钟、舰载机待命）
+// 注：战术暂停时，尚未生效的设置按下达顺序叠加在战舰当前设置之上，用于计算下一次切换 & 绘制预览
+type ShipSettings struct {
+	MainGunDisabled         bool
+	SecondaryGunDisabled    bool
+	AntiAircraftGunDisabled bool
+	TorpedoDisabled         bool
+	RocketDisabled          bool
+
+	FireStance     FireStance
+	TargetPolicies map[WeaponType]TargetPolicy
+	StandOffRate   float64
+	EngineOrder    EngineOrder
+	HoldOnDeck     bool
+}
+
+// 设置展示顺序
+var settingWeaponTypes = []WeaponType{
+	WeaponTypeMainGun,
+	WeaponTypeSecondaryGun,
+	WeaponTypeAntiAircraftGun,
+	WeaponTypeTorpedo,
+	WeaponTypeRocket,
+}
+
+// Settings 战舰的当前设置（目标策略表为副本，修改不影响战舰）
+func (s *BattleShip) Settings() ShipSettings {
+	return ShipSettings{
+		MainGunDisabled:         s.Weapon.MainGunDisabled,
+		SecondaryGunDisabled:    s.Weapon.SecondaryGunDisabled,
+		AntiAircraftGunDisabled: s.Weapon.AntiAircraftGunDisabled,
+		TorpedoDisabled:         s.Weapon.TorpedoDisabled,
+		RocketDisabled:          s.Weapon.RocketDisabled,
+		FireStance:              s.FireStance,
+		TargetPolicies:          maps.Clone(s.TargetPolicies),
+		StandOffRate:            s.StandOffRate,
+		EngineOrder:             s.EngineOrder,
+		HoldOnDeck:              s.Aircraft.HoldOnDeck,
+	}
+}
+
+// WeaponDisabled 某类武器是否被禁用（WeaponTypeAll 表示任意一类武器被禁用）
+func (ss *ShipSettings) WeaponDisabled(t WeaponType) bool {
+	switch t {
+	case WeaponTypeMainGun:
+		return ss.MainGunDisabled
+	case WeaponTypeSecondaryGun:
+		return ss.SecondaryGunDisabled
+	case WeaponTypeAntiAircraftGun:
+		return ss.AntiAircraftGunDisabled
+	case WeaponTypeTorpedo:
+		return ss.TorpedoDisabled
+	case WeaponTypeRocket:
+		return ss.RocketDisabled
+	case WeaponTypeAll:
+		return ss.MainGunDisabled || ss.SecondaryGunDisabled || ss.AntiAircraftGunDisabled ||
+			ss.TorpedoDisabled || ss.RocketDisabled
+	}
+	return false
+}
+
+// SetWeaponDisabled 启用 / 禁用某类武器（WeaponTypeAll 表示全部）
+func (ss *ShipSettings) SetWeaponDisabled(t WeaponType, disabled bool) {
+	if t == WeaponTypeAll || t == WeaponTypeMainGun {
+		ss.MainGunDisabled = disabled
+	}
+	if t == WeaponTypeAll || t == WeaponTypeSecondaryGun {
+		ss.SecondaryGunDisabled = disabled
+	}
+	if t == WeaponTypeAll || t == WeaponTypeAntiAircraftGun {
+		ss.AntiAircraftGunDisabled = disabled
+	}
+	if t == WeaponTypeAll || t == WeaponTypeTorpedo {
+		ss.TorpedoDisabled = disabled
+	}
+	if t == WeaponTypeAll || t == WeaponTypeRocket {
+		ss.RocketDisabled = disabled
+	}
+}
+
+// TargetPolicyOf 获取某类武器的目标策略（未设置时为默认策略）
+func (ss *ShipSettings) TargetPolicyOf(weaponType WeaponType) TargetPolicy {
+	return targetPolicyOf(ss.TargetPolicies, weaponType)
+}
+
+// SetTargetPolicy 设置某类武器的目标策略（WeaponTypeAll + 默认策略表示全部恢复默认）
+func (ss *ShipSettings) SetTargetPolicy(weaponType WeaponType, policy TargetPolicy) {
+	ss.TargetPolicies = setTargetPolicy(ss.TargetPolicies, weaponType, policy)
+}
+
+// ChangesDisplay 相对 current（战舰当前设置）有变化的设置的展示用名称
+func (ss *ShipSettings) ChangesDisplay(current ShipSettings) []string {
+	labels := []string{}
+	for _, t := range settingWeaponTypes {
+		if disabled := ss.WeaponDisabled(t); disabled != current.WeaponDisabled(t) {
+			msg := lo.Ternary(disabled, i18n.MsgPlannedWeaponDisabled, i18n.MsgPlannedWeaponEnabled)
+			labels = append(labels, i18n.Format(msg, map[string]any{"Weapon": WeaponDisplayName(t)}))
+		}
+	}
+	if ss.EngineOrder != current.EngineOrder {
+		labels = append(labels, ss.EngineOrder.ToDisplay())
+	}
+	if ss.FireStance != current.FireStance {
+		labels = append(labels, ss.FireStance.ToDisplay())
+	}
+	if ss.StandOffRate != current.StandOffRate {
+		labels = append(labels, lo.Ternary(
+			ss.StandOffRate > 0, StandOffDisplay(ss.StandOffRate), i18n.Text(i18n.MsgPlannedStandOffClose),
+		))
+	}
+	for _, t := range settingWeaponTypes {
+		policy := ss.TargetPolicies[t]
+		if policy == current.TargetPolicies[t] {
+			continue
+		}
+		if policy == TargetPolicyDefault {
+			labels = append(labels, i18n.Format(i18n.MsgPlannedTargetPolicyDefault, map[string]any{
+				"Weapon": WeaponDisplayName(t),
+			}))
+		} else {
+			labels = append(labels, fmt.Sprintf("%s: %s", WeaponDisplayName(t), policy.ToDisplay()))
+		}
+	}
+	if ss.HoldOnDeck != current.HoldOnDeck {
+		labels = append(labels, lo.Ternary(
+			ss.HoldOnDeck, i18n.Text(i18n.MsgAirOpsHold), i18n.Text(i18n.MsgPlannedAirOpsAuto),
+		))
+	}
+	return labels
+}
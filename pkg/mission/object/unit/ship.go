@@ -3,7 +3,7 @@ package unit
 import (
 	"fmt"
 	"math"
-	"math/rand"
+	"math/rand/v2"
 
 	"github.com/mohae/deepcopy"
 
@@ -88,12 +88,18 @@ type BattleShip struct {
 
 	// 初始生命值
 	TotalHP float64 `json:"totalHP"`
-	// 水平伤害减免（0.7 -> 仅受到击中的 70% 伤害)
+	// 水平伤害减免（0.7 -> 仅受到击中的 70% 伤害)，只作用于不结算装甲的平射命中（如鱼雷）
 	HorizontalDamageReduction float64 `json:"horizontalDamageReduction"`
-	// 垂直伤害减免
+	// 垂直伤害减免，只作用于不结算装甲的曲射命中
 	VerticalDamageReduction float64 `json:"verticalDamageReduction"`
+	// 舷侧装甲带厚度（毫米，平射命中时结算）
+	BeltArmor float64 `json:"beltArmor"`
+	// 甲板装甲厚度（毫米，曲射 / 炸弹命中时结算）
+	DeckArmor float64 `json:"deckArmor"`
 	// 最大速度
 	MaxSpeed float64 `json:"maxSpeed"`
+	// 燃油容量（满油可航行的地图格数，0 表示不限燃油，仅在启用燃油的关卡中生效）
+	FuelCapacity float64 `json:"fuelCapacity"`
 	// 加速度
 	Acceleration float64 `json:"acceleration"`
 	// 转向速度（度）
@@ -108,6 +114,8 @@ type BattleShip struct {
 	TimeCost int64 `json:"timeCost"`
 	// 吨位
 	Tonnage float64 `json:"tonnage"`
+	// 探测范围（地图格，未配置时按舰种默认值）
+	DetectionRange float64 `json:"detectionRange"`
 	// 武器
 	Weapon ShipWeapon `json:"weapon"`
 	// 舰载机联队
@@ -127,6 +135,10 @@ type BattleShip struct {
 	CurRotation float64
 	// 当前速度
 	CurSpeed float64
+	// 剩余燃油（地图格数）
+	Fuel float64
+	// 上次结算燃油后航行的距离（地图格数）
+	SailedDistance float64
 	// 动画累计模拟帧
 	AnimationAge float64
 	// 上一次生成专用尾流时的采样步，用于避免每帧重复堆叠
@@ -135,11 +147,35 @@ type BattleShip struct {
 	GroupID object.GroupID
 	// 攻击目标（敌舰 Uid）
 	AttackTarget string
+	// 排队命令（队首为正在执行的命令）
+	Orders []ShipOrder
+	// 编队状态
+	Formation ShipFormation
+	// 航速限制（编队向导舰按最慢的成员航行，0 表示不限速）
+	SpeedLimit float64
+	// 车钟（航速命令），移动时按对应比例的航速航行，后退时倒车（CurSpeed 为负数）
+	EngineOrder EngineOrder
+	// 值守任务（护航 / 警戒）
+	Duty ShipDuty
+	// 交战规则
+	FireStance FireStance
+	// 与攻击目标保持的距离（对舰最大射程的比例，0 表示逼近目标）
+	StandOffRate float64
+	// 各类武器的目标策略（未设置的使用默认策略）
+	TargetPolicies map[WeaponType]TargetPolicy
+	// 近期攻击过自己的敌人（Key: 战舰 / 战机 Uid，Value: 最近一次命中的任务时间），用于还击
+	Attackers map[string]int64
+	// 引擎 / 舵机战损情况（武器战损记录在各武器上）
+	Damage ShipDamage
+	// 火灾 / 进水 & 损管情况
+	Hazards ShipHazards
 
 	// 所属阵营（玩家）
 	BelongPlayer faction.Player
-	// 上次治疗的 Unix 毫秒时间戳，用于计算固定间隔（仅医疗船使用）
+	// 上次治疗的任务时间（毫秒），用于计算固定间隔（仅医疗船使用）
 	LastHealAt int64
+	// 上次补给弹药的任务时间（毫秒），用于计算固定间隔
+	LastResupplyAt int64
 }
 
 var _ Hurtable = (*BattleShip)(nil)
@@ -257,47 +293,51 @@ func (s *BattleShip) Attack(shipUid string) {
 }
 
 // Fire 向指定目标发射武器
-func (s *BattleShip) Fire(enemy Hurtable) (shotBullets []*objBullet.Bullet) {
+func (s *BattleShip) Fire(enemy Hurtable, now int64, rng *rand.Rand) (shotBullets []*objBullet.Bullet) {
 	// 如果生命值为 0，那还 Fire 个锤子，直接返回
 	if s.CurHP <= 0 {
 		return
 	}
 	for _, gun := range s.Weapon.MainGuns {
-		shotBullets = append(shotBullets, gun.Fire(s, enemy)...)
+		shotBullets = append(shotBullets, gun.Fire(s, enemy, now, rng)...)
 	}
 	for _, gun := range s.Weapon.SecondaryGuns {
-		shotBullets = append(shotBullets, gun.Fire(s, enemy)...)
+		shotBullets = append(shotBullets, gun.Fire(s, enemy, now, rng)...)
 	}
 	for _, gun := range s.Weapon.AntiAircraftGuns {
-		shotBullets = append(shotBullets, gun.Fire(s, enemy)...)
+		shotBullets = append(shotBullets, gun.Fire(s, enemy, now, rng)...)
 	}
 	for _, tp := range s.Weapon.Torpedoes {
-		shotBullets = append(shotBullets, tp.Fire(s, enemy)...)
+		shotBullets = append(shotBullets, tp.Fire(s, enemy, now, rng)...)
 	}
 	for _, rocket := range s.Weapon.Rockets {
-		shotBullets = append(shotBullets, rocket.Fire(s, enemy)...)
+		shotBullets = append(shotBullets, rocket.Fire(s, enemy, now, rng)...)
 	}
 	return shotBullets
 }
 
-// HurtBy 受到伤害
-func (s *BattleShip) HurtBy(bullet *objBullet.Bullet) {
-	realDamage := 0.0
-	if bullet.ShotType == objBullet.ShotTypeDirect {
-		// 平射打击水平装甲带
-		realDamage = bullet.Damage * (1 - s.HorizontalDamageReduction)
-	} else {
-		// 曲射打击垂直装甲带
-		realDamage = bullet.Damage * (1 - s.VerticalDamageReduction)
+// HurtBy 受到伤害（按装甲结算击穿情况，可能击伤命中点附近的部件，引发火灾 / 进水）
+func (s *BattleShip) HurtBy(bullet *objBullet.Bullet, now int64, rng *rand.Rand) {
+	// 装甲结算：炮弹 / 炸弹 / 火箭弹按舷侧 / 甲板装甲结算（取代固定的伤害减免），跳弹 / 未击穿 / 过度击穿只造成部分伤害
+	armorResult, armorDamageRate := s.resolveArmor(bullet)
+	realDamage := bullet.Damage * armorDamageRate
+	if armorResult == objBullet.ArmorResultNone {
+		// 不结算装甲的命中（鱼雷 / 镭射等）仍按固定的伤害减免：平射按水平伤害减免，曲射按垂直伤害减免
+		if bullet.ShotType == objBullet.ShotTypeDirect {
+			realDamage *= 1 - s.HorizontalDamageReduction
+		} else {
+			realDamage *= 1 - s.VerticalDamageReduction
+		}
 	}
 
-	// 暴击伤害的机制，一发大口径可能直接起飞，支持多段暴击
+	// 暴击伤害的机制，一发大口径可能直接起飞，支持多段暴击（只有击穿 / 不结算装甲的命中才会暴击）
 	criticalType := objBullet.CriticalTypeNone
-	randVal := rand.Float64()
-	if randVal < bullet.CriticalRate/10 {
+	randVal := rng.Float64()
+	canCritical := armorResult == objBullet.ArmorResultNone || armorResult == objBullet.ArmorResultPenetration
+	if canCritical && randVal < bullet.CriticalRate/10 {
 		realDamage *= 10
 		criticalType = objBullet.CriticalTypeTenTimes
-	} else if randVal < bullet.CriticalRate {
+	} else if canCritical && randVal < bullet.CriticalRate {
 		realDamage *= 3
 		criticalType = objBullet.CriticalTypeThreeTimes
 	}
@@ -307,6 +347,11 @@ func (s *BattleShip) HurtBy(bullet *objBullet.Bullet) {
 	// 弹药是可以造成重复伤害的，这里需要计算累计值，暴击类型统计，只统计最高倍数
 	bullet.RealDamage += realDamage
 	bullet.CriticalType = max(criticalType, bullet.CriticalType)
+	bullet.ArmorResult = armorResult
+	// 部件战损
+	s.damageComponents(bullet, realDamage, now, rng)
+	// 火灾 / 进水
+	s.startHazards(bullet, realDamage, criticalType, rng)
 }
 
 // GenTrails 生成尾流
@@ -439,34 +484,54 @@ func (s *BattleShip) MoveTo(mapCfg *mapcfg.MapCfg, targetPos objPos.MapPos, near
 
 	// 应用全局速度倍率
 	multiplier := config.G.SpeedMultiplier
-	maxSpeed := s.MaxSpeed * multiplier
-	acceleration := s.Acceleration * multiplier
-	rotateSpeed := s.RotateSpeed * multiplier
-
-	// 未到达目标位置，逐渐加速
-	if s.CurSpeed < maxSpeed {
-		s.CurSpeed = min(maxSpeed, s.CurSpeed+acceleration)
-	}
-	// 到目标位置附近，逐渐减速
-	if nearGoal && s.CurPos.Near(targetPos, s.Length/constants.MapBlockSize*1.5) {
-		s.CurSpeed = max(acceleration*20, s.CurSpeed-acceleration*10)
+	// 引擎 / 舵机战损时航速、加速度 & 转向速度降低
+	maxSpeed := s.EffectiveMaxSpeed() * multiplier
+	// 编队航行时不超过编队航速（加入编队 / 引擎战损时超出的航速按加速度逐渐降低）
+	if s.SpeedLimit > 0 {
+		maxSpeed = min(s.EffectiveMaxSpeed(), s.SpeedLimit) * multiplier
+	}
+	acceleration := s.EffectiveAcceleration() * multiplier
+	rotateSpeed := s.EffectiveRotateSpeed() * multiplier
+
+	// 按车钟确定目标航速（后退时为负数）
+	speedRate := s.EngineOrder.SpeedRate()
+	targetSpeed := maxSpeed * speedRate
+	astern := speedRate < 0
+	// 逐渐加速 / 减速到目标航速
+	if s.CurSpeed < targetSpeed {
+		s.CurSpeed = min(targetSpeed, s.CurSpeed+acceleration)
+	} else if s.CurSpeed > targetSpeed {
+		s.CurSpeed = max(targetSpeed, s.CurSpeed-acceleration)
+	}
+	// 到目标位置附近，逐渐减速（停车时不需要）
+	if nearGoal && targetSpeed != 0 && s.CurPos.Near(targetPos, s.Length/constants.MapBlockSize*1.5) {
+		minSpeed := min(acceleration*20, math.Abs(targetSpeed))
+		if astern {
+			s.CurSpeed = min(-minSpeed, s.CurSpeed+acceleration*10)
+		} else {
+			s.CurSpeed = max(minSpeed, s.CurSpeed-acceleration*10)
+		}
 	}
 	targetRotation := s.CurPos.Angle(targetPos)
-	// 逐渐转向
+	// 后退时舰尾朝向目标
+	if astern {
+		targetRotation = math.Mod(targetRotation+180, 360)
+	}
+	// 逐渐转向（倒车时舵效较差，转向更慢）
 	if s.CurRotation != targetRotation {
-		// 默认顺时针旋转
-		rotateFlag := RotateFlagClockwise
-		// 如果逆时针夹角小于顺时针夹角，则需要逆时针旋转
-		if math.Mod(targetRotation-s.CurRotation+360, 360) > 180 {
-			rotateFlag = RotateFlagAnticlockwise
+		if astern {
+			rotateSpeed /= 2
 		}
-		s.CurRotation += float64(rotateFlag) * min(math.Abs(targetRotation-s.CurRotation), rotateSpeed)
-		s.CurRotation = math.Mod(s.CurRotation+360, 360)
+		s.turnTo(targetRotation, rotateSpeed)
 		// 如果距离太近，则原地旋转到差不多角度，才开始移动
 		if s.CurPos.Near(targetPos, 4) && math.Abs(s.CurRotation-targetRotation) > 1 {
 			s.CurSpeed = 0
 		}
 	}
+	// 停车后不再移动，等待车钟改变
+	if s.CurSpeed == 0 {
+		return false
+	}
 	nextPos := s.CurPos.Copy()
 	// 修改位置
 	nextPos.AddRx(math.Sin(s.CurRotation*math.Pi/180) * s.CurSpeed)
@@ -480,10 +545,23 @@ func (s *BattleShip) MoveTo(mapCfg *mapcfg.MapCfg, targetPos objPos.MapPos, near
 	}
 	// 移动到新位置
 	s.CurPos = nextPos
+	s.SailedDistance += math.Abs(s.CurSpeed)
 
 	return false
 }
 
+// turnTo 按转向速度朝目标角度转向
+func (s *BattleShip) turnTo(targetRotation, rotateSpeed float64) {
+	// 默认顺时针旋转
+	rotateFlag := RotateFlagClockwise
+	// 如果逆时针夹角小于顺时针夹角，则需要逆时针旋转
+	if math.Mod(targetRotation-s.CurRotation+360, 360) > 180 {
+		rotateFlag = RotateFlagAnticlockwise
+	}
+	s.CurRotation += float64(rotateFlag) * min(math.Abs(targetRotation-s.CurRotation), rotateSpeed)
+	s.CurRotation = math.Mod(s.CurRotation+360, 360)
+}
+
 // ShipMap 保存按配置名称索引的舰船模板。
 var ShipMap = map[string]*BattleShip{}
 
@@ -503,6 +581,7 @@ func NewShip(
 	s.BelongPlayer = player
 	// 战舰默认不编组
 	s.GroupID = object.GroupIDNone
+	s.StandOffRate = DefaultStandOffRates[s.Type]
 	return &s
 }
 
@@ -0,0 +1,139 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+)
+
+// 任务存档使用 gob 序列化战场对象，gob 只处理导出字段，
+// 以下类型中参与局内模拟的非导出字段需要显式编解码
+
+// planeFields 与 Plane 字段相同但不带方法，避免 GobEncode 递归
+type planeFields Plane
+
+// landingArcFields 进近圆弧（landingApproachArc）的可序列化形式
+type landingArcFields struct {
+	Center, Start                  [2]float64
+	Radius, StartAngle, SweepAngle float64
+	Frames, RelativeSpeed          float64
+}
+
+// planeGob 战机存档数据
+type planeGob struct {
+	Plane                  planeFields
+	LandingArc             landingArcFields
+	LandingStagingLeg      landingStagingLeg
+	LandingCarrierRotation float64
+	LandingCarrierTurnRate float64
+	LandingDeckFrames      float64
+}
+
+// GobEncode 序列化战机（包含降落阶段的非导出状态）
+func (p *Plane) GobEncode() ([]byte, error) {
+	arc := p.landingArc
+	return gobEncode(planeGob{
+		Plane: planeFields(*p),
+		LandingArc: landingArcFields{
+			Center:        [2]float64{arc.center.forward, arc.center.lateral},
+			Start:         [2]float64{arc.start.forward, arc.start.lateral},
+			Radius:        arc.radius,
+			StartAngle:    arc.startAngle,
+			SweepAngle:    arc.sweepAngle,
+			Frames:        arc.frames,
+			RelativeSpeed: arc.relativeSpeed,
+		},
+		LandingStagingLeg:      p.landingStagingLeg,
+		LandingCarrierRotation: p.landingCarrierRotation,
+		LandingCarrierTurnRate: p.landingCarrierTurnRate,
+		LandingDeckFrames:      p.landingDeckFrames,
+	})
+}
+
+// GobDecode 反序列化战机，并根据类型重新设置移动策略
+func (p *Plane) GobDecode(data []byte) error {
+	var g planeGob
+	if err := gobDecode(data, &g); err != nil {
+		return err
+	}
+	*p = Plane(g.Plane)
+	arc := g.LandingArc
+	p.landingArc = landingApproachArc{
+		center:        carrierLocalOffset{forward: arc.Center[0], lateral: arc.Center[1]},
+		start:         carrierLocalOffset{forward: arc.Start[0], lateral: arc.Start[1]},
+		radius:        arc.Radius,
+		startAngle:    arc.StartAngle,
+		sweepAngle:    arc.SweepAngle,
+		frames:        arc.Frames,
+		relativeSpeed: arc.RelativeSpeed,
+	}
+	p.landingStagingLeg = g.LandingStagingLeg
+	p.landingCarrierRotation = g.LandingCarrierRotation
+	p.landingCarrierTurnRate = g.LandingCarrierTurnRate
+	p.landingDeckFrames = g.LandingDeckFrames
+	p.movementStrategy = NewMovementStrategy(p.Type)
+	return nil
+}
+
+// shipAircraftFields 与 ShipAircraft 字段相同但不带方法
+type shipAircraftFields ShipAircraft
+
+// shipAircraftGob 舰载机存档数据
+type shipAircraftGob struct {
+	Aircraft     shipAircraftFields
+	LandingSlots map[string]int
+}
+
+// GobEncode 序列化舰载机（包含回收槽位）
+func (sa *ShipAircraft) GobEncode() ([]byte, error) {
+	return gobEncode(shipAircraftGob{Aircraft: shipAircraftFields(*sa), LandingSlots: sa.landingSlots})
+}
+
+// GobDecode 反序列化舰载机
+func (sa *ShipAircraft) GobDecode(data []byte) error {
+	var g shipAircraftGob
+	if err := gobDecode(data, &g); err != nil {
+		return err
+	}
+	*sa = ShipAircraft(g.Aircraft)
+	sa.landingSlots = g.LandingSlots
+	return nil
+}
+
+// shipUidGeneratorGob 战舰 Uid 生成器存档数据
+type shipUidGeneratorGob struct {
+	Player  faction.Player
+	Counter map[string]int
+}
+
+// GobEncode 序列化战舰 Uid 生成器（包含各舰种计数）
+func (g *ShipUidGenerator) GobEncode() ([]byte, error) {
+	return gobEncode(shipUidGeneratorGob{Player: g.player, Counter: g.counter})
+}
+
+// GobDecode 反序列化战舰 Uid 生成器
+func (g *ShipUidGenerator) GobDecode(data []byte) error {
+	var d shipUidGeneratorGob
+	if err := gobDecode(data, &d); err != nil {
+		return err
+	}
+	g.player = d.Player
+	g.counter = d.Counter
+	if g.counter == nil {
+		g.counter = map[string]int{}
+	}
+	return nil
+}
+
+func gobEncode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobDecode(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
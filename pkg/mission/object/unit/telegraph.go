@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"slices"
+
+	"github.com/narasux/jutland/pkg/i18n"
+)
+
+// EngineOrder 车钟（航速命令）
+type EngineOrder string
+
+const (
+	// EngineOrderFlank 最大航速（默认）
+	EngineOrderFlank EngineOrder = ""
+	// EngineOrderFull 全速前进
+	EngineOrderFull EngineOrder = "full"
+	// EngineOrderTwoThirds 前进 2/3
+	EngineOrderTwoThirds EngineOrder = "twoThirds"
+	// EngineOrderOneThird 前进 1/3
+	EngineOrderOneThird EngineOrder = "oneThird"
+	// EngineOrderStop 停车
+	EngineOrderStop EngineOrder = "stop"
+	// EngineOrderAstern 后退（低速倒车）
+	EngineOrderAstern EngineOrder = "astern"
+)
+
+// EngineOrders 车钟档位（由慢到快，后退为最低档）
+var EngineOrders = []EngineOrder{
+	EngineOrderAstern,
+	EngineOrderStop,
+	EngineOrderOneThird,
+	EngineOrderTwoThirds,
+	EngineOrderFull,
+	EngineOrderFlank,
+}
+
+// 各车钟档位的航速比例（相对最大航速，负数为倒车）
+var engineOrderSpeedRates = map[EngineOrder]float64{
+	EngineOrderAstern:    -1.0 / 3,
+	EngineOrderStop:      0,
+	EngineOrderOneThird:  1.0 / 3,
+	EngineOrderTwoThirds: 2.0 / 3,
+	EngineOrderFull:      0.85,
+	EngineOrderFlank:     1,
+}
+
+// SpeedRate 航速比例（相对最大航速，负数为倒车，未知档位视为最大航速）
+func (o EngineOrder) SpeedRate() float64 {
+	if rate, ok := engineOrderSpeedRates[o]; ok {
+		return rate
+	}
+	return 1
+}
+
+// Faster 加一档（已是最大航速时不变）
+func (o EngineOrder) Faster() EngineOrder {
+	idx := slices.Index(EngineOrders, o)
+	if idx < 0 {
+		return EngineOrderFlank
+	}
+	return EngineOrders[min(idx+1, len(EngineOrders)-1)]
+}
+
+// Slower 减一档（已是后退时不变）
+func (o EngineOrder) Slower() EngineOrder {
+	idx := slices.Index(EngineOrders, o)
+	if idx < 0 {
+		return EngineOrderFlank
+	}
+	return EngineOrders[max(idx-1, 0)]
+}
+
+// ToDisplay 车钟展示用名称
+func (o EngineOrder) ToDisplay() string {
+	switch o {
+	case EngineOrderAstern:
+		return i18n.Text(i18n.MsgEngineOrderAstern)
+	case EngineOrderStop:
+		return i18n.Text(i18n.MsgEngineOrderStop)
+	case EngineOrderOneThird:
+		return i18n.Text(i18n.MsgEngineOrderOneThird)
+	case EngineOrderTwoThirds:
+		return i18n.Text(i18n.MsgEngineOrderTwoThirds)
+	case EngineOrderFull:
+		return i18n.Text(i18n.MsgEngineOrderFull)
+	}
+	return i18n.Text(i18n.MsgEngineOrderFlank)
+}
+
+// SetEngineOrder 设置车钟
+func (s *BattleShip) SetEngineOrder(order EngineOrder) {
+	if _, ok := engineOrderSpeedRates[order]; !ok {
+		order = EngineOrderFlank
+	}
+	s.EngineOrder = order
+}
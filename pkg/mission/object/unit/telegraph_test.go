@@ -0,0 +1,74 @@
+package unit
+
+import (
+	"testing"
+
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	"github.com/narasux/jutland/pkg/resources/mapcfg"
+)
+
+func newTelegraphTestShip(x, y float64) *BattleShip {
+	return &BattleShip{CurHP: 100, MaxSpeed: 0.3, Acceleration: 0.1, RotateSpeed: 5, CurPos: objPos.NewR(x, y)}
+}
+
+func TestEngineOrderLimitsSpeed(t *testing.T) {
+	useDefaultSettings(t)
+	mapCfg := &mapcfg.MapCfg{Width: 100, Height: 100}
+	ship := newTelegraphTestShip(10, 80)
+	ship.SetEngineOrder(EngineOrderOneThird)
+	for range 5 {
+		ship.MoveTo(mapCfg, objPos.NewR(10, 10), true)
+	}
+	requireClose(t, ship.CurSpeed, 0.1)
+
+	// 停车后逐渐停下，不会到达目标
+	ship.SetEngineOrder(EngineOrderStop)
+	for range 5 {
+		if ship.MoveTo(mapCfg, objPos.NewR(10, 10), true) {
+			t.Fatalf("stopped ship should not arrive")
+		}
+	}
+	requireClose(t, ship.CurSpeed, 0)
+}
+
+func TestSpeedLimitSlowsDownGradually(t *testing.T) {
+	useDefaultSettings(t)
+	mapCfg := &mapcfg.MapCfg{Width: 100, Height: 100}
+	// 编队航速低于当前航速时，按加速度逐渐减速，而不是瞬间降到编队航速
+	ship := newTelegraphTestShip(10, 80)
+	ship.CurSpeed, ship.SpeedLimit = 0.3, 0.1
+	ship.MoveTo(mapCfg, objPos.NewR(10, 10), true)
+	requireClose(t, ship.CurSpeed, 0.2)
+	ship.MoveTo(mapCfg, objPos.NewR(10, 10), true)
+	requireClose(t, ship.CurSpeed, 0.1)
+}
+
+func TestEngineOrderAsternBacksToTarget(t *testing.T) {
+	useDefaultSettings(t)
+	mapCfg := &mapcfg.MapCfg{Width: 100, Height: 100}
+	// 航向正北，目标在正南方：倒车时不需要掉头
+	ship := newTelegraphTestShip(10, 10)
+	ship.SetEngineOrder(EngineOrderAstern)
+	for range 5 {
+		ship.MoveTo(mapCfg, objPos.NewR(10, 30), true)
+	}
+	requireClose(t, ship.CurRotation, 0)
+	requireClose(t, ship.CurSpeed, -0.1)
+	if ship.CurPos.RY <= 10 {
+		t.Fatalf("ship should back towards the target, got %s", ship.CurPos.String())
+	}
+}
+
+func TestEngineOrderShift(t *testing.T) {
+	if EngineOrderFlank.Faster() != EngineOrderFlank || EngineOrderAstern.Slower() != EngineOrderAstern {
+		t.Fatalf("engine order should stay at the ends")
+	}
+	if EngineOrderStop.Slower() != EngineOrderAstern || EngineOrderTwoThirds.Faster() != EngineOrderFull {
+		t.Fatalf("unexpected engine order shift")
+	}
+	ship := &BattleShip{}
+	ship.SetEngineOrder("unknown")
+	if ship.EngineOrder != EngineOrderFlank {
+		t.Fatalf("unknown engine order should fall back to flank, got %q", ship.EngineOrder)
+	}
+}
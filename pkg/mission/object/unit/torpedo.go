@@ -3,12 +3,13 @@ package unit
 import (
 	"log"
 	"math"
-	"time"
+	"math/rand/v2"
 
 	"github.com/mohae/deepcopy"
 
 	"github.com/narasux/jutland/pkg/common/constants"
 	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/clock"
 	"github.com/narasux/jutland/pkg/mission/object"
 	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
 	objPos "github.com/narasux/jutland/pkg/mission/object/position"
@@ -27,6 +28,8 @@ type TorpedoLauncher struct {
 	ShotInterval float64 `json:"shotInterval"`
 	// 装填时间（单位: s）
 	ReloadTime float64 `json:"reloadTime"`
+	// 弹药容量（鱼雷枚数，含已装填的，0 表示不限弹药）
+	AmmoCapacity int `json:"ammoCapacity"`
 	// 射程
 	Range float64 `json:"range"`
 	// 鱼雷速度
@@ -43,29 +46,36 @@ type TorpedoLauncher struct {
 	RightFiringArc FiringArc
 
 	// 动态参数
-	// 当前鱼雷是否可用（如战损 / 禁用）
+	// 当前鱼雷是否被禁用（玩家关闭武器）
 	Disable bool
-	// 开始装填时间（时间戳）
+	// 战损修复完成的任务时间（毫秒，0 表示完好），战损期间不可发射
+	RepairAt int64
+	// 开始装填时间（任务时间，毫秒）
 	ReloadStartAt int64
-	// 最近发射时间（时间戳）
+	// 最近发射时间（任务时间，毫秒）
 	LatestFireAt int64
 	// 本次装填鱼雷已发射数量
 	ShotCountBeforeReload int
+	// 剩余弹药（鱼雷枚数）
+	Ammo int
 }
 
 var _ AttackWeapon = (*TorpedoLauncher)(nil)
 
+// Damaged 是否战损中
+func (lc *TorpedoLauncher) Damaged() bool {
+	return lc.RepairAt != 0
+}
+
 // Reloaded 是否在重新装填 / 发射间隔
-func (lc *TorpedoLauncher) Reloaded() bool {
+func (lc *TorpedoLauncher) Reloaded(now int64) bool {
 	// 注：鱼雷是需要考虑发射间隔的，比如每秒一发之类，全部打完才是重新装填
-	timeNow := time.Now().UnixMilli()
-	speedMult := config.G.SpeedMultiplier
 	// 在重新装填，不可发射
-	if float64(timeNow-lc.ReloadStartAt)*speedMult < lc.ReloadTime*1e3 {
+	if float64(clock.Since(now, lc.ReloadStartAt)) < lc.ReloadTime*1e3 {
 		return false
 	}
 	// 小于发射间隔也是不行的
-	if float64(timeNow-lc.LatestFireAt)*speedMult < lc.ShotInterval*1e3 {
+	if float64(clock.Since(now, lc.LatestFireAt)) < lc.ShotInterval*1e3 {
 		return false
 	}
 	return lc.ShotCountBeforeReload < lc.BulletCount
@@ -86,9 +96,9 @@ func (lc *TorpedoLauncher) InShotRange(shipCurRotation float64, curPos, targetPo
 }
 
 // Fire 发射
-func (lc *TorpedoLauncher) Fire(shooter Attacker, enemy Hurtable) (bullets []*objBullet.Bullet) {
+func (lc *TorpedoLauncher) Fire(shooter Attacker, enemy Hurtable, now int64, _ *rand.Rand) (bullets []*objBullet.Bullet) {
 	// 未启用 / 装填中 / 对象不是战舰，不可发射
-	if lc.Disable || !lc.Reloaded() || enemy.ObjType() != object.TypeShip {
+	if lc.Disable || lc.Damaged() || lc.OutOfAmmo() || !lc.Reloaded(now) || enemy.ObjType() != object.TypeShip {
 		return
 	}
 
@@ -115,13 +125,13 @@ func (lc *TorpedoLauncher) Fire(shooter Attacker, enemy Hurtable) (bullets []*ob
 	}
 	// 鱼雷不是齐射的，是一个一个来的
 	lc.ShotCountBeforeReload++
+	lc.consumeAmmo()
 
-	timeNow := time.Now().UnixMilli()
-	lc.LatestFireAt = timeNow
+	lc.LatestFireAt = now
 	// 弹药打完了，重新装填
 	if lc.ShotCountBeforeReload >= lc.BulletCount {
 		lc.ShotCountBeforeReload = 0
-		lc.ReloadStartAt = timeNow
+		lc.ReloadStartAt = now
 	}
 
 	// 鱼雷的生命值就是最大射程（+5 预留）
@@ -149,5 +159,6 @@ func NewTorpedoLauncher(name string, posPercent float64, leftFireArc, rightFireA
 	lc.PosPercent = posPercent
 	lc.LeftFiringArc = leftFireArc
 	lc.RightFiringArc = rightFireArc
+	lc.Ammo = lc.AmmoCapacity
 	return &lc
 }
@@ -1,6 +1,8 @@
 package unit
 
 import (
+	"math/rand/v2"
+
 	"github.com/narasux/jutland/pkg/i18n"
 	"github.com/narasux/jutland/pkg/mission/faction"
 	"github.com/narasux/jutland/pkg/mission/object"
@@ -70,7 +72,7 @@ func AvailableNations() []Nation {
 	return []Nation{NationAll, NationCN, NationUS, NationJP, NationDE, NationUK, NationSU, NationSpecial}
 }
 
-// CombatPowerInfo 单位的静态战力评估，仅用于图鉴与平衡分析。
+// CombatPowerInfo 单位的静态战力评估，用于图鉴、平衡分析与电脑 AI 的目标评估。
 type CombatPowerInfo struct {
 	// FormationSize 表示本条战力覆盖的单位数量：舰船为 1，飞机为 10 架标准编队。
 	FormationSize int
@@ -134,17 +136,17 @@ type BattleUnit interface {
 type Hurtable interface {
 	BattleUnit
 	ObjType() object.Type
-	HurtBy(bullet *objBullet.Bullet)
+	HurtBy(bullet *objBullet.Bullet, now int64, rng *rand.Rand)
 }
 
 // Attacker 攻击者
 type Attacker interface {
 	BattleUnit
 	ObjType() object.Type
-	Fire(enemy Hurtable) []*objBullet.Bullet
+	Fire(enemy Hurtable, now int64, rng *rand.Rand) []*objBullet.Bullet
 }
 
 // AttackWeapon 攻击性武器
 type AttackWeapon interface {
-	Fire(shooter Attacker, enemy Hurtable) []*objBullet.Bullet
+	Fire(shooter Attacker, enemy Hurtable, now int64, rng *rand.Rand) []*objBullet.Bullet
 }
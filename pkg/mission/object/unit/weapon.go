@@ -1,6 +1,10 @@
 package unit
 
-import "github.com/narasux/jutland/pkg/mission/object"
+import (
+	"github.com/samber/lo"
+
+	"github.com/narasux/jutland/pkg/mission/object"
+)
 
 // FiringArc 火炮射界
 type FiringArc struct {
@@ -91,20 +95,40 @@ type ShipWeapon struct {
 	RocketDisabled          bool
 }
 
-// MainGunReloaded 主炮是否已装填
-func (w *ShipWeapon) MainGunReloaded() bool {
+// AllDamaged 该类武器是否全部战损（没有该类武器时为 false）
+func (w *ShipWeapon) AllDamaged(t WeaponType) bool {
+	gunsDamaged := func(guns []*Gun) bool {
+		return len(guns) != 0 && lo.EveryBy(guns, (*Gun).Damaged)
+	}
+	switch t {
+	case WeaponTypeMainGun:
+		return gunsDamaged(w.MainGuns)
+	case WeaponTypeSecondaryGun:
+		return gunsDamaged(w.SecondaryGuns)
+	case WeaponTypeAntiAircraftGun:
+		return gunsDamaged(w.AntiAircraftGuns)
+	case WeaponTypeTorpedo:
+		return len(w.Torpedoes) != 0 && lo.EveryBy(w.Torpedoes, (*TorpedoLauncher).Damaged)
+	case WeaponTypeRocket:
+		return len(w.Rockets) != 0 && lo.EveryBy(w.Rockets, (*RocketLauncher).Damaged)
+	}
+	return false
+}
+
+// MainGunReloaded 主炮是否已装填（战损 / 弹药耗尽的主炮不计）
+func (w *ShipWeapon) MainGunReloaded(now int64) bool {
 	for _, g := range w.MainGuns {
-		if g.Reloaded() {
+		if !g.Damaged() && !g.OutOfAmmo() && g.Reloaded(now) {
 			return true
 		}
 	}
 	return false
 }
 
-// SecondaryGunReloaded 副炮是否已装填
-func (w *ShipWeapon) SecondaryGunReloaded() bool {
+// SecondaryGunReloaded 副炮是否已装填（战损 / 弹药耗尽的副炮不计）
+func (w *ShipWeapon) SecondaryGunReloaded(now int64) bool {
 	for _, g := range w.SecondaryGuns {
-		if g.Reloaded() {
+		if !g.Damaged() && !g.OutOfAmmo() && g.Reloaded(now) {
 			return true
 		}
 	}
@@ -112,9 +136,9 @@ func (w *ShipWeapon) SecondaryGunReloaded() bool {
 }
 
 // TorpedoLauncherReloaded 鱼雷是否已装填
-func (w *ShipWeapon) TorpedoLauncherReloaded() bool {
+func (w *ShipWeapon) TorpedoLauncherReloaded(now int64) bool {
 	for _, t := range w.Torpedoes {
-		if t.Reloaded() {
+		if !t.Damaged() && !t.OutOfAmmo() && t.Reloaded(now) {
 			return true
 		}
 	}
@@ -122,9 +146,9 @@ func (w *ShipWeapon) TorpedoLauncherReloaded() bool {
 }
 
 // RocketLauncherReloaded 火箭炮是否已装填并可发射下一组
-func (w *ShipWeapon) RocketLauncherReloaded() bool {
+func (w *ShipWeapon) RocketLauncherReloaded(now int64) bool {
 	for _, r := range w.Rockets {
-		if r.Reloaded() {
+		if !r.Damaged() && !r.OutOfAmmo() && r.Reloaded(now) {
 			return true
 		}
 	}
@@ -143,7 +167,7 @@ type PlaneWeapon struct {
 	RocketsMD []WeaponMetadata `json:"rockets"`
 	// 最小释放间隔
 	ReleaseInterval int64 `json:"releaseInterval"`
-	// 最近释放时间
+	// 最近释放时间（任务时间，毫秒）
 	LatestReleaseAt int64
 	// 固定机炮
 	Guns []*Gun
@@ -0,0 +1,75 @@
+package replay
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/jutland/pkg/config"
+)
+
+// Command 回放录像的子命令名称（jutland replay <file>）
+const Command = "replay"
+
+// FileExt 录像文件扩展名（gzip 压缩的 JSON）
+const FileExt = ".jrp"
+
+// Dir 录像文件默认存放目录
+var Dir = filepath.Join(config.BaseDir, "replays")
+
+// NewFilePath 生成录像文件路径：<Dir>/<任务>-<种子>-<时间>.jrp
+func NewFilePath(rp *Replay, now time.Time) string {
+	name := fmt.Sprintf("%s-%d-%s%s", rp.Mission, rp.Seed, now.Format("20060102-150405"), FileExt)
+	return filepath.Join(Dir, name)
+}
+
+// Save 保存录像到文件（自动创建目录）
+func Save(path string, rp *Replay) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrap(err, "create replay dir")
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "create replay file")
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = errors.Wrap(closeErr, "close replay file")
+		}
+	}()
+
+	zw := gzip.NewWriter(file)
+	if err = json.NewEncoder(zw).Encode(rp); err != nil {
+		return errors.Wrap(err, "encode replay")
+	}
+	return errors.Wrap(zw.Close(), "flush replay")
+}
+
+// Load 从文件加载录像，并校验是否可以回放
+func Load(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open replay file")
+	}
+	defer file.Close()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "read replay file")
+	}
+	defer zr.Close()
+
+	var rp Replay
+	if err = json.NewDecoder(zr).Decode(&rp); err != nil {
+		return nil, errors.Wrap(err, "decode replay")
+	}
+	if err = rp.Validate(); err != nil {
+		return nil, err
+	}
+	return &rp, nil
+}
@@ -0,0 +1,213 @@
+// replay 任务录像：按帧记录进入指令集的玩家 / 电脑指令，回放时在相同种子的全新任务中重新下发
+package replay
+
+import (
+	"log"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// Version 录像格式版本，指令或模拟逻辑不兼容变更时需要递增
+const Version = 12
+
+// Replay 任务录像
+type Replay struct {
+	Version int    `json:"version"`
+	Mission string `json:"mission"`
+	Seed    int64  `json:"seed"`
+	// 录制时影响模拟结果的游戏设置
+	Settings Settings `json:"settings"`
+	// 电脑 AI 难度
+	Difficulty state.Difficulty `json:"difficulty,omitempty"`
+	// 录制方操控的玩家 & 联机对战的远程玩家（单机录像为空，即默认玩家）
+	Player       faction.Player `json:"player,omitempty"`
+	RemotePlayer faction.Player `json:"remotePlayer,omitempty"`
+	// 录制结束时的帧数
+	EndTick int64 `json:"endTick"`
+	// 录制期间是否使用过作弊指令（作弊直接修改任务状态，无法回放）
+	Cheated bool `json:"cheated,omitempty"`
+	// 按帧号递增排列，只包含有指令下发的帧
+	Frames []Frame `json:"frames"`
+}
+
+// Settings 影响模拟结果的游戏设置
+type Settings struct {
+	SpeedMultiplier float64 `json:"speedMultiplier"`
+}
+
+// Frame 单帧下发的指令
+type Frame struct {
+	Tick         int64          `json:"t"`
+	Instructions []instr.Record `json:"i"`
+}
+
+// CurrentSettings 获取当前影响模拟结果的游戏设置
+func CurrentSettings() Settings {
+	return Settings{SpeedMultiplier: config.G.SpeedMultiplier}
+}
+
+// Apply 临时应用游戏设置，返回恢复原设置的函数
+func (s Settings) Apply() (restore func()) {
+	speedMultiplier := config.G.SpeedMultiplier
+	config.G.SpeedMultiplier = s.SpeedMultiplier
+	return func() { config.G.SpeedMultiplier = speedMultiplier }
+}
+
+// ApplySettings 临时应用录像中的游戏设置，返回恢复原设置的函数
+func (r *Replay) ApplySettings() (restore func()) {
+	return r.Settings.Apply()
+}
+
+// Validate 检查录像是否可以回放
+func (r *Replay) Validate() error {
+	if r.Version != Version {
+		return errors.Errorf("unsupported replay version %d, expected %d", r.Version, Version)
+	}
+	if r.Mission == "" {
+		return errors.Errorf("replay missing mission")
+	}
+	lastTick := int64(0)
+	for _, frame := range r.Frames {
+		if frame.Tick <= lastTick {
+			return errors.Errorf("replay frame tick %d out of order", frame.Tick)
+		}
+		lastTick = frame.Tick
+		for _, record := range frame.Instructions {
+			if _, err := instr.Decode(record); err != nil {
+				return errors.Wrapf(err, "replay frame tick %d", frame.Tick)
+			}
+		}
+	}
+	return nil
+}
+
+// Recorder 录像录制器
+// 注：方法均允许 nil 接收者（不录制），直接构造的 MissionManager（如单元测试）同样可用
+type Recorder struct {
+	replay Replay
+}
+
+// NewRecorder 创建录像录制器
+func NewRecorder(mission string, seed int64) *Recorder {
+	return &Recorder{replay: Replay{
+		Version:  Version,
+		Mission:  mission,
+		Seed:     seed,
+		Settings: CurrentSettings(),
+		Frames:   []Frame{},
+	}}
+}
+
+// UsePlayers 记录录制方操控的玩家与联机对战的远程玩家
+func (r *Recorder) UsePlayers(player, remotePlayer faction.Player) {
+	if r == nil {
+		return
+	}
+	r.replay.Player, r.replay.RemotePlayer = player, remotePlayer
+}
+
+// UseDifficulty 记录电脑 AI 难度
+func (r *Recorder) UseDifficulty(difficulty state.Difficulty) {
+	if r == nil {
+		return
+	}
+	r.replay.Difficulty = difficulty
+}
+
+// ResumeRecorder 基于已有录像继续录制（如读取任务存档后）
+// 注：录像需要在相同设置下从头模拟，设置与当前不一致时无法继续录制，返回 nil
+func ResumeRecorder(rp *Replay) *Recorder {
+	if rp == nil || rp.Settings.SpeedMultiplier != config.G.SpeedMultiplier {
+		return nil
+	}
+	r := &Recorder{replay: *rp}
+	r.replay.Frames = slices.Clone(rp.Frames)
+	return r
+}
+
+// Record 记录一批在指定帧进入指令集的指令
+// 注：同一帧可以多次调用，按调用顺序追加（回放时同样按顺序覆盖合并）
+func (r *Recorder) Record(tick int64, instructions map[string]instr.Instruction) {
+	if r == nil || len(instructions) == 0 {
+		return
+	}
+	// 批内按 Uid 排序，保证录像文件内容稳定
+	records := instr.EncodeAll(instructions)
+
+	frames := r.replay.Frames
+	if len(frames) == 0 || frames[len(frames)-1].Tick != tick {
+		r.replay.Frames = append(frames, Frame{Tick: tick})
+	}
+	last := &r.replay.Frames[len(r.replay.Frames)-1]
+	last.Instructions = append(last.Instructions, records...)
+}
+
+// MarkCheated 标记录制期间使用过作弊指令
+func (r *Recorder) MarkCheated() {
+	if r == nil {
+		return
+	}
+	r.replay.Cheated = true
+}
+
+// Replay 获取截止到指定帧的录像
+func (r *Recorder) Replay(endTick int64) *Replay {
+	if r == nil {
+		return nil
+	}
+	rp := r.replay
+	rp.EndTick = endTick
+	rp.Frames = slices.Clone(r.replay.Frames)
+	return &rp
+}
+
+// Player 录像播放器，按帧号依次取出录制的指令
+type Player struct {
+	replay *Replay
+	next   int
+}
+
+// NewPlayer 创建录像播放器
+func NewPlayer(rp *Replay) *Player {
+	return &Player{replay: rp}
+}
+
+// Replay 播放的录像
+func (p *Player) Replay() *Replay {
+	return p.replay
+}
+
+// Next 取出指定帧录制的指令（按录制顺序，每次都是全新的指令实例），帧号需要递增
+func (p *Player) Next(tick int64) []instr.Instruction {
+	frames := p.replay.Frames
+	for p.next < len(frames) && frames[p.next].Tick < tick {
+		p.next++
+	}
+	if p.next >= len(frames) || frames[p.next].Tick != tick {
+		return nil
+	}
+
+	instructions := make([]instr.Instruction, 0, len(frames[p.next].Instructions))
+	for _, record := range frames[p.next].Instructions {
+		// 加载时已经校验过，这里不应该出错
+		i, err := instr.Decode(record)
+		if err != nil {
+			log.Printf("[WARN] replay skip record %s at tick %d: %s", record.Name, tick, err)
+			continue
+		}
+		instructions = append(instructions, i)
+	}
+	p.next++
+	return instructions
+}
+
+// Finished 录像是否已经播放完毕
+func (p *Player) Finished(tick int64) bool {
+	return tick >= p.replay.EndTick
+}
@@ -0,0 +1,102 @@
+package replay
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/config"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+func newTestRecorder(t *testing.T) *Recorder {
+	t.Helper()
+	oldSettings := config.G
+	config.G = config.NewDefaultGameSettings()
+	config.G.SpeedMultiplier = 2
+	t.Cleanup(func() { config.G = oldSettings })
+	return NewRecorder("Midway1942", 42)
+}
+
+func TestRecorderMergesBatchesInSameTick(t *testing.T) {
+	r := newTestRecorder(t)
+	move := instr.NewShipMove("ship-1", objPos.New(3, 4))
+	r.Record(3, map[string]instr.Instruction{move.Uid(): move})
+	r.Record(3, map[string]instr.Instruction{})
+	summon := instr.NewShipSummon("ComputerAlpha/rp-0", "")
+	r.Record(3, map[string]instr.Instruction{summon.Uid(): summon})
+	weapon := instr.NewDisableWeapon("ship-1", objUnit.WeaponTypeAll)
+	r.Record(5, map[string]instr.Instruction{weapon.Uid(): weapon})
+
+	rp := r.Replay(10)
+	require.Equal(t, int64(10), rp.EndTick)
+	require.Equal(t, 2.0, rp.Settings.SpeedMultiplier)
+	require.Len(t, rp.Frames, 2)
+	require.Equal(t, int64(3), rp.Frames[0].Tick)
+	require.Equal(t, []string{instr.NameShipMove, instr.NameShipSummon}, []string{
+		rp.Frames[0].Instructions[0].Name, rp.Frames[0].Instructions[1].Name,
+	})
+	require.NoError(t, rp.Validate())
+}
+
+func TestSaveLoadAndPlay(t *testing.T) {
+	r := newTestRecorder(t)
+	move := instr.NewShipMovePath("ship-1", objPos.New(1, 1), objPos.NewR(8.5, 9.5), 0.2)
+	r.Record(2, map[string]instr.Instruction{move.Uid(): move})
+	r.MarkCheated()
+
+	path := filepath.Join(t.TempDir(), "test"+FileExt)
+	require.NoError(t, Save(path, r.Replay(4)))
+	rp, err := Load(path)
+	require.NoError(t, err)
+	require.True(t, rp.Cheated)
+
+	p := NewPlayer(rp)
+	require.Empty(t, p.Next(1))
+	require.Equal(t, []instr.Instruction{move}, p.Next(2))
+	require.Empty(t, p.Next(3))
+	require.False(t, p.Finished(3))
+	require.True(t, p.Finished(4))
+}
+
+func TestResumeRecorder(t *testing.T) {
+	r := newTestRecorder(t)
+	move := instr.NewShipMove("ship-1", objPos.New(3, 4))
+	r.Record(3, map[string]instr.Instruction{move.Uid(): move})
+
+	resumed := ResumeRecorder(r.Replay(5))
+	weapon := instr.NewDisableWeapon("ship-1", objUnit.WeaponTypeAll)
+	resumed.Record(7, map[string]instr.Instruction{weapon.Uid(): weapon})
+	rp := resumed.Replay(9)
+	require.Len(t, rp.Frames, 2)
+	require.Equal(t, int64(7), rp.Frames[1].Tick)
+	// 原录像不受影响
+	require.Len(t, r.Replay(5).Frames, 1)
+
+	// 设置不一致时无法继续录制
+	config.G.SpeedMultiplier = 1
+	require.Nil(t, ResumeRecorder(rp))
+	require.Nil(t, ResumeRecorder(nil))
+}
+
+func TestNilRecorderIsNoop(t *testing.T) {
+	var r *Recorder
+	move := instr.NewShipMove("ship-1", objPos.New(3, 4))
+	r.Record(1, map[string]instr.Instruction{move.Uid(): move})
+	r.MarkCheated()
+	require.Nil(t, r.Replay(1))
+}
+
+func TestValidateRejectsBrokenReplay(t *testing.T) {
+	require.Error(t, (&Replay{Version: Version + 1, Mission: "Midway1942"}).Validate())
+	require.Error(t, (&Replay{Version: Version}).Validate())
+	require.Error(t, (&Replay{Version: Version, Mission: "Midway1942", Frames: []Frame{
+		{Tick: 2}, {Tick: 2},
+	}}).Validate())
+	require.Error(t, (&Replay{Version: Version, Mission: "Midway1942", Frames: []Frame{
+		{Tick: 1, Instructions: []instr.Record{{Name: "Unknown", ObjUid: "ship-1"}}},
+	}}).Validate())
+}
@@ -0,0 +1,131 @@
+// save 任务存档：保存进行中任务的完整状态与未执行完的指令，之后从任务选择界面继续
+package save
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/jutland/pkg/config"
+	"github.com/narasux/jutland/pkg/mission/faction"
+	instr "github.com/narasux/jutland/pkg/mission/instruction"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// Version 存档格式版本，任务状态 / 对象结构不兼容变更时需要递增
+const Version = 2
+
+// FileExt 存档文件扩展名（gzip 压缩的 gob）
+const FileExt = ".jsav"
+
+// Dir 存档文件默认存放目录
+var Dir = filepath.Join(config.BaseDir, "saves")
+
+// File 任务存档
+type File struct {
+	Version int
+	SavedAt time.Time
+	// 任务状态
+	State *state.Snapshot
+	// 指令集中尚未执行完的指令（含执行进度），按 Uid 排序
+	Instructions []instr.Snapshot
+	// 界面操作产生，待下一帧下发的指令
+	PendingInstructions []instr.Snapshot
+	// 战术暂停时下达，尚未下发的指令（按下达顺序分批）
+	PlannedInstructions [][]instr.Snapshot
+	// 各玩家损失的战舰 / 战机数量
+	LostShips  map[faction.Player]int
+	LostPlanes map[faction.Player]int
+	// 截至存档时的录像，读档后继续录制
+	Replay *replay.Replay
+}
+
+// Validate 检查存档是否可以读取
+func (f *File) Validate() error {
+	if f.Version != Version {
+		return errors.Errorf("unsupported save version %d, expected %d", f.Version, Version)
+	}
+	if f.State == nil || f.State.Mission == "" {
+		return errors.Errorf("save missing mission state")
+	}
+	if f.Replay != nil {
+		if err := f.Replay.Validate(); err != nil {
+			return errors.Wrap(err, "save replay")
+		}
+	}
+	return nil
+}
+
+// FilePath 任务存档路径（每个任务一个存档，重复保存会覆盖）
+func FilePath(mission string) string {
+	return filepath.Join(Dir, mission+FileExt)
+}
+
+// SavedAt 获取任务存档的保存时间，存档不存在时返回 false
+func SavedAt(mission string) (time.Time, bool) {
+	info, err := os.Stat(FilePath(mission))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
+
+// Save 保存存档到文件（自动创建目录）
+// 注：先写临时文件再重命名，避免写入失败时破坏已有存档
+func Save(path string, f *File) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrap(err, "create save dir")
+	}
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Wrap(err, "create save file")
+	}
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	zw := gzip.NewWriter(file)
+	if err = gob.NewEncoder(zw).Encode(f); err != nil {
+		return errors.Wrap(err, "encode save")
+	}
+	if err = zw.Close(); err != nil {
+		return errors.Wrap(err, "flush save")
+	}
+	if err = file.Close(); err != nil {
+		return errors.Wrap(err, "close save file")
+	}
+	return errors.Wrap(os.Rename(tmpPath, path), "replace save file")
+}
+
+// Load 从文件加载存档，并校验是否可以读取
+func Load(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open save file")
+	}
+	defer file.Close()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "read save file")
+	}
+	defer zr.Close()
+
+	var f File
+	if err = gob.NewDecoder(zr).Decode(&f); err != nil {
+		return nil, errors.Wrap(err, "decode save")
+	}
+	if err = f.Validate(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
@@ -0,0 +1,50 @@
+package save
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/clock"
+	"github.com/narasux/jutland/pkg/mission/faction"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+func TestSaveLoad(t *testing.T) {
+	oldDir := Dir
+	Dir = t.TempDir()
+	t.Cleanup(func() { Dir = oldDir })
+
+	_, ok := SavedAt("Midway1942")
+	require.False(t, ok)
+
+	src := state.NewRandSource(42)
+	src.Uint64()
+	f := &File{
+		Version: Version,
+		State: &state.Snapshot{
+			Mission:    "Midway1942",
+			Seed:       42,
+			Clock:      clock.Clock{Ticks: 120, ScaledTicks: 240},
+			RandSource: src,
+		},
+		LostShips: map[faction.Player]int{faction.HumanAlpha: 2},
+	}
+	require.NoError(t, Save(FilePath("Midway1942"), f))
+	savedAt, ok := SavedAt("Midway1942")
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now(), savedAt, time.Minute)
+
+	loaded, err := Load(FilePath("Midway1942"))
+	require.NoError(t, err)
+	require.Equal(t, f.State.Clock, loaded.State.Clock)
+	require.Equal(t, f.LostShips, loaded.LostShips)
+	require.Equal(t, src.Uint64(), loaded.State.RandSource.Uint64())
+}
+
+func TestValidateRejectsBrokenSave(t *testing.T) {
+	require.Error(t, (&File{Version: Version + 1}).Validate())
+	require.Error(t, (&File{Version: Version}).Validate())
+	require.NoError(t, (&File{Version: Version, State: &state.Snapshot{Mission: "Midway1942"}}).Validate())
+}
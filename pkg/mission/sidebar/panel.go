@@ -341,6 +341,7 @@ func (p *Panel) drawMinimap(screen *ebiten.Image, ms *state.MissionState) {
 	)
 	p.drawMinimapCamera(screen, ms)
 	p.drawMinimapBuildings(screen, ms)
+	p.drawMinimapLastKnownShips(screen, ms)
 	p.drawMinimapShips(screen, ms)
 	p.drawMinimapPlanes(screen, ms)
 }
@@ -357,7 +358,7 @@ func (p *Panel) drawMinimapCamera(screen *ebiten.Image, ms *state.MissionState)
 
 func (p *Panel) drawMinimapBuildings(screen *ebiten.Image, ms *state.MissionState) {
 	for _, rp := range ms.Arena.ReinforcePoints {
-		clr := lo.Ternary(rp.BelongPlayer == ms.Player.CurPlayer, colorx.Green, colorx.Red)
+		clr := lo.Ternary(ms.IsAlly(ms.Player.CurPlayer, rp.BelongPlayer), colorx.Green, colorx.Red)
 		x, y := p.mapToSidebar(ms, rp.Pos.RX, rp.Pos.RY)
 		vector.FillCircle(screen, float32(x), float32(y), 3, clr, false)
 	}
@@ -369,7 +370,10 @@ func (p *Panel) drawMinimapBuildings(screen *ebiten.Image, ms *state.MissionStat
 
 func (p *Panel) drawMinimapShips(screen *ebiten.Image, ms *state.MissionState) {
 	for _, ship := range ms.Arena.Ships {
-		img := textureImg.GetAbbrShip(ship.Tonnage, ship.BelongPlayer != ms.Player.CurPlayer)
+		if !ms.CanSee(ms.Player.CurPlayer, ship) {
+			continue
+		}
+		img := textureImg.GetAbbrShip(ship.Tonnage, !ms.IsAlly(ms.Player.CurPlayer, ship.BelongPlayer))
 		opts := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
 		ebutil.SetOptsCenterRotation(opts, img, ship.CurRotation)
 		opts.GeoM.Scale(0.55, 0.55)
@@ -379,9 +383,30 @@ func (p *Panel) drawMinimapShips(screen *ebiten.Image, ms *state.MissionState) {
 	}
 }
 
+// drawMinimapLastKnownShips 绘制战争迷雾中敌舰的最后已知位置（逐渐淡出）
+func (p *Panel) drawMinimapLastKnownShips(screen *ebiten.Image, ms *state.MissionState) {
+	if ms.Vision.Revealed {
+		return
+	}
+	now := ms.Core.Clock.Now()
+	for _, ship := range ms.Vision.LastKnown {
+		img := textureImg.GetAbbrShip(ship.Tonnage, true)
+		opts := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+		ebutil.SetOptsCenterRotation(opts, img, ship.Rotation)
+		opts.GeoM.Scale(0.55, 0.55)
+		x, y := p.mapToSidebar(ms, ship.Pos.RX, ship.Pos.RY)
+		opts.GeoM.Translate(x, y)
+		opts.ColorScale.ScaleAlpha(float32(ship.Alpha(now) * 0.5))
+		screen.DrawImage(img, opts)
+	}
+}
+
 func (p *Panel) drawMinimapPlanes(screen *ebiten.Image, ms *state.MissionState) {
 	for _, plane := range ms.Arena.Planes {
-		img := textureImg.GetAbbrPlane(plane.BelongPlayer != ms.Player.CurPlayer)
+		if !ms.CanSee(ms.Player.CurPlayer, plane) {
+			continue
+		}
+		img := textureImg.GetAbbrPlane(!ms.IsAlly(ms.Player.CurPlayer, plane.BelongPlayer))
 		opts := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
 		ebutil.SetOptsCenterRotation(opts, img, plane.CurRotation)
 		opts.GeoM.Scale(0.65, 0.65)
@@ -397,11 +422,11 @@ func (p *Panel) drawBattleInfo(screen *ebiten.Image, ms *state.MissionState) {
 	p.drawCard(screen, ui.Panel.X+16, y, ui.Panel.W-32, 104)
 
 	selfFleet := ms.Fleet(ms.Player.CurPlayer)
-	enemyFleet := ms.Fleet(ms.Player.CurEnemy)
+	enemyFleet := ms.HostileFleet(ms.Player.CurPlayer)
 	bodyFont := font.LocalizedUI(font.Kai)
 	p.drawText(
 		screen,
-		i18n.Format(i18n.MsgSidebarFunds, map[string]any{"Funds": ms.Player.CurFunds}),
+		i18n.Format(i18n.MsgSidebarFunds, map[string]any{"Funds": ms.CurFunds()}),
 		ui.Panel.X+28,
 		y+14,
 		18,
@@ -0,0 +1,15 @@
+package state
+
+import (
+	"github.com/narasux/jutland/pkg/mission/faction"
+)
+
+// IsAlly 两个玩家在当前任务中是否为友军（包括同一玩家）
+func (s *MissionState) IsAlly(p, q faction.Player) bool {
+	return s.Core.MissionMD.Alliances.IsAlly(p, q)
+}
+
+// IsEnemy 两个玩家在当前任务中是否敌对（中立势力不与任何玩家敌对）
+func (s *MissionState) IsEnemy(p, q faction.Player) bool {
+	return s.Core.MissionMD.Alliances.IsEnemy(p, q)
+}
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+)
+
+// Checksum 计算任务模拟状态的校验值（联机对战时用于检测双方是否不同步）
+// 注：只包含影响模拟结果的状态（时钟，随机数源，资金，战场对象），不包含相机，选中，界面等本地状态
+func (s *MissionState) Checksum() uint64 {
+	// 确保随机数源已经初始化（直接构造的 MissionState 可能还没有使用过随机数）
+	s.Rand()
+
+	w := checksumWriter{Hash64: fnv.New64a()}
+	w.ints(s.Core.Clock.Ticks)
+	if b, err := s.Core.RandSource.MarshalBinary(); err == nil {
+		_, _ = w.Write(b)
+	}
+	for _, player := range faction.AllPlayers {
+		if funds, ok := s.FundsOf(player); ok {
+			w.str(string(player))
+			w.ints(funds)
+		}
+	}
+	for _, rp := range s.SortedReinforcePoints() {
+		w.str(rp.Uid)
+		w.ints(int64(len(rp.OncomingShips)))
+		w.floats(rp.RallyPos.RX, rp.RallyPos.RY)
+	}
+	for _, ship := range s.SortedShips() {
+		w.str(ship.Uid)
+		w.str(string(ship.BelongPlayer))
+		w.floats(ship.CurPos.RX, ship.CurPos.RY, ship.CurRotation, ship.CurSpeed, ship.CurHP)
+	}
+	for _, plane := range s.SortedPlanes() {
+		w.str(plane.Uid)
+		w.floats(plane.CurPos.RX, plane.CurPos.RY, plane.CurHP)
+	}
+	w.ints(int64(len(s.Arena.ForwardingBullets)))
+	for _, bt := range s.Arena.ForwardingBullets {
+		w.floats(bt.CurPos.RX, bt.CurPos.RY)
+	}
+	return w.Sum64()
+}
+
+// checksumWriter 按固定字节序写入校验数据
+type checksumWriter struct {
+	hash.Hash64
+}
+
+func (w checksumWriter) str(v string) {
+	_, _ = w.Write([]byte(v))
+	// 分隔符，避免相邻字符串拼接后产生歧义
+	_, _ = w.Write([]byte{0})
+}
+
+func (w checksumWriter) ints(values ...int64) {
+	for _, v := range values {
+		_, _ = w.Write(binary.LittleEndian.AppendUint64(nil, uint64(v)))
+	}
+}
+
+func (w checksumWriter) floats(values ...float64) {
+	for _, v := range values {
+		_, _ = w.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(v)))
+	}
+}
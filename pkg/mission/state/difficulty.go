@@ -0,0 +1,49 @@
+package state
+
+import "github.com/pkg/errors"
+
+// Difficulty 电脑 AI 难度
+type Difficulty string
+
+const (
+	// DifficultyEasy 简单：反应慢，不集火，不撤退
+	DifficultyEasy Difficulty = "easy"
+	// DifficultyNormal 普通
+	DifficultyNormal Difficulty = "normal"
+	// DifficultyHard 困难：反应快，集中火力，受损及时撤退
+	DifficultyHard Difficulty = "hard"
+)
+
+// Difficulties 所有可选的 AI 难度（按从易到难排序）
+var Difficulties = []Difficulty{DifficultyEasy, DifficultyNormal, DifficultyHard}
+
+// OrDefault 未设置 / 未知的难度视为普通难度（如旧版本的存档与录像）
+func (d Difficulty) OrDefault() Difficulty {
+	switch d {
+	case DifficultyEasy, DifficultyNormal, DifficultyHard:
+		return d
+	default:
+		return DifficultyNormal
+	}
+}
+
+// Next 下一个难度（循环切换）
+func (d Difficulty) Next() Difficulty {
+	d = d.OrDefault()
+	for idx, difficulty := range Difficulties {
+		if difficulty == d {
+			return Difficulties[(idx+1)%len(Difficulties)]
+		}
+	}
+	return DifficultyNormal
+}
+
+// ParseDifficulty 解析 AI 难度（如命令行参数）
+func ParseDifficulty(s string) (Difficulty, error) {
+	for _, difficulty := range Difficulties {
+		if string(difficulty) == s {
+			return difficulty, nil
+		}
+	}
+	return "", errors.Errorf("unknown difficulty %s, expected one of %v", s, Difficulties)
+}
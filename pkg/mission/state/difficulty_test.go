@@ -0,0 +1,29 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDifficultyOrDefault(t *testing.T) {
+	require.Equal(t, DifficultyHard, DifficultyHard.OrDefault())
+	require.Equal(t, DifficultyNormal, Difficulty("").OrDefault())
+	require.Equal(t, DifficultyNormal, Difficulty("nightmare").OrDefault())
+}
+
+func TestDifficultyNext(t *testing.T) {
+	require.Equal(t, DifficultyNormal, DifficultyEasy.Next())
+	require.Equal(t, DifficultyHard, DifficultyNormal.Next())
+	require.Equal(t, DifficultyEasy, DifficultyHard.Next())
+	require.Equal(t, DifficultyHard, Difficulty("").Next())
+}
+
+func TestParseDifficulty(t *testing.T) {
+	d, err := ParseDifficulty("easy")
+	require.NoError(t, err)
+	require.Equal(t, DifficultyEasy, d)
+
+	_, err = ParseDifficulty("nightmare")
+	require.Error(t, err)
+}
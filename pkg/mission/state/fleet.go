@@ -1,6 +1,10 @@
 package state
 
 import (
+	"sort"
+
+	"github.com/samber/lo"
+
 	"github.com/narasux/jutland/pkg/mission/faction"
 	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
 )
@@ -13,7 +17,45 @@ type ShipClass struct {
 
 // Fleet 舰队
 type Fleet struct {
+	// 统计舰队的玩家（敌对舰队为其所针对的玩家）
 	Player  faction.Player
 	Total   int
 	Classes []ShipClass
 }
+
+// Fleet 统计玩家的舰队（同类战舰计数）
+func (s *MissionState) Fleet(player faction.Player) Fleet {
+	return s.collectFleet(player, func(ship *objUnit.BattleShip) bool {
+		return ship.BelongPlayer == player
+	})
+}
+
+// HostileFleet 统计与玩家敌对的所有势力的舰队（不含中立势力）
+func (s *MissionState) HostileFleet(player faction.Player) Fleet {
+	return s.collectFleet(player, func(ship *objUnit.BattleShip) bool {
+		return s.IsEnemy(player, ship.BelongPlayer)
+	})
+}
+
+func (s *MissionState) collectFleet(player faction.Player, filter func(ship *objUnit.BattleShip) bool) Fleet {
+	ships := lo.Filter(lo.Values(s.Arena.Ships), func(ship *objUnit.BattleShip, _ int) bool {
+		return filter(ship)
+	})
+
+	classMap := map[string]ShipClass{}
+	for _, ship := range ships {
+		if cls, ok := classMap[ship.Name]; ok {
+			cls.Total++
+			classMap[ship.Name] = cls
+		} else {
+			classMap[ship.Name] = ShipClass{Total: 1, Kind: ship}
+		}
+	}
+
+	classes := lo.Values(classMap)
+	// 按照吨位从大到小排列
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Kind.Tonnage > classes[j].Kind.Tonnage
+	})
+	return Fleet{Player: player, Total: len(ships), Classes: classes}
+}
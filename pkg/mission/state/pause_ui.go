@@ -37,3 +37,15 @@ func CalcPauseUILayout(screen layout.ScreenLayout) PauseUILayout {
 		DangerButton:  PauseUIRect{X: primaryX + buttonW + gap, Y: buttonY, W: buttonW, H: buttonH},
 	}
 }
+
+// PauseSaveStatus 暂停面板中的保存进度结果
+type PauseSaveStatus int
+
+const (
+	// PauseSaveNone 本次暂停尚未保存
+	PauseSaveNone PauseSaveStatus = iota
+	// PauseSaveSucceeded 保存成功
+	PauseSaveSucceeded
+	// PauseSaveFailed 保存失败
+	PauseSaveFailed
+)
@@ -0,0 +1,87 @@
+package state
+
+import (
+	"github.com/narasux/jutland/pkg/mission/faction"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+)
+
+// ComputerPlayers 任务中由电脑 AI 控制的玩家（除当前玩家，远程玩家与中立势力外的所有参战玩家）
+func (s *MissionState) ComputerPlayers() []faction.Player {
+	players := []faction.Player{}
+	for _, player := range s.Core.MissionMD.Players {
+		if player != s.Player.CurPlayer && player != s.Player.RemotePlayer && player != faction.Neutral {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// initFunds 各参战玩家（中立势力除外）的初始资金
+func initFunds(missionMD metadata.MissionMetadata) map[faction.Player]int64 {
+	funds := map[faction.Player]int64{}
+	for _, player := range missionMD.Players {
+		if player != faction.Neutral {
+			funds[player] = missionMD.InitFunds
+		}
+	}
+	return funds
+}
+
+// FundsOf 获取玩家资金，没有资金的玩家（中立势力）返回 false
+// 注：联机对战时双方的当前玩家不同，模拟逻辑需要通过玩家获取资金，保证两端结果一致
+func (s *MissionState) FundsOf(player faction.Player) (int64, bool) {
+	funds, ok := s.Player.Funds[player]
+	return funds, ok
+}
+
+// CurFunds 获取当前玩家的资金
+func (s *MissionState) CurFunds() int64 {
+	funds, _ := s.FundsOf(s.Player.CurPlayer)
+	return funds
+}
+
+// AddFunds 增加玩家资金（负数为扣除），没有资金的玩家忽略
+func (s *MissionState) AddFunds(player faction.Player, delta int64) {
+	if funds, ok := s.Player.Funds[player]; ok {
+		s.Player.Funds[player] = funds + delta
+	}
+}
+
+// UsePlayer 切换当前玩家（联机对战的客机），并把镜头 & 选中的增援点切换到该玩家
+func (s *MissionState) UsePlayer(player faction.Player) {
+	if player == s.Player.CurPlayer {
+		return
+	}
+	s.Player.CurPlayer = player
+	s.Interaction.SelectedReinforcePointUid = ""
+	s.Interaction.SelectedShips = []string{}
+
+	var focus *objPos.MapPos
+	for _, rp := range s.SortedReinforcePoints() {
+		if rp.BelongPlayer == player {
+			s.Interaction.SelectedReinforcePointUid = rp.Uid
+			focus = &rp.Pos
+		}
+	}
+	// 没有增援点时，镜头对准该玩家的第一艘战舰
+	if focus == nil {
+		for _, ship := range s.SortedShips() {
+			if ship.BelongPlayer == player {
+				focus = &ship.CurPos
+				break
+			}
+		}
+	}
+	if focus != nil {
+		pos := focus.Copy()
+		pos.AssignRxy(
+			pos.RX-float64(s.View.Camera.Width)/2,
+			pos.RY-float64(s.View.Camera.Height)/2,
+		)
+		pos.EnsureBorder(s.CameraPosBorder())
+		s.View.Camera.Pos = pos
+	}
+	s.Vision.LastKnown = nil
+	s.UpdateVision()
+}
@@ -0,0 +1,34 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+)
+
+func TestFundsPerPlayer(t *testing.T) {
+	misState := &MissionState{
+		Player: MissionPlayerState{
+			CurPlayer: faction.HumanAlpha,
+			Funds: initFunds(metadata.MissionMetadata{
+				InitFunds: 1000,
+				Players:   []faction.Player{faction.HumanAlpha, faction.ComputerAlpha, faction.Neutral},
+			}),
+		},
+	}
+
+	misState.AddFunds(faction.ComputerAlpha, -300)
+	misState.AddFunds(faction.Neutral, 500)
+	require.Equal(t, int64(1000), misState.CurFunds())
+
+	funds, ok := misState.FundsOf(faction.ComputerAlpha)
+	require.True(t, ok)
+	require.Equal(t, int64(700), funds)
+
+	// 中立势力没有资金
+	_, ok = misState.FundsOf(faction.Neutral)
+	require.False(t, ok)
+}
@@ -0,0 +1,89 @@
+package state
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+// MaxRandomSeed 随机种子上限（不含），保持在便于口头 / 文字转述的位数内
+const MaxRandomSeed = 1_000_000_000
+
+// NewRandomSeed 生成新的任务随机种子
+func NewRandomSeed() int64 {
+	return rand.Int64N(MaxRandomSeed)
+}
+
+// NewRandSource 根据种子创建随机数源
+func NewRandSource(seed int64) *rand.PCG {
+	return rand.NewPCG(uint64(seed), uint64(seed)^0x9e3779b97f4a7c15)
+}
+
+// NewPlayerRand 创建玩家输入处理器（人类 / 电脑）专用的随机数
+// 注：输入处理器不能消耗任务随机数源，录像回放时不再重新计算输入，
+// 否则后续战斗消耗的随机数会与录制时错位
+func NewPlayerRand(seed int64, player faction.Player) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(player))
+	return rand.New(NewRandSource(seed ^ int64(h.Sum64())))
+}
+
+// Rand 任务随机数源，同一种子下战斗可以完全复现
+// 注：随机数源按需初始化，直接构造的 MissionState（如单元测试）同样可用
+func (s *MissionState) Rand() *rand.Rand {
+	if s.Core.rand == nil {
+		if s.Core.RandSource == nil {
+			s.Core.RandSource = NewRandSource(s.Core.Seed)
+		}
+		s.Core.rand = rand.New(s.Core.RandSource)
+	}
+	return s.Core.rand
+}
+
+// SortedShips 按 Uid 排序的存活战舰
+// 注：map 遍历顺序是随机的，消耗随机数的逻辑需要按固定顺序遍历，才能保证可复现
+func (s *MissionState) SortedShips() []*objUnit.BattleShip {
+	ships := lo.Values(s.Arena.Ships)
+	slices.SortFunc(ships, func(a, b *objUnit.BattleShip) int {
+		return strings.Compare(a.Uid, b.Uid)
+	})
+	return ships
+}
+
+// SortedPlanes 按 Uid 排序的在场战机
+func (s *MissionState) SortedPlanes() []*objUnit.Plane {
+	planes := lo.Values(s.Arena.Planes)
+	slices.SortFunc(planes, func(a, b *objUnit.Plane) int {
+		return strings.Compare(a.Uid, b.Uid)
+	})
+	return planes
+}
+
+// SortedReinforcePoints 按 Uid 排序的增援点
+func (s *MissionState) SortedReinforcePoints() []*objBuilding.ReinforcePoint {
+	points := lo.Values(s.Arena.ReinforcePoints)
+	slices.SortFunc(points, func(a, b *objBuilding.ReinforcePoint) int {
+		return strings.Compare(a.Uid, b.Uid)
+	})
+	return points
+}
+
+// SortedOilPlatforms 按位置排序的油井
+// 注：油井 Uid 是随机生成的，联机对战双方 / 回放时并不相同，因此按位置排序
+func (s *MissionState) SortedOilPlatforms() []*objBuilding.OilPlatform {
+	platforms := lo.Values(s.Arena.OilPlatforms)
+	slices.SortFunc(platforms, func(a, b *objBuilding.OilPlatform) int {
+		if a.Pos.MX != b.Pos.MX {
+			return a.Pos.MX - b.Pos.MX
+		}
+		return a.Pos.MY - b.Pos.MY
+	})
+	return platforms
+}
@@ -0,0 +1,31 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+)
+
+func TestRandIsReproducibleWithSameSeed(t *testing.T) {
+	a, b := &MissionState{Core: MissionCoreState{Seed: 42}}, &MissionState{Core: MissionCoreState{Seed: 42}}
+	for range 16 {
+		require.Equal(t, a.Rand().Int64(), b.Rand().Int64())
+	}
+
+	c := &MissionState{Core: MissionCoreState{Seed: 43}}
+	require.NotEqual(t, a.Rand().Int64(), c.Rand().Int64())
+}
+
+func TestPlayerRandIsIndependentOfMissionRand(t *testing.T) {
+	misState := &MissionState{Core: MissionCoreState{Seed: 42}}
+	alpha, beta := NewPlayerRand(42, faction.HumanAlpha), NewPlayerRand(42, faction.ComputerAlpha)
+	require.NotEqual(t, alpha.Int64(), beta.Int64())
+
+	// 消耗玩家随机数不影响任务随机数序列
+	expected := (&MissionState{Core: MissionCoreState{Seed: 42}}).Rand().Int64()
+	alpha.Int64()
+	require.Equal(t, expected, misState.Rand().Int64())
+	require.Equal(t, NewPlayerRand(42, faction.HumanAlpha).Int64(), NewPlayerRand(42, faction.HumanAlpha).Int64())
+}
@@ -0,0 +1,102 @@
+package state
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/narasux/jutland/pkg/common/constants"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+	"github.com/narasux/jutland/pkg/utils/geometry"
+)
+
+// SelectMode 选择战舰的方式
+type SelectMode int
+
+const (
+	// SelectReplace 替换当前选中的战舰
+	SelectReplace SelectMode = iota
+	// SelectAdd 追加到当前选中的战舰（按住 Shift）
+	SelectAdd
+	// SelectRemove 从当前选中的战舰中移除（按住 Ctrl）
+	SelectRemove
+)
+
+// ApplySelection 按选择方式合并原有选中与本次选中的战舰（保持原有顺序，追加的战舰排在后面）
+func ApplySelection(base, picked []string, mode SelectMode) []string {
+	switch mode {
+	case SelectAdd:
+		selected := slices.Clone(base)
+		for _, uid := range picked {
+			if !slices.Contains(selected, uid) {
+				selected = append(selected, uid)
+			}
+		}
+		return selected
+	case SelectRemove:
+		return slices.DeleteFunc(slices.Clone(base), func(uid string) bool {
+			return slices.Contains(picked, uid)
+		})
+	default:
+		return slices.Clone(picked)
+	}
+}
+
+// NextCycledShip 在候选战舰中循环选择：当前只选中一艘候选战舰时取其后一艘，否则取第一艘，没有候选时返回空
+func NextCycledShip(candidates, selected []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	if len(selected) == 1 {
+		if idx := slices.Index(candidates, selected[0]); idx != -1 {
+			return candidates[(idx+1)%len(candidates)]
+		}
+	}
+	return candidates[0]
+}
+
+// ShipAtPos 获取指定位置上满足条件的战舰（按 Uid 顺序，返回第一艘）
+func (s *MissionState) ShipAtPos(pos objPos.MapPos, match func(*objUnit.BattleShip) bool) *objUnit.BattleShip {
+	for _, ship := range s.SortedShips() {
+		if !match(ship) {
+			continue
+		}
+		if geometry.IsPointInRotatedRectangle(
+			pos.RX, pos.RY,
+			ship.CurPos.RX, ship.CurPos.RY,
+			ship.Length/constants.MapBlockSize,
+			ship.Width/constants.MapBlockSize,
+			ship.CurRotation,
+		) {
+			return ship
+		}
+	}
+	return nil
+}
+
+// OwnShipUids 当前玩家满足条件的存活战舰（按 Uid 排序）
+func (s *MissionState) OwnShipUids(match func(*objUnit.BattleShip) bool) []string {
+	uids := []string{}
+	for _, ship := range s.SortedShips() {
+		if ship.BelongPlayer == s.Player.CurPlayer && ship.CurHP > 0 && match(ship) {
+			uids = append(uids, ship.Uid)
+		}
+	}
+	return uids
+}
+
+// IdleShipUids 当前玩家的空闲战舰（按 Uid 排序）
+func (s *MissionState) IdleShipUids() []string {
+	return s.OwnShipUids((*objUnit.BattleShip).IsIdle)
+}
+
+// DamagedShipUids 当前玩家受损的战舰（按剩余生命值比例从低到高，相同时按 Uid 排序）
+func (s *MissionState) DamagedShipUids() []string {
+	uids := s.OwnShipUids(func(ship *objUnit.BattleShip) bool { return ship.CurHP < ship.TotalHP })
+	hpRate := func(uid string) float64 {
+		ship := s.Arena.Ships[uid]
+		return ship.CurHP / ship.TotalHP
+	}
+	slices.SortStableFunc(uids, func(a, b string) int { return cmp.Compare(hpRate(a), hpRate(b)) })
+	return uids
+}
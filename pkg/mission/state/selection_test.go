@@ -0,0 +1,55 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+func TestApplySelection(t *testing.T) {
+	base := []string{"s2", "s1"}
+
+	require.Equal(t, []string{"s3"}, ApplySelection(base, []string{"s3"}, SelectReplace))
+	require.Equal(t, []string{"s2", "s1", "s3"}, ApplySelection(base, []string{"s1", "s3"}, SelectAdd))
+	require.Equal(t, []string{"s2"}, ApplySelection(base, []string{"s1", "s3"}, SelectRemove))
+	// 不修改原有选中
+	require.Equal(t, []string{"s2", "s1"}, base)
+}
+
+func TestNextCycledShip(t *testing.T) {
+	candidates := []string{"s1", "s2", "s3"}
+
+	require.Equal(t, "", NextCycledShip(nil, []string{"s1"}))
+	require.Equal(t, "s1", NextCycledShip(candidates, nil))
+	require.Equal(t, "s3", NextCycledShip(candidates, []string{"s2"}))
+	require.Equal(t, "s1", NextCycledShip(candidates, []string{"s3"}))
+	// 选中多艘或非候选战舰时从第一艘开始
+	require.Equal(t, "s1", NextCycledShip(candidates, []string{"s2", "s3"}))
+	require.Equal(t, "s1", NextCycledShip(candidates, []string{"s9"}))
+}
+
+func TestIdleAndDamagedShipUids(t *testing.T) {
+	newShip := func(uid string, player faction.Player, hp float64) *objUnit.BattleShip {
+		return &objUnit.BattleShip{Uid: uid, BelongPlayer: player, CurHP: hp, TotalHP: 100}
+	}
+	ships := map[string]*objUnit.BattleShip{
+		"a": newShip("a", faction.HumanAlpha, 100),
+		"b": newShip("b", faction.HumanAlpha, 30),
+		"c": newShip("c", faction.HumanAlpha, 60),
+		"d": newShip("d", faction.HumanAlpha, 30),
+		"e": newShip("e", faction.ComputerAlpha, 10),
+	}
+	ships["b"].CurSpeed = 0.1
+	ships["c"].AttackTarget = "e"
+	ships["d"].Formation = objUnit.ShipFormation{Type: objUnit.FormationLineAhead, GuideUid: "a"}
+	misState := &MissionState{
+		Player: MissionPlayerState{CurPlayer: faction.HumanAlpha},
+		Arena:  MissionArenaState{Ships: ships},
+	}
+
+	require.Equal(t, []string{"a"}, misState.IdleShipUids())
+	require.Equal(t, []string{"b", "d", "c"}, misState.DamagedShipUids())
+}
@@ -0,0 +1,152 @@
+package state
+
+import (
+	"math/rand/v2"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/jutland/pkg/mission/clock"
+	"github.com/narasux/jutland/pkg/mission/faction"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	"github.com/narasux/jutland/pkg/mission/object"
+	objBuilding "github.com/narasux/jutland/pkg/mission/object/building"
+	objBullet "github.com/narasux/jutland/pkg/mission/object/bullet"
+	objExplosion "github.com/narasux/jutland/pkg/mission/object/explosion"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+// Snapshot 任务状态快照（用于任务存档，可以 gob 序列化）
+// 注：地图、任务元数据、屏幕布局等可以由任务名称重建的内容不保存，
+// 尾流、游戏标识等纯视觉效果也不保存，读档后重新生成
+type Snapshot struct {
+	Mission    string
+	Seed       int64
+	Clock      clock.Clock
+	RandSource *rand.PCG
+	// 电脑 AI 难度（旧存档中为空，视为普通难度）
+	Difficulty Difficulty
+
+	// 相机位置 & 游戏选项
+	CameraPos objPos.MapPos
+	GameOpts  GameOptions
+
+	Player MissionPlayerState
+
+	// 选中的战舰 & 增援点
+	SelectedShips             []string
+	SelectedGroupID           object.GroupID
+	SelectedReinforcePointUid string
+	ShowRallyLinePointUid     string
+
+	// 战场对象（增援队列 & 集结点、战舰武器装填 / 禁用状态、战机飞行阶段等都在对象中）
+	ReinforcePoints   map[string]*objBuilding.ReinforcePoint
+	OilPlatforms      map[string]*objBuilding.OilPlatform
+	Ships             map[string]*objUnit.BattleShip
+	ShipUidGenerators map[faction.Player]*objUnit.ShipUidGenerator
+	DestroyedShips    []*objUnit.BattleShip
+	DestroyedPlanes   []*objUnit.Plane
+	Explosions        []*objExplosion.Explosion
+	Planes            map[string]*objUnit.Plane
+	ForwardingBullets []*objBullet.Bullet
+
+	// 战争迷雾（可见单位读档后重新计算）
+	LastKnownShips map[string]*LastKnownShip
+	FogRevealed    bool
+}
+
+// Snapshot 获取任务状态快照
+// 注：快照与任务状态共享战场对象，需要立即序列化，不能在后续帧中继续使用
+func (s *MissionState) Snapshot() *Snapshot {
+	// 确保随机数源已经初始化（直接构造的 MissionState 可能还没有使用过随机数）
+	s.Rand()
+	return &Snapshot{
+		Mission:                   s.Core.Mission,
+		Seed:                      s.Core.Seed,
+		Clock:                     s.Core.Clock,
+		RandSource:                s.Core.RandSource,
+		Difficulty:                s.Core.Difficulty,
+		CameraPos:                 s.View.Camera.Pos,
+		GameOpts:                  s.UI.GameOpts,
+		Player:                    s.Player,
+		SelectedShips:             s.Interaction.SelectedShips,
+		SelectedGroupID:           s.Interaction.SelectedGroupID,
+		SelectedReinforcePointUid: s.Interaction.SelectedReinforcePointUid,
+		ShowRallyLinePointUid:     s.UI.ShowRallyLinePointUid,
+		ReinforcePoints:           s.Arena.ReinforcePoints,
+		OilPlatforms:              s.Arena.OilPlatforms,
+		Ships:                     s.Arena.Ships,
+		ShipUidGenerators:         s.Arena.ShipUidGenerators,
+		DestroyedShips:            s.Arena.DestroyedShips,
+		DestroyedPlanes:           s.Arena.DestroyedPlanes,
+		Explosions:                s.Arena.Explosions,
+		Planes:                    s.Arena.Planes,
+		ForwardingBullets:         s.Arena.ForwardingBullets,
+		LastKnownShips:            s.Vision.LastKnown,
+		FogRevealed:               s.Vision.Revealed,
+	}
+}
+
+// RestoreMissionState 根据快照恢复任务状态（任务进行中）
+func RestoreMissionState(snap *Snapshot) (*MissionState, error) {
+	if metadata.Get(snap.Mission).Name == "" {
+		return nil, errors.Errorf("unknown mission %s", snap.Mission)
+	}
+	if snap.RandSource == nil {
+		return nil, errors.Errorf("mission %s snapshot missing rand source", snap.Mission)
+	}
+
+	s := NewMissionState(snap.Mission, snap.Seed)
+	s.Core.Clock = snap.Clock
+	s.Core.RandSource = snap.RandSource
+	s.Core.rand = nil
+	s.Core.Difficulty = snap.Difficulty.OrDefault()
+
+	s.View.Camera.Pos = snap.CameraPos
+	s.UI.GameOpts = snap.GameOpts
+	s.UI.GameOpts.Zoom = NormalizeZoom(s.UI.GameOpts.Zoom)
+	s.RefreshCameraSize()
+
+	s.Player = snap.Player
+	s.Interaction.SelectedShips = nonNil(snap.SelectedShips)
+	s.Interaction.SelectedGroupID = snap.SelectedGroupID
+	s.Interaction.SelectedReinforcePointUid = snap.SelectedReinforcePointUid
+	s.UI.ShowRallyLinePointUid = snap.ShowRallyLinePointUid
+
+	s.Arena.ReinforcePoints = nonNilMap(snap.ReinforcePoints)
+	s.Arena.OilPlatforms = nonNilMap(snap.OilPlatforms)
+	s.Arena.Ships = nonNilMap(snap.Ships)
+	s.Arena.Planes = nonNilMap(snap.Planes)
+	s.Arena.DestroyedShips = nonNil(snap.DestroyedShips)
+	s.Arena.DestroyedPlanes = nonNil(snap.DestroyedPlanes)
+	s.Arena.Explosions = nonNil(snap.Explosions)
+	s.Arena.ForwardingBullets = nonNil(snap.ForwardingBullets)
+	for player, g := range snap.ShipUidGenerators {
+		s.Arena.ShipUidGenerators[player] = g
+	}
+	// gob 不区分空集合与 nil，油井装载中的货轮需要补齐
+	for _, op := range s.Arena.OilPlatforms {
+		op.LoadingOilShips = nonNilMap(op.LoadingOilShips)
+	}
+	// 可见单位按恢复后的战场重新计算，避免沿用初始战舰的视野
+	s.Vision = MissionVisionState{
+		LastKnown: nonNilMap(snap.LastKnownShips),
+		Revealed:  snap.FogRevealed,
+	}
+	s.UpdateVision()
+	return s, nil
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func nonNilMap[K comparable, V any](items map[K]V) map[K]V {
+	if items == nil {
+		return map[K]V{}
+	}
+	return items
+}
@@ -1,11 +1,11 @@
 package state
 
 import (
-	"sort"
-
-	"github.com/samber/lo"
+	"fmt"
+	"math/rand/v2"
 
 	"github.com/narasux/jutland/pkg/common/constants"
+	"github.com/narasux/jutland/pkg/mission/clock"
 	"github.com/narasux/jutland/pkg/mission/faction"
 	"github.com/narasux/jutland/pkg/mission/metadata"
 	"github.com/narasux/jutland/pkg/mission/object"
@@ -46,6 +46,14 @@ type MissionCoreState struct {
 	ConfirmQuitMission bool
 	// 任务关卡元数据
 	MissionMD metadata.MissionMetadata
+	// 任务时钟（仅推进模拟时走时，暂停 / 终端模式下冻结）
+	Clock clock.Clock
+	// 随机种子 & 随机数源（通过 MissionState.Rand 使用）
+	Seed       int64
+	RandSource *rand.PCG
+	rand       *rand.Rand
+	// 电脑 AI 难度
+	Difficulty Difficulty
 }
 
 // MissionViewState 任务视图状态
@@ -60,11 +68,10 @@ type MissionViewState struct {
 type MissionPlayerState struct {
 	// 当前玩家
 	CurPlayer faction.Player
-	// 当前资金
-	CurFunds int64
-	// 当前敌人
-	// TODO 支持多个敌对势力
-	CurEnemy faction.Player
+	// 远程玩家（联机对战时由对方操控，单机时为空）
+	RemotePlayer faction.Player
+	// 各参战玩家的资金（中立势力没有资金）
+	Funds map[faction.Player]int64
 }
 
 // MissionInteractionState 任务交互状态
@@ -120,12 +127,37 @@ type MissionUIState struct {
 	ShowRallyLinePointUid string
 	// 集结点设置失败计数器（用于短暂闪烁提示）
 	RallySetFailedTick int
+	// 暂停面板中的保存进度结果
+	PauseSaveStatus PauseSaveStatus
+	// 联机对战时是否在等待对方的指令（此时模拟暂停推进）
+	WaitingForPeer bool
+	// 是否处于战术暂停（模拟暂停推进，仍可选择战舰 & 下达命令）
+	TacticalPaused bool
+	// 战术暂停时下达、尚未生效的战舰命令（按战舰 Uid 索引，用于绘制预览）
+	PlannedOrders map[string]*PlannedShipOrders
 	// 游戏选项
 	GameOpts GameOptions
 	// DebugFlags 调试标识
 	DebugFlags DebugFlags
 }
 
+// PlannedShipOrders 战舰尚未生效的命令 & 设置
+type PlannedShipOrders struct {
+	// 是否取代战舰现有的排队命令（否则追加到其后）
+	Replace bool
+	Orders  []objUnit.ShipOrder
+	// 尚未生效的设置叠加到战舰当前设置上的结果（没有暂存的设置时为 nil）
+	Settings *objUnit.ShipSettings
+}
+
+// ShipSettings 战舰的设置，战术暂停时包含尚未生效的设置（用于计算武器开关、交战规则等的下一次切换）
+func (s *MissionState) ShipSettings(ship *objUnit.BattleShip) objUnit.ShipSettings {
+	if planned, ok := s.UI.PlannedOrders[ship.Uid]; ok && planned.Settings != nil {
+		return *planned.Settings
+	}
+	return ship.Settings()
+}
+
 // MissionState 任务状态（包含地图，资源，进度，对象等）
 type MissionState struct {
 	Core        MissionCoreState
@@ -133,6 +165,7 @@ type MissionState struct {
 	Player      MissionPlayerState
 	Interaction MissionInteractionState
 	Arena       MissionArenaState
+	Vision      MissionVisionState
 	UI          MissionUIState
 }
 
@@ -143,38 +176,14 @@ func (s *MissionState) CameraPosBorder() (w float64, h float64) {
 	return w, h
 }
 
-// CountShips 对同类战舰进行计数
-func (s *MissionState) Fleet(player faction.Player) Fleet {
-	ships := lo.Filter(lo.Values(s.Arena.Ships), func(ship *objUnit.BattleShip, _ int) bool {
-		return ship.BelongPlayer == player
-	})
-
-	classMap := map[string]ShipClass{}
-	for _, ship := range ships {
-		if cls, ok := classMap[ship.Name]; ok {
-			cls.Total++
-			classMap[ship.Name] = cls
-		} else {
-			classMap[ship.Name] = ShipClass{Total: 1, Kind: ship}
-		}
-	}
-
-	classes := lo.Values(classMap)
-	// 按照吨位从大到小排列
-	sort.Slice(classes, func(i, j int) bool {
-		return classes[i].Kind.Tonnage > classes[j].Kind.Tonnage
-	})
-	return Fleet{Player: player, Total: len(ships), Classes: classes}
-}
-
 // NewMissionState ...
-func NewMissionState(mission string) *MissionState {
+func NewMissionState(mission string, seed int64) *MissionState {
 	missionMD := metadata.Get(mission)
 	misLayout := layout.NewScreenLayout()
 	// 初始化战舰 Uid 生成器
-	shipUidGenerators := map[faction.Player]*objUnit.ShipUidGenerator{
-		faction.HumanAlpha:    objUnit.NewShipUidGenerator(faction.HumanAlpha),
-		faction.ComputerAlpha: objUnit.NewShipUidGenerator(faction.ComputerAlpha),
+	shipUidGenerators := map[faction.Player]*objUnit.ShipUidGenerator{}
+	for _, player := range faction.AllPlayers {
+		shipUidGenerators[player] = objUnit.NewShipUidGenerator(player)
 	}
 	// 初始化战舰
 	ships := map[string]*objUnit.BattleShip{}
@@ -191,8 +200,9 @@ func NewMissionState(mission string) *MissionState {
 	// 初始化增援点
 	selectedReinforcePointUid := ""
 	reinforcePoints := map[string]*objBuilding.ReinforcePoint{}
-	for _, md := range missionMD.InitReinforcePoints {
+	for idx, md := range missionMD.InitReinforcePoints {
 		rp := objBuilding.NewReinforcePoint(
+			fmt.Sprintf("%s/rp-%d", md.BelongPlayer, idx),
 			md.Pos,
 			md.Rotation,
 			md.RallyPos,
@@ -218,6 +228,9 @@ func NewMissionState(mission string) *MissionState {
 			MissionStatus:      MissionRunning,
 			ConfirmQuitMission: false,
 			MissionMD:          missionMD,
+			Seed:               seed,
+			RandSource:         NewRandSource(seed),
+			Difficulty:         DifficultyNormal,
 		},
 		View: MissionViewState{
 			Layout: misLayout,
@@ -232,8 +245,7 @@ func NewMissionState(mission string) *MissionState {
 		},
 		Player: MissionPlayerState{
 			CurPlayer: faction.HumanAlpha,
-			CurFunds:  missionMD.InitFunds,
-			CurEnemy:  faction.ComputerAlpha,
+			Funds:     initFunds(missionMD),
 		},
 		Interaction: MissionInteractionState{
 			IsAreaSelecting:           false,
@@ -274,5 +286,6 @@ func NewMissionState(mission string) *MissionState {
 		},
 	}
 	ms.RefreshCameraSize()
+	ms.UpdateVision()
 	return ms
 }
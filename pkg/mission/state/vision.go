@@ -0,0 +1,169 @@
+package state
+
+import (
+	"github.com/narasux/jutland/pkg/mission/faction"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+// ReinforcePointDetectionRange 增援点探测范围（地图格）
+const ReinforcePointDetectionRange = 12
+
+// LastKnownFadeDuration 敌舰离开视野后，最后已知位置的淡出时长（任务时间，毫秒）
+const LastKnownFadeDuration int64 = 10 * 1000
+
+// LastKnownShip 敌舰最后已知位置（用于战争迷雾下的淡出展示）
+type LastKnownShip struct {
+	Uid string
+	// 战舰顶部图片名称 & 吨位（缩略地图展示用）
+	ImageName string
+	Tonnage   float64
+	// 最后一次看到时的位置 & 旋转角度
+	Pos      objPos.MapPos
+	Rotation float64
+	// 最后一次看到的任务时间（毫秒）
+	SeenAt int64
+}
+
+// Alpha 按离开视野的时长计算展示透明度（1 -> 刚离开视野，0 -> 完全淡出）
+func (s *LastKnownShip) Alpha(now int64) float64 {
+	return max(0, 1-float64(now-s.SeenAt)/float64(LastKnownFadeDuration))
+}
+
+// MissionVisionState 任务视野状态（战争迷雾）
+type MissionVisionState struct {
+	// 各玩家当前可见的非友军单位 Uid（nil 表示尚未计算，视为全部可见）
+	Visible map[faction.Player]map[string]bool
+	// 当前玩家视野中消失的敌舰最后已知位置（Key: Uid）
+	LastKnown map[string]*LastKnownShip
+	// 是否对当前玩家揭示全部地图（black sheep wall）
+	Revealed bool
+
+	// 各玩家的视野来源（战舰，战机，增援点，含同盟共享），每帧随可见单位一起更新
+	observers map[faction.Player][]visionObserver
+}
+
+// visionObserver 视野来源
+type visionObserver struct {
+	pos            objPos.MapPos
+	detectionRange float64
+}
+
+// UpdateVision 根据各单位的探测范围，更新各玩家的可见单位 & 当前玩家的敌舰最后已知位置
+// 注：需要在每帧模拟中调用，可见性会影响开火 / 出击目标的选择
+func (s *MissionState) UpdateVision() {
+	observers := map[faction.Player][]visionObserver{}
+	for _, ship := range s.Arena.Ships {
+		observers[ship.BelongPlayer] = append(observers[ship.BelongPlayer], visionObserver{
+			pos: ship.CurPos, detectionRange: ship.DetectionRange,
+		})
+	}
+	for _, plane := range s.Arena.Planes {
+		observers[plane.BelongPlayer] = append(observers[plane.BelongPlayer], visionObserver{
+			pos: plane.CurPos, detectionRange: plane.DetectionRange,
+		})
+	}
+	for _, rp := range s.Arena.ReinforcePoints {
+		observers[rp.BelongPlayer] = append(observers[rp.BelongPlayer], visionObserver{
+			pos: rp.Pos, detectionRange: ReinforcePointDetectionRange,
+		})
+	}
+
+	// 同盟内的玩家共享视野
+	observers = s.shareAlliedObservers(observers)
+
+	visible := map[faction.Player]map[string]bool{s.Player.CurPlayer: {}}
+	for player := range observers {
+		visible[player] = map[string]bool{}
+	}
+	for player, obs := range observers {
+		for _, ship := range s.Arena.Ships {
+			if !s.IsAlly(player, ship.BelongPlayer) && inObserversRange(obs, ship.CurPos) {
+				visible[player][ship.Uid] = true
+			}
+		}
+		for _, plane := range s.Arena.Planes {
+			if !s.IsAlly(player, plane.BelongPlayer) && inObserversRange(obs, plane.CurPos) {
+				visible[player][plane.Uid] = true
+			}
+		}
+	}
+
+	s.updateLastKnownShips(visible[s.Player.CurPlayer])
+	s.Vision.Visible = visible
+	s.Vision.observers = observers
+}
+
+// shareAlliedObservers 合并同盟内各玩家的视野来源
+func (s *MissionState) shareAlliedObservers(
+	observers map[faction.Player][]visionObserver,
+) map[faction.Player][]visionObserver {
+	shared := map[faction.Player][]visionObserver{}
+	for _, player := range faction.AllPlayers {
+		for _, ally := range faction.AllPlayers {
+			if s.IsAlly(player, ally) && len(observers[ally]) != 0 {
+				shared[player] = append(shared[player], observers[ally]...)
+			}
+		}
+	}
+	return shared
+}
+
+// updateLastKnownShips 记录刚离开当前玩家视野的敌舰位置，并清理重新可见 / 完全淡出的记录
+func (s *MissionState) updateLastKnownShips(curVisible map[string]bool) {
+	if s.Vision.LastKnown == nil {
+		s.Vision.LastKnown = map[string]*LastKnownShip{}
+	}
+	now := s.Core.Clock.Now()
+	for uid := range s.Vision.Visible[s.Player.CurPlayer] {
+		ship, ok := s.Arena.Ships[uid]
+		if !ok || curVisible[uid] {
+			continue
+		}
+		s.Vision.LastKnown[uid] = &LastKnownShip{
+			Uid:       uid,
+			ImageName: ship.CurrentTopImageName(),
+			Tonnage:   ship.Tonnage,
+			Pos:       ship.CurPos,
+			Rotation:  ship.CurRotation,
+			SeenAt:    now,
+		}
+	}
+	for uid, ship := range s.Vision.LastKnown {
+		if curVisible[uid] || ship.Alpha(now) <= 0 {
+			delete(s.Vision.LastKnown, uid)
+		}
+	}
+}
+
+// inObserversRange 判断位置是否在任意视野来源的探测范围内
+func inObserversRange(observers []visionObserver, pos objPos.MapPos) bool {
+	for _, ob := range observers {
+		if ob.pos.Distance(pos) <= ob.detectionRange {
+			return true
+		}
+	}
+	return false
+}
+
+// CanSee 判断玩家能否看到指定单位（己方 & 友军单位始终可见）
+func (s *MissionState) CanSee(player faction.Player, unit objUnit.BattleUnit) bool {
+	if s.IsAlly(player, unit.Player()) || s.Vision.Visible == nil {
+		return true
+	}
+	if s.Vision.Revealed && player == s.Player.CurPlayer {
+		return true
+	}
+	return s.Vision.Visible[player][unit.ID()]
+}
+
+// PosInVision 判断位置是否在玩家视野内（用于尾流，弹药等没有所属单位的对象）
+func (s *MissionState) PosInVision(player faction.Player, pos objPos.MapPos) bool {
+	if s.Vision.Visible == nil || (s.Vision.Revealed && player == s.Player.CurPlayer) {
+		return true
+	}
+	if s.Vision.observers == nil {
+		s.UpdateVision()
+	}
+	return inObserversRange(s.Vision.observers[player], pos)
+}
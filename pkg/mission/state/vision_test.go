@@ -0,0 +1,110 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/narasux/jutland/pkg/common/constants"
+	"github.com/narasux/jutland/pkg/mission/faction"
+	objPos "github.com/narasux/jutland/pkg/mission/object/position"
+	objUnit "github.com/narasux/jutland/pkg/mission/object/unit"
+)
+
+func newVisionTestState() *MissionState {
+	ally := &objUnit.BattleShip{
+		Uid: "ally", Name: "ally", DetectionRange: 10, CurPos: objPos.New(10, 10), BelongPlayer: faction.HumanAlpha,
+	}
+	enemy := &objUnit.BattleShip{
+		Uid: "enemy", Name: "enemy", DetectionRange: 10, CurPos: objPos.New(15, 10), BelongPlayer: faction.ComputerAlpha,
+	}
+	return &MissionState{
+		Player: MissionPlayerState{CurPlayer: faction.HumanAlpha},
+		Arena: MissionArenaState{
+			Ships:  map[string]*objUnit.BattleShip{ally.Uid: ally, enemy.Uid: enemy},
+			Planes: map[string]*objUnit.Plane{},
+		},
+	}
+}
+
+func TestCanSeeWithoutVisionTreatsAllVisible(t *testing.T) {
+	misState := newVisionTestState()
+	require.True(t, misState.CanSee(faction.HumanAlpha, misState.Arena.Ships["enemy"]))
+	require.True(t, misState.PosInVision(faction.HumanAlpha, objPos.New(90, 90)))
+}
+
+func TestUpdateVisionByDetectionRange(t *testing.T) {
+	misState := newVisionTestState()
+	enemy := misState.Arena.Ships["enemy"]
+
+	misState.UpdateVision()
+	require.True(t, misState.CanSee(faction.HumanAlpha, enemy))
+	require.True(t, misState.CanSee(faction.ComputerAlpha, misState.Arena.Ships["ally"]))
+	// 己方单位始终可见
+	require.True(t, misState.CanSee(faction.ComputerAlpha, enemy))
+
+	enemy.CurPos = objPos.New(40, 10)
+	misState.UpdateVision()
+	require.False(t, misState.CanSee(faction.HumanAlpha, enemy))
+	require.True(t, misState.PosInVision(faction.HumanAlpha, objPos.New(12, 12)))
+	require.False(t, misState.PosInVision(faction.HumanAlpha, objPos.New(40, 10)))
+
+	// 揭示地图只对当前玩家生效
+	misState.Vision.Revealed = true
+	require.True(t, misState.CanSee(faction.HumanAlpha, enemy))
+	require.False(t, misState.CanSee(faction.ComputerAlpha, misState.Arena.Ships["ally"]))
+}
+
+func TestLastKnownShipFadesOut(t *testing.T) {
+	misState := newVisionTestState()
+	enemy := misState.Arena.Ships["enemy"]
+	misState.UpdateVision()
+	require.Empty(t, misState.Vision.LastKnown)
+
+	// 离开视野时记录最后已知位置
+	enemy.CurPos = objPos.New(40, 10)
+	misState.UpdateVision()
+	lastKnown := misState.Vision.LastKnown["enemy"]
+	require.NotNil(t, lastKnown)
+	require.Equal(t, objPos.New(40, 10), lastKnown.Pos)
+	require.Equal(t, 1.0, lastKnown.Alpha(misState.Core.Clock.Now()))
+
+	// 继续移动不会更新最后已知位置，且随时间淡出
+	enemy.CurPos = objPos.New(50, 10)
+	misState.Core.Clock.ScaledTicks = constants.MaxTPS * 5
+	misState.UpdateVision()
+	require.Equal(t, objPos.New(40, 10), misState.Vision.LastKnown["enemy"].Pos)
+	require.InDelta(t, 0.5, misState.Vision.LastKnown["enemy"].Alpha(misState.Core.Clock.Now()), 0.01)
+
+	misState.Core.Clock.ScaledTicks = constants.MaxTPS * 11
+	misState.UpdateVision()
+	require.Empty(t, misState.Vision.LastKnown)
+
+	// 重新进入视野后，不再保留最后已知位置
+	enemy.CurPos = objPos.New(15, 10)
+	misState.UpdateVision()
+	enemy.CurPos = objPos.New(40, 10)
+	misState.UpdateVision()
+	require.NotEmpty(t, misState.Vision.LastKnown)
+	enemy.CurPos = objPos.New(15, 10)
+	misState.UpdateVision()
+	require.Empty(t, misState.Vision.LastKnown)
+}
+
+func TestAlliesShareVision(t *testing.T) {
+	misState := newVisionTestState()
+	misState.Core.MissionMD.Alliances = faction.Alliances{faction.HumanAlpha: 0, faction.HumanBeta: 0}
+	friend := &objUnit.BattleShip{
+		Uid: "friend", Name: "friend", DetectionRange: 10, CurPos: objPos.New(40, 10), BelongPlayer: faction.HumanBeta,
+	}
+	misState.Arena.Ships[friend.Uid] = friend
+	enemy := misState.Arena.Ships["enemy"]
+	enemy.CurPos = objPos.New(45, 10)
+
+	misState.UpdateVision()
+	// 友军的视野共享给当前玩家，友军单位始终可见
+	require.True(t, misState.CanSee(faction.HumanAlpha, enemy))
+	require.True(t, misState.CanSee(faction.HumanAlpha, friend))
+	require.True(t, misState.PosInVision(faction.HumanAlpha, objPos.New(42, 10)))
+	require.False(t, misState.Vision.Visible[faction.HumanAlpha]["friend"])
+}
@@ -0,0 +1,89 @@
+// sim 命令行无界面任务模拟
+package sim
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/jutland/pkg/mission/faction"
+	"github.com/narasux/jutland/pkg/mission/manager"
+	"github.com/narasux/jutland/pkg/mission/metadata"
+	_ "github.com/narasux/jutland/pkg/mission/object/initialize"
+	"github.com/narasux/jutland/pkg/mission/replay"
+	"github.com/narasux/jutland/pkg/mission/state"
+)
+
+// Command 子命令名称
+const Command = "sim"
+
+// 默认最大模拟帧数（按 60 TPS 计算约 10 分钟）
+const defaultMaxTicks = 36000
+
+// Run 执行无界面模拟，args 为子命令之后的参数
+// 示例：jutland sim --mission PearlHarbor1941 --ticks 36000 --record replays/sim.jrp
+func Run(args []string) error {
+	return run(args, os.Stdout)
+}
+
+func run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet(Command, flag.ContinueOnError)
+	fs.SetOutput(out)
+	mission := fs.String("mission", "", "mission name in configs/missions.json5")
+	ticks := fs.Int("ticks", defaultMaxTicks, "max ticks to simulate, <= 0 means unlimited")
+	seed := fs.Int64("seed", -1, "random seed, negative means generate a new one")
+	difficulty := fs.String("difficulty", string(state.DifficultyNormal), "computer AI difficulty: easy, normal, hard")
+	record := fs.String("record", "", "save the replay of the simulation to this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *mission == "" {
+		return errors.New("--mission is required")
+	}
+	if metadata.Get(*mission).Name == "" {
+		return errors.Errorf("mission %s not found", *mission)
+	}
+
+	d, err := state.ParseDifficulty(*difficulty)
+	if err != nil {
+		return err
+	}
+	if *seed < 0 {
+		*seed = state.NewRandomSeed()
+	}
+
+	mgr := manager.NewHeadless(*mission, *seed)
+	mgr.UseDifficulty(d)
+	summary := mgr.RunHeadless(*ticks)
+	printSummary(out, summary)
+
+	if *record != "" {
+		if err := replay.Save(*record, mgr.Replay()); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Replay : %s\n", *record)
+	}
+	return nil
+}
+
+// printSummary 输出模拟结果
+func printSummary(out io.Writer, summary manager.HeadlessSummary) {
+	fmt.Fprintf(
+		out, "Mission: %s\nSeed   : %d\nLevel  : %s\nStatus : %s\nTicks  : %d\n",
+		summary.Mission, summary.Seed, summary.Difficulty, summary.Status, summary.Ticks,
+	)
+	for _, p := range summary.Players {
+		fmt.Fprintf(
+			out, "[%s] ships: %d, tonnage: %.0f, hp: %.1f%%, lost ships: %d, lost planes: %d",
+			p.Player, p.Ships, p.Tonnage, p.RemainHPRate*100, p.LostShips, p.LostPlanes,
+		)
+		// 中立势力没有资金
+		if p.Player != faction.Neutral {
+			fmt.Fprintf(out, ", funds: %d", p.Funds)
+		}
+		fmt.Fprintln(out)
+	}
+}
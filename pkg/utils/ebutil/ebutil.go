@@ -98,3 +98,43 @@ func drawFlagFace(screen *ebiten.Image, x, y, width, height float32, clr color.C
 	opts.ColorScale.ScaleWithColor(clr)
 	vector.FillPath(screen, &path, nil, opts)
 }
+
+// DrawDashedLine 用分段线段绘制虚线
+func DrawDashedLine(
+	screen *ebiten.Image,
+	startX, startY, endX, endY float64,
+	dashLen, gapLen, strokeWidth float64,
+	clr color.Color,
+) {
+	dx := endX - startX
+	dy := endY - startY
+	totalDist := math.Sqrt(dx*dx + dy*dy)
+	if totalDist == 0 {
+		return
+	}
+	ux, uy := dx/totalDist, dy/totalDist
+	progress := 0.0
+	drawing := true
+
+	for progress < totalDist {
+		seg := dashLen
+		if !drawing {
+			seg = gapLen
+		}
+		if progress+seg > totalDist {
+			seg = totalDist - progress
+		}
+		if drawing {
+			segStartX := startX + ux*progress
+			segStartY := startY + uy*progress
+			vector.StrokeLine(
+				screen,
+				float32(segStartX), float32(segStartY),
+				float32(segStartX+ux*seg), float32(segStartY+uy*seg),
+				float32(strokeWidth), clr, false,
+			)
+		}
+		progress += seg
+		drawing = !drawing
+	}
+}